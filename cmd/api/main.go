@@ -8,7 +8,7 @@ import (
 	"github.com/0xpanadol/manga/internal/config"
 	"github.com/0xpanadol/manga/pkg/broker"
 	"github.com/0xpanadol/manga/pkg/logger"
-	"github.com/0xpanadol/manga/pkg/uploader"
+	"github.com/0xpanadol/manga/pkg/storage"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -17,6 +17,7 @@ import (
 
 	// Import the new postgres repository package with an alias
 	postgresrepo "github.com/0xpanadol/manga/internal/repository/postgres"
+	"github.com/0xpanadol/manga/internal/search"
 	"github.com/0xpanadol/manga/internal/service"
 	"github.com/0xpanadol/manga/internal/transport/http/handler"
 	"github.com/0xpanadol/manga/internal/transport/http/middleware"
@@ -87,19 +88,26 @@ func main() {
 	mangaRepo := postgresrepo.NewPostgresMangaRepository(dbpool)
 	chapterRepo := postgresrepo.NewPostgresChapterRepository(dbpool)
 	socialRepo := postgresrepo.NewPostgresSocialRepository(dbpool)
+	jobRepo := postgresrepo.NewPostgresJobRepository(dbpool)
+	coverRepo := postgresrepo.NewPostgresCoverRepository(dbpool)
+	creatorRepo := postgresrepo.NewPostgresCreatorRepository(dbpool)
+	tagRepo := postgresrepo.NewPostgresTagRepository(dbpool)
+	revisionRepo := postgresrepo.NewPostgresRevisionRepository(dbpool)
+	suggestionRepo := postgresrepo.NewPostgresSuggestionRepository(dbpool)
+
+	// === INITIALIZE OBJECT STORAGE ===
+	objectStorage, err := storage.New(cfg.StorageConfig())
+	if err != nil {
+		log.Fatalf("could not initialize object storage: %v", err)
+	}
+	log.Println("Object storage initialized")
 
-	// New: Initialize MinIO Uploader
-	minioUploader, err := uploader.NewMinioUploader(
-		cfg.MinioEndpoint,
-		cfg.MinioAccessKey,
-		cfg.MinioSecretKey,
-		cfg.MinioBucketName,
-		cfg.MinioUseSSL,
-	)
+	// === INITIALIZE SEARCH INDEX ===
+	searchIndex, err := search.New(cfg.SearchConfig(), mangaRepo)
 	if err != nil {
-		log.Fatalf("could not initialize minio uploader: %v", err)
+		log.Fatalf("could not initialize search index: %v", err)
 	}
-	log.Println("MinIO uploader initialized")
+	log.Println("Search index initialized")
 
 	authService := service.NewAuthService(
 		userRepo,
@@ -110,15 +118,32 @@ func main() {
 		cfg.JWTRefreshExpiresIn,
 	)
 	userService := service.NewUserService(userRepo)
-	mangaService := service.NewMangaService(mangaRepo, redisClient)
-	chapterService := service.NewChapterService(chapterRepo, minioUploader)
+	mangaService := service.NewMangaService(mangaRepo, creatorRepo, revisionRepo, searchIndex, messageBroker, redisClient)
+	chapterService := service.NewChapterService(chapterRepo, jobRepo, revisionRepo, objectStorage, messageBroker, cfg.ArchiveLimits())
 	socialService := service.NewSocialService(socialRepo)
+	jobService := service.NewJobService(jobRepo)
+	downloadService := service.NewDownloadService(chapterRepo, mangaRepo, jobRepo, objectStorage, messageBroker)
+	coverService := service.NewCoverService(coverRepo, mangaRepo, objectStorage, redisClient)
+	creatorService := service.NewCreatorService(creatorRepo, mangaRepo)
+	tagService := service.NewTagService(tagRepo, messageBroker, redisClient)
+	trashService := service.NewTrashService(mangaRepo, chapterRepo, revisionRepo, objectStorage, messageBroker)
+	suggestionService := service.NewSuggestionService(suggestionRepo, mangaRepo, mangaService)
+	chapterReviewService := service.NewChapterReviewService(chapterRepo, userRepo, revisionRepo, messageBroker)
 
 	authHandler := handler.NewAuthHandler(authService)
 	userHandler := handler.NewUserHandler(userService)
 	mangaHandler := handler.NewMangaHandler(mangaService)
-	chapterHandler := handler.NewChapterHandler(chapterService)
-	socialHandler := handler.NewSocialHandler(socialService)
+	chapterHandler := handler.NewChapterHandler(chapterService, mangaService)
+	socialHandler := handler.NewSocialHandler(socialService, mangaService, chapterService)
+	jobHandler := handler.NewJobHandler(jobService)
+	downloadHandler := handler.NewDownloadHandler(downloadService)
+	coverHandler := handler.NewCoverHandler(coverService, mangaService)
+	creatorHandler := handler.NewCreatorHandler(creatorService)
+	tagHandler := handler.NewTagHandler(tagService)
+	trashHandler := handler.NewTrashHandler(trashService)
+	historyHandler := handler.NewHistoryHandler(mangaService, chapterService)
+	suggestionHandler := handler.NewSuggestionHandler(suggestionService, mangaService)
+	chapterReviewHandler := handler.NewChapterReviewHandler(chapterReviewService)
 
 	// ROUTER
 	ginRouter := gin.Default()
@@ -131,6 +156,11 @@ func main() {
 	// === ADD NEW SYSTEM ENDPOINTS ===
 	ginRouter.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	// Serve locally stored media when the filesystem backend is in use
+	if local, ok := objectStorage.(*storage.LocalStorage); ok {
+		ginRouter.Static(local.URLPath(), local.Root())
+	}
+
 	// Add Swagger route
 	ginRouter.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -145,7 +175,18 @@ func main() {
 		mangaHandler,
 		chapterHandler,
 		socialHandler,
+		jobHandler,
+		downloadHandler,
+		coverHandler,
+		creatorHandler,
+		tagHandler,
+		trashHandler,
+		historyHandler,
+		suggestionHandler,
+		chapterReviewHandler,
+		userService,
 		cfg.JWTAccessSecret,
+		cfg.RequireIfMatch,
 	)
 
 	// SERVER
@@ -0,0 +1,184 @@
+// Command catalog exports the manga catalog, with genres, alternative titles and chapter
+// metadata, as NDJSON or CSV, and imports files in the same formats.
+//
+// Usage:
+//
+//	catalog export -out catalog.ndjson         # or .csv; stdout in NDJSON without -out
+//	catalog import -dry-run catalog.csv        # validate every row and report what would change
+//	catalog import catalog.csv
+//
+// Imports upsert manga by their external_id and chapters by number, so re-running one is
+// harmless. The format is taken from the file extension unless -format is given.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/0xpanadol/manga/internal/catalog"
+	"github.com/0xpanadol/manga/internal/config"
+	postgresrepo "github.com/0xpanadol/manga/internal/repository/postgres"
+	"github.com/0xpanadol/manga/internal/search"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/0xpanadol/manga/pkg/broker"
+	"github.com/0xpanadol/manga/pkg/storage"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: catalog export [-format ndjson|csv] [-out file]")
+	fmt.Fprintln(os.Stderr, "       catalog import [-format ndjson|csv] [-dry-run] file")
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	switch os.Args[1] {
+	case "export":
+		runExport(os.Args[2:])
+	case "import":
+		runImport(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func runExport(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	formatFlag := flags.String("format", "", "ndjson or csv; taken from the extension of -out if omitted")
+	outFlag := flags.String("out", "", "file to write the catalog to instead of stdout")
+	flags.Parse(args)
+
+	format := catalog.FormatNDJSON
+	if *formatFlag != "" || *outFlag != "" {
+		var err error
+		if format, err = catalog.ParseFormat(*formatFlag, *outFlag); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if *outFlag != "" {
+		f, err := os.Create(*outFlag)
+		if err != nil {
+			log.Fatalf("could not create %s: %v", *outFlag, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	c, cleanup := newCatalog(ctx)
+	defer cleanup()
+
+	count, err := c.Export(ctx, catalog.NewWriter(out, format))
+	if err != nil {
+		log.Fatalf("export failed after %d manga: %v", count, err)
+	}
+	log.Printf("exported %d manga", count)
+}
+
+func runImport(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	formatFlag := flags.String("format", "", "ndjson or csv; taken from the file extension if omitted")
+	dryRun := flags.Bool("dry-run", false, "validate every row and report what would be imported without writing anything")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		usage()
+	}
+	path := flags.Arg(0)
+	format, err := catalog.ParseFormat(*formatFlag, path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("could not open %s: %v", path, err)
+	}
+	rows, err := catalog.ReadRows(f, format)
+	f.Close()
+	if err != nil {
+		log.Fatalf("could not read %s: %v", path, err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	c, cleanup := newCatalog(ctx)
+	defer cleanup()
+
+	log.Printf("Importing %d rows from %s", len(rows), path)
+	report, err := c.Import(ctx, rows, *dryRun)
+	if err != nil {
+		log.Fatalf("import failed: %v", err)
+	}
+
+	fmt.Println()
+	report.Print(os.Stdout)
+	if report.Count(catalog.StatusFailed) > 0 {
+		os.Exit(1)
+	}
+}
+
+// newCatalog connects to everything the manga and chapter services need, which publish events
+// and keep the cache and search index in sync like the API does.
+func newCatalog(ctx context.Context) (*catalog.Catalog, func()) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("could not load or validate config: %v", err)
+	}
+
+	dbpool, err := pgxpool.New(ctx, cfg.DBUrl)
+	if err != nil {
+		log.Fatalf("unable to connect to database: %v", err)
+	}
+
+	redisClient := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})
+	if _, err := redisClient.Ping(ctx).Result(); err != nil {
+		log.Fatalf("could not connect to redis: %v", err)
+	}
+
+	messageBroker, err := broker.NewRabbitMQBroker(cfg.RabbitMQUrl)
+	if err != nil {
+		log.Fatalf("could not initialize message broker: %v", err)
+	}
+
+	objectStorage, err := storage.New(cfg.StorageConfig())
+	if err != nil {
+		log.Fatalf("could not initialize object storage: %v", err)
+	}
+
+	mangaRepo := postgresrepo.NewPostgresMangaRepository(dbpool)
+	searchIndex, err := search.New(cfg.SearchConfig(), mangaRepo)
+	if err != nil {
+		log.Fatalf("could not initialize search index: %v", err)
+	}
+
+	creatorRepo := postgresrepo.NewPostgresCreatorRepository(dbpool)
+	chapterRepo := postgresrepo.NewPostgresChapterRepository(dbpool)
+	jobRepo := postgresrepo.NewPostgresJobRepository(dbpool)
+	tagRepo := postgresrepo.NewPostgresTagRepository(dbpool)
+	revisionRepo := postgresrepo.NewPostgresRevisionRepository(dbpool)
+
+	mangaService := service.NewMangaService(mangaRepo, creatorRepo, revisionRepo, searchIndex, messageBroker, redisClient)
+	chapterService := service.NewChapterService(chapterRepo, jobRepo, revisionRepo, objectStorage, messageBroker, cfg.ArchiveLimits())
+	tagService := service.NewTagService(tagRepo, messageBroker, redisClient)
+
+	cleanup := func() {
+		messageBroker.Close()
+		redisClient.Close()
+		dbpool.Close()
+	}
+	return catalog.New(mangaService, chapterService, tagService), cleanup
+}
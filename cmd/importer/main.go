@@ -0,0 +1,116 @@
+// Command importer bulk-loads chapters for a manga from a directory tree or a manifest.
+//
+// Usage:
+//
+//	importer -manga <id> -dir ./series        # one folder or .cbz/.zip per chapter
+//	importer -manifest ./series/manifest.yaml # explicit chapter list (JSON or YAML)
+//
+// Chapters that already have pages are skipped, so an interrupted import can be re-run.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/0xpanadol/manga/internal/config"
+	"github.com/0xpanadol/manga/internal/importer"
+	postgresrepo "github.com/0xpanadol/manga/internal/repository/postgres"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/0xpanadol/manga/pkg/broker"
+	"github.com/0xpanadol/manga/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func main() {
+	mangaFlag := flag.String("manga", "", "ID of the manga to import into (may also be set in the manifest)")
+	dirFlag := flag.String("dir", "", "directory containing one folder or .cbz/.zip archive per chapter")
+	manifestFlag := flag.String("manifest", "", "JSON or YAML manifest listing the chapters to import")
+	concurrency := flag.Int("concurrency", 4, "number of chapters to import at once")
+	dryRun := flag.Bool("dry-run", false, "validate the sources and report what would be imported without writing anything")
+	publish := flag.Bool("publish", false, "publish new chapters immediately instead of creating drafts")
+	flag.Parse()
+
+	if (*dirFlag == "") == (*manifestFlag == "") {
+		log.Fatal("exactly one of -dir or -manifest is required")
+	}
+
+	var chapters []importer.Chapter
+	mangaIDStr := *mangaFlag
+	if *manifestFlag != "" {
+		manifest, err := importer.LoadManifest(*manifestFlag)
+		if err != nil {
+			log.Fatalf("could not load manifest: %v", err)
+		}
+		chapters = manifest.Chapters
+		if mangaIDStr == "" {
+			mangaIDStr = manifest.MangaID
+		}
+	} else {
+		var err error
+		chapters, err = importer.ScanDir(*dirFlag)
+		if err != nil {
+			log.Fatalf("could not scan directory: %v", err)
+		}
+	}
+
+	mangaID, err := uuid.Parse(mangaIDStr)
+	if err != nil {
+		log.Fatal("a valid manga ID is required, via -manga or the manifest")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("could not load or validate config: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbpool, err := pgxpool.New(ctx, cfg.DBUrl)
+	if err != nil {
+		log.Fatalf("unable to connect to database: %v", err)
+	}
+	defer dbpool.Close()
+
+	objectStorage, err := storage.New(cfg.StorageConfig())
+	if err != nil {
+		log.Fatalf("could not initialize object storage: %v", err)
+	}
+
+	mangaRepo := postgresrepo.NewPostgresMangaRepository(dbpool)
+	if _, err := mangaRepo.FindByID(ctx, mangaID); err != nil {
+		log.Fatalf("could not find manga %s: %v", mangaID, err)
+	}
+
+	// Needed for the chapter.published events of chapters created with -publish
+	messageBroker, err := broker.NewRabbitMQBroker(cfg.RabbitMQUrl)
+	if err != nil {
+		log.Fatalf("could not initialize message broker: %v", err)
+	}
+	defer messageBroker.Close()
+
+	chapterRepo := postgresrepo.NewPostgresChapterRepository(dbpool)
+	jobRepo := postgresrepo.NewPostgresJobRepository(dbpool)
+	revisionRepo := postgresrepo.NewPostgresRevisionRepository(dbpool)
+	chapterService := service.NewChapterService(chapterRepo, jobRepo, revisionRepo, objectStorage, messageBroker, cfg.ArchiveLimits())
+
+	log.Printf("Importing %d chapters into manga %s", len(chapters), mangaID)
+	report := importer.New(chapterService, importer.Options{
+		Concurrency: *concurrency,
+		DryRun:      *dryRun,
+		Publish:     *publish,
+		Limits:      cfg.ArchiveLimits(),
+	}).Run(ctx, mangaID, chapters)
+
+	fmt.Println()
+	report.Print(os.Stdout)
+	if report.Count(importer.StatusFailed) > 0 {
+		os.Exit(1)
+	}
+}
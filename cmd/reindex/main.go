@@ -0,0 +1,59 @@
+// Command reindex rebuilds the search index from the catalog, e.g. after switching the search
+// engine or when the index has fallen out of sync.
+//
+// Usage:
+//
+//	reindex -batch 500
+//
+// Manga are upserted, so re-running it is harmless. With the Postgres engine there is nothing
+// to rebuild and it only counts the manga.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/0xpanadol/manga/internal/config"
+	postgresrepo "github.com/0xpanadol/manga/internal/repository/postgres"
+	"github.com/0xpanadol/manga/internal/search"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func main() {
+	batchSize := flag.Int("batch", 500, "number of manga to index at once")
+	flag.Parse()
+
+	if *batchSize < 1 {
+		log.Fatal("-batch must be at least 1")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("could not load or validate config: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbpool, err := pgxpool.New(ctx, cfg.DBUrl)
+	if err != nil {
+		log.Fatalf("unable to connect to database: %v", err)
+	}
+	defer dbpool.Close()
+
+	mangaRepo := postgresrepo.NewPostgresMangaRepository(dbpool)
+	index, err := search.New(cfg.SearchConfig(), mangaRepo)
+	if err != nil {
+		log.Fatalf("could not initialize search index: %v", err)
+	}
+
+	count, err := search.NewIndexer(index, mangaRepo).Reindex(ctx, *batchSize)
+	if err != nil {
+		log.Fatalf("reindex failed after %d manga: %v", count, err)
+	}
+	log.Printf("indexed %d manga", count)
+}
@@ -1,18 +1,27 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"html"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/0xpanadol/manga/internal/config"
+	"github.com/0xpanadol/manga/internal/domain"
+	postgresrepo "github.com/0xpanadol/manga/internal/repository/postgres"
+	"github.com/0xpanadol/manga/internal/search"
 	"github.com/0xpanadol/manga/internal/service"
 	"github.com/0xpanadol/manga/pkg/broker"
 	"github.com/0xpanadol/manga/pkg/email"
 	"github.com/0xpanadol/manga/pkg/logger"
+	"github.com/0xpanadol/manga/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rabbitmq/amqp091-go"
 	"go.uber.org/zap"
 )
@@ -35,6 +44,32 @@ func main() {
 	defer messageBroker.Close()
 	appLogger.Info("Message broker connected")
 
+	dbpool, err := pgxpool.New(context.Background(), cfg.DBUrl)
+	if err != nil {
+		appLogger.Fatal("Unable to connect to database", zap.Error(err))
+	}
+	defer dbpool.Close()
+	appLogger.Info("Database connection established")
+
+	objectStorage, err := storage.New(cfg.StorageConfig())
+	if err != nil {
+		appLogger.Fatal("Could not initialize object storage", zap.Error(err))
+	}
+
+	chapterRepo := postgresrepo.NewPostgresChapterRepository(dbpool)
+	jobRepo := postgresrepo.NewPostgresJobRepository(dbpool)
+	mangaRepo := postgresrepo.NewPostgresMangaRepository(dbpool)
+	revisionRepo := postgresrepo.NewPostgresRevisionRepository(dbpool)
+	chapterService := service.NewChapterService(chapterRepo, jobRepo, revisionRepo, objectStorage, messageBroker, cfg.ArchiveLimits())
+	downloadService := service.NewDownloadService(chapterRepo, mangaRepo, jobRepo, objectStorage, messageBroker)
+	trashService := service.NewTrashService(mangaRepo, chapterRepo, revisionRepo, objectStorage, messageBroker)
+
+	searchIndex, err := search.New(cfg.SearchConfig(), mangaRepo)
+	if err != nil {
+		appLogger.Fatal("Could not initialize search index", zap.Error(err))
+	}
+	searchIndexer := search.NewIndexer(searchIndex, mangaRepo)
+
 	// === Initialize Email Sender ===
 	emailSender := email.NewSMTPSender(
 		cfg.SmtpHost,
@@ -95,13 +130,176 @@ func main() {
 		d.Ack(false)
 	}
 
-	// Start consumers for both queues
+	// === Handler for chapter reviews, telling the uploader the decision ===
+	chapterReviewedHandler := func(d amqp091.Delivery) {
+		var payload service.ChapterReviewedPayload
+		if err := json.Unmarshal(d.Body, &payload); err != nil {
+			appLogger.Error("Failed to unmarshal message body", zap.Error(err))
+			d.Nack(false, false)
+			return
+		}
+
+		title := html.EscapeString(payload.MangaTitle)
+		number := html.EscapeString(payload.ChapterNumber)
+		var subject, body string
+		if payload.Status == string(domain.ChapterReviewApproved) {
+			subject = fmt.Sprintf("Your chapter %s of %s was approved", payload.ChapterNumber, payload.MangaTitle)
+			body = fmt.Sprintf("Hi %s,<br><br>Your chapter %s of %s was approved. Thank you for uploading it!", html.EscapeString(payload.Username), number, title)
+		} else {
+			subject = fmt.Sprintf("Your chapter %s of %s was rejected", payload.ChapterNumber, payload.MangaTitle)
+			body = fmt.Sprintf("Hi %s,<br><br>Your chapter %s of %s was rejected for the following reason:<br><br>%s", html.EscapeString(payload.Username), number, title, html.EscapeString(payload.Reason))
+		}
+
+		if err := emailSender.SendEmail(payload.Email, subject, body); err != nil {
+			appLogger.Error("Failed to send chapter review email", zap.Error(err), zap.String("recipient", payload.Email))
+			d.Nack(false, false)
+			return
+		}
+
+		appLogger.Info("Sent chapter review email", zap.String("chapter_id", payload.ChapterID), zap.String("status", payload.Status))
+		d.Ack(false)
+	}
+
+	// === Handler for chapter archive jobs ===
+	chapterArchiveHandler := func(d amqp091.Delivery) {
+		var payload service.JobQueuedPayload
+		if err := json.Unmarshal(d.Body, &payload); err != nil {
+			appLogger.Error("Failed to unmarshal message body", zap.Error(err))
+			d.Nack(false, false)
+			return
+		}
+		jobID, err := uuid.Parse(payload.JobID)
+		if err != nil {
+			appLogger.Error("Invalid job ID in message", zap.String("job_id", payload.JobID))
+			d.Nack(false, false)
+			return
+		}
+
+		// Failures are recorded on the job itself, so the message is acknowledged either way.
+		if err := chapterService.ProcessArchiveJob(context.Background(), jobID); err != nil {
+			appLogger.Error("Chapter archive job failed", zap.Error(err), zap.String("job_id", payload.JobID))
+		} else {
+			appLogger.Info("Chapter archive job completed", zap.String("job_id", payload.JobID))
+		}
+		d.Ack(false)
+	}
+
+	// === Handler for volume download bundles ===
+	volumeBundleHandler := func(d amqp091.Delivery) {
+		var payload service.JobQueuedPayload
+		if err := json.Unmarshal(d.Body, &payload); err != nil {
+			appLogger.Error("Failed to unmarshal message body", zap.Error(err))
+			d.Nack(false, false)
+			return
+		}
+		jobID, err := uuid.Parse(payload.JobID)
+		if err != nil {
+			appLogger.Error("Invalid job ID in message", zap.String("job_id", payload.JobID))
+			d.Nack(false, false)
+			return
+		}
+
+		// As with archives, failures are recorded on the job and the message is acknowledged.
+		if err := downloadService.ProcessVolumeJob(context.Background(), jobID); err != nil {
+			appLogger.Error("Volume bundle job failed", zap.Error(err), zap.String("job_id", payload.JobID))
+		} else {
+			appLogger.Info("Volume bundle job completed", zap.String("job_id", payload.JobID))
+		}
+		d.Ack(false)
+	}
+
+	// === Handler for manga changes, keeping the search index in sync ===
+	mangaChangedHandler := func(d amqp091.Delivery) {
+		var payload service.MangaChangedPayload
+		if err := json.Unmarshal(d.Body, &payload); err != nil {
+			appLogger.Error("Failed to unmarshal message body", zap.Error(err))
+			d.Nack(false, false)
+			return
+		}
+		mangaID, err := uuid.Parse(payload.MangaID)
+		if err != nil {
+			appLogger.Error("Invalid manga ID in message", zap.String("manga_id", payload.MangaID))
+			d.Nack(false, false)
+			return
+		}
+
+		// A failed sync is only logged; the next change or a full reindex catches the manga up.
+		if err := searchIndexer.Sync(context.Background(), mangaID); err != nil {
+			appLogger.Error("Failed to sync manga to the search index", zap.Error(err), zap.String("manga_id", payload.MangaID))
+		}
+		d.Ack(false)
+	}
+
+	// Start consumers for all queues
 	if err := messageBroker.Consume("user.registered", userRegisteredHandler); err != nil {
 		appLogger.Fatal("Failed to start user.registered consumer", zap.Error(err))
 	}
 	if err := messageBroker.Consume("password.reset.requested", passwordResetHandler); err != nil {
 		appLogger.Fatal("Failed to start password.reset.requested consumer", zap.Error(err))
 	}
+	if err := messageBroker.Consume(service.ChapterReviewedEvent, chapterReviewedHandler); err != nil {
+		appLogger.Fatal("Failed to start "+service.ChapterReviewedEvent+" consumer", zap.Error(err))
+	}
+	if err := messageBroker.Consume("chapter.archive.uploaded", chapterArchiveHandler); err != nil {
+		appLogger.Fatal("Failed to start chapter.archive.uploaded consumer", zap.Error(err))
+	}
+	if err := messageBroker.Consume("volume.bundle.requested", volumeBundleHandler); err != nil {
+		appLogger.Fatal("Failed to start volume.bundle.requested consumer", zap.Error(err))
+	}
+	for _, queue := range []string{service.MangaCreatedEvent, service.MangaUpdatedEvent, service.MangaDeletedEvent} {
+		if err := messageBroker.Consume(queue, mangaChangedHandler); err != nil {
+			appLogger.Fatal("Failed to start "+queue+" consumer", zap.Error(err))
+		}
+	}
+
+	// === Scheduler for chapters due to be published ===
+	publishInterval := cfg.PublishInterval
+	if publishInterval <= 0 {
+		publishInterval = time.Minute
+	}
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go func() {
+		ticker := time.NewTicker(publishInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-schedulerCtx.Done():
+				return
+			case <-ticker.C:
+				count, err := chapterService.PublishDue(schedulerCtx)
+				if err != nil {
+					appLogger.Error("Failed to publish scheduled chapters", zap.Error(err))
+				} else if count > 0 {
+					appLogger.Info("Published scheduled chapters", zap.Int("count", count))
+				}
+			}
+		}
+	}()
+
+	// === Purge of the trash once the retention period has passed ===
+	trashRetention := cfg.TrashRetention
+	if trashRetention <= 0 {
+		trashRetention = 30 * 24 * time.Hour
+	}
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-schedulerCtx.Done():
+				return
+			case <-ticker.C:
+				count, err := trashService.Purge(schedulerCtx, time.Now().Add(-trashRetention))
+				if err != nil {
+					appLogger.Error("Failed to purge the trash", zap.Error(err))
+				}
+				if count > 0 {
+					appLogger.Info("Purged deleted manga and chapters", zap.Int("count", count))
+				}
+			}
+		}
+	}()
 
 	// Wait for termination signal to gracefully shut down
 	quit := make(chan os.Signal, 1)
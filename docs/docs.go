@@ -146,30 +146,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/chapters/{id}": {
+        "/authors": {
             "get": {
-                "description": "Retrieves details for a single chapter.",
+                "description": "Lists authors and artists by name.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Chapters"
+                    "Authors"
                 ],
-                "summary": "Get a single chapter by ID",
+                "summary": "List creators",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (at most 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Chapter ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by part of the name",
+                        "name": "q",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Chapter"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Creator"
+                            }
                         }
                     },
                     "400": {
@@ -181,15 +197,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -201,13 +208,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the details of a specific chapter. Requires 'chapters:manage' permission.",
+                "description": "Adds an author or artist who can then be credited on manga. Requires 'manga:manage' permission.",
                 "consumes": [
                     "application/json"
                 ],
@@ -215,32 +222,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chapters"
+                    "Authors"
                 ],
-                "summary": "Update a chapter",
+                "summary": "Create a creator",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Chapter ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Chapter Update Info",
+                        "description": "Creator Info",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.createChapterRequest"
+                            "$ref": "#/definitions/handler.createCreatorRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Chapter"
+                            "$ref": "#/definitions/domain.Creator"
                         }
                     },
                     "400": {
@@ -270,8 +270,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -289,51 +289,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+            }
+        },
+        "/authors/{id}": {
+            "get": {
+                "description": "Retrieves an author or artist with their bibliography. Each work lists its credits, showing the creator's roles. Works with content ratings the user hasn't opted into are left out.",
+                "produces": [
+                    "application/json"
                 ],
-                "description": "Deletes a specific chapter. Requires 'chapters:manage' permission.",
                 "tags": [
-                    "Chapters"
+                    "Authors"
                 ],
-                "summary": "Delete a chapter",
+                "summary": "Get a creator",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Chapter ID",
+                        "description": "Creator ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred display languages, comma-separated (e.g., en,ja-ro)",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/service.CreatorProfile"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -362,16 +353,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/chapters/{id}/comments": {
+        "/chapters/{id}": {
             "get": {
-                "description": "Retrieves a paginated list of comments for a specific chapter.",
+                "description": "Retrieves details for a single chapter. Chapters that aren't published are only visible to users with 'chapters:manage' and to their uploader.\nChapters of manga with a content rating the user hasn't opted into are forbidden.\nThe ETag header holds the chapter's version: send it as If-None-Match to revalidate a copy, or as If-Match to update or delete the chapter.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Social"
+                    "Chapters"
                 ],
-                "summary": "List chapter comments",
+                "summary": "Get a single chapter by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -381,30 +372,22 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Items per page",
-                        "name": "per_page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.CommentWithUser"
-                            }
+                            "$ref": "#/definitions/domain.Chapter"
                         }
                     },
+                    "304": {
+                        "description": "Not Modified"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -414,6 +397,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -425,13 +426,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Adds a new comment to a specific chapter.",
+                "description": "Updates the details of a specific chapter. Pages are kept unless given. Requires 'chapters:manage' permission.",
                 "consumes": [
                     "application/json"
                 ],
@@ -439,9 +440,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Social"
+                    "Chapters"
                 ],
-                "summary": "Post a comment on a chapter",
+                "summary": "Update a chapter",
                 "parameters": [
                     {
                         "type": "string",
@@ -451,20 +452,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Comment Content",
+                        "type": "string",
+                        "description": "ETag of the chapter as last read",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Chapter Update Info",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.createCommentRequest"
+                            "$ref": "#/definitions/handler.createChapterRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Comment"
+                            "$ref": "#/definitions/domain.Chapter"
                         }
                     },
                     "400": {
@@ -485,6 +492,42 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -495,26 +538,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/chapters/{id}/pages": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Uploads one or more image files for a chapter. Requires 'chapters:manage' permission.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Moves a specific chapter to the trash, from which it can be restored until it is purged. Requires 'chapters:manage' permission.",
                 "tags": [
                     "Chapters"
                 ],
-                "summary": "Upload chapter pages",
+                "summary": "Delete a chapter",
                 "parameters": [
                     {
                         "type": "string",
@@ -524,22 +559,15 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Image files for the chapter pages. Can be sent multiple times.",
-                        "name": "pages",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "ETag of the chapter as last read",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -577,6 +605,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -587,20 +633,24 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/chapters/{id}/progress": {
-            "post": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Marks a chapter as read for the current user.",
+                "description": "Applies a JSON Merge Patch (RFC 7386) to the chapter: only the fields in the body change and null clears an optional field.\nPages are replaced as a whole when given. Requires 'chapters:manage' permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Social"
+                    "Chapters"
                 ],
-                "summary": "Mark chapter as read",
+                "summary": "Partially update a chapter",
                 "parameters": [
                     {
                         "type": "string",
@@ -608,14 +658,32 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    {
+                        "type": "string",
+                        "description": "ETag of the chapter as last read",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.createChapterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Chapter"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -632,6 +700,51 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -644,17 +757,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/manga": {
+        "/chapters/{id}/comments": {
             "get": {
-                "description": "Retrieves a paginated and filtered list of manga.",
+                "description": "Retrieves a paginated list of comments for a specific chapter. Comments on unpublished chapters are only listed for their uploader and users with 'chapters:manage'; comments on chapters of manga with a content rating the user hasn't opted into are forbidden.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Manga"
+                    "Social"
                 ],
-                "summary": "List manga",
+                "summary": "List chapter comments",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chapter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -665,38 +785,14 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "default": 20,
-                        "description": "Items per page",
+                        "description": "Items per page (at most 100)",
                         "name": "per_page",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Full-text search query for title and description",
-                        "name": "q",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by comma-separated genre names (e.g., Action,Fantasy)",
-                        "name": "genres",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "ongoing",
-                            "completed",
-                            "hiatus",
-                            "cancelled"
-                        ],
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort order (e.g., title, -created_at)",
-                        "name": "sort",
+                        "description": "next_cursor of the previous page, to continue right after it",
+                        "name": "cursor",
                         "in": "query"
                     }
                 ],
@@ -704,10 +800,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Manga"
-                            }
+                            "$ref": "#/definitions/handler.listResponse-domain_CommentWithUser"
                         }
                     },
                     "400": {
@@ -719,6 +812,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -736,7 +847,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Adds a new manga to the catalog. Requires 'manga:manage' permission.",
+                "description": "Adds a new comment to a specific chapter. Unpublished chapters can only be commented on by their uploader or users with 'chapters:manage', and chapters of manga with a content rating the user hasn't opted into not at all.",
                 "consumes": [
                     "application/json"
                 ],
@@ -744,17 +855,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Manga"
+                    "Social"
                 ],
-                "summary": "Create a new manga",
+                "summary": "Post a comment on a chapter",
                 "parameters": [
                     {
-                        "description": "Manga Creation Info",
+                        "type": "string",
+                        "description": "Chapter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment Content",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.createMangaRequest"
+                            "$ref": "#/definitions/handler.createCommentRequest"
                         }
                     }
                 ],
@@ -762,7 +880,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Manga"
+                            "$ref": "#/definitions/domain.Comment"
                         }
                     },
                     "400": {
@@ -792,6 +910,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -804,30 +931,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/manga/{id}": {
+        "/chapters/{id}/download": {
             "get": {
-                "description": "Retrieves details for a single manga, including its genres.",
+                "description": "Streams the chapter's pages as a CBZ (with ComicInfo.xml), EPUB or PDF file. Only published chapters can be downloaded, unless the user has 'chapters:manage'.",
                 "produces": [
-                    "application/json"
+                    "application/vnd.comicbook+zip",
+                    "application/epub+zip",
+                    "application/pdf"
                 ],
                 "tags": [
-                    "Manga"
+                    "Downloads"
                 ],
-                "summary": "Get a single manga by ID",
+                "summary": "Download a chapter",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Manga ID",
+                        "description": "Chapter ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "cbz",
+                            "epub",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "default": "cbz",
+                        "description": "Download format",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Manga"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -839,6 +980,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -860,36 +1010,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/manga/{id}/comments": {
-            "get": {
-                "description": "Retrieves a paginated list of comments for a specific manga.",
+        "/chapters/{id}/pages": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Uploads one or more image files for a chapter, or a single CBZ/ZIP archive whose images replace the chapter's pages.\nArchives can be processed asynchronously by the worker with async=true; poll the returned job via GET /jobs/{id}.\nRequires 'chapters:manage' permission, or 'chapters:submit' for the user's own chapters while they are pending review.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Social"
+                    "Chapters"
                 ],
-                "summary": "List manga comments",
+                "summary": "Upload chapter pages",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Manga ID",
+                        "description": "Chapter ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "type": "file",
+                        "description": "Image files for the chapter pages. Can be sent multiple times.",
+                        "name": "pages",
+                        "in": "formData"
                     },
                     {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Items per page",
-                        "name": "per_page",
+                        "type": "file",
+                        "description": "CBZ/ZIP archive containing the chapter pages",
+                        "name": "archive",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Process the archive in the background",
+                        "name": "async",
                         "in": "query"
                     }
                 ],
@@ -897,12 +1059,18 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.CommentWithUser"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Job"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -912,6 +1080,42 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -922,48 +1126,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/chapters/{id}/progress": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Adds a new comment to a specific manga.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Marks a chapter as read for the current user. Unpublished chapters can only be marked by their uploader or users with 'chapters:manage', and chapters of manga with a content rating the user hasn't opted into not at all.",
                 "tags": [
                     "Social"
                 ],
-                "summary": "Post a comment on a manga",
+                "summary": "Mark chapter as read",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Manga ID",
+                        "description": "Chapter ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Comment Content",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.createCommentRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/domain.Comment"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -983,6 +1171,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -995,25 +1201,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/manga/{id}/favorite": {
-            "post": {
+        "/jobs/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Adds or removes a manga from the current user's favorites list.",
+                "description": "Retrieves the status and result of a job processed by the worker. Only the job's creator or a user with 'chapters:manage' may view it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Social"
+                    "Jobs"
                 ],
-                "summary": "Toggle manga favorite status",
+                "summary": "Get a background job",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Manga ID",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1023,7 +1229,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/repository.ToggleFavoriteResult"
+                            "$ref": "#/definitions/domain.Job"
                         }
                     },
                     "400": {
@@ -1044,6 +1250,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1056,24 +1271,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/manga/{manga_id}/chapters": {
+        "/manga": {
             "get": {
-                "description": "Retrieves a paginated list of chapters for a specific manga.",
+                "description": "Retrieves a paginated and filtered list of manga. Only manga with content ratings the user has opted into are listed (only safe for logged-out visitors).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Chapters"
+                    "Manga"
                 ],
-                "summary": "List chapters for a manga",
+                "summary": "List manga",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Manga ID",
-                        "name": "manga_id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -1084,19 +1292,153 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "default": 20,
-                        "description": "Items per page",
+                        "description": "Items per page (at most 100)",
                         "name": "per_page",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, to continue right after it. Faster than page on deep pages; needs the same sort",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search the title, alternative titles and description. Matches word prefixes and tolerates typos in titles",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "en",
+                        "description": "Language of q, for stemming and stop words (e.g., en, fr)",
+                        "name": "search_language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by comma-separated tag names or slugs (e.g., Action,slice-of-life)",
+                        "name": "genres",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "all",
+                            "any"
+                        ],
+                        "type": "string",
+                        "default": "all",
+                        "description": "Whether manga must have all or any of the genres",
+                        "name": "genres_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hide manga with these comma-separated tag names or slugs",
+                        "name": "exclude_genres",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Hide manga with any of the excluded genres, or only those with all of them",
+                        "name": "exclude_mode",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ongoing",
+                            "completed",
+                            "hiatus",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by comma-separated content ratings (safe, suggestive, explicit), within the user's preferences",
+                        "name": "content_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by creator ID (author or artist)",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by comma-separated demographics (shounen, shoujo, seinen, josei)",
+                        "name": "demographic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only manga that started in or after this year",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only manga that started in or before this year",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by original language (e.g., ja, ko)",
+                        "name": "original_language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Look up by MyAnimeList ID",
+                        "name": "mal_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Look up by AniList ID",
+                        "name": "anilist_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Look up by MangaUpdates ID",
+                        "name": "mangaupdates_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (e.g., relevance, title, -year, -created_at). Searches default to relevance",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred display languages, comma-separated (e.g., en,ja-ro)",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also count all matches by genre, status, content rating and year. The counts of a field ignore the search's filter on it",
+                        "name": "facets",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Chapter"
-                            }
+                            "$ref": "#/definitions/handler.listMangaResponse"
                         }
                     },
                     "400": {
@@ -1125,7 +1467,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Adds a new chapter to a specific manga. Requires 'chapters:manage' permission.",
+                "description": "Adds a new manga to the catalog. Creators can be credited explicitly, otherwise the author string is split into story and art credits.\nRequires 'manga:manage' permission.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1133,24 +1475,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chapters"
+                    "Manga"
                 ],
-                "summary": "Create a new chapter",
+                "summary": "Create a new manga",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Manga ID",
-                        "name": "manga_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Chapter Creation Info",
+                        "description": "Manga Creation Info",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.createChapterRequest"
+                            "$ref": "#/definitions/handler.createMangaRequest"
                         }
                     }
                 ],
@@ -1158,7 +1493,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Chapter"
+                            "$ref": "#/definitions/domain.Manga"
                         }
                     },
                     "400": {
@@ -1209,30 +1544,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me": {
+        "/manga/autocomplete": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieves the profile information for the currently authenticated user.",
+                "description": "Suggests manga for a search being typed, by main or alternative titles that start with or resemble it, tolerating typos. Only manga with content ratings the user has opted into are suggested.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Manga"
+                ],
+                "summary": "Autocomplete manga titles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The search typed so far",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of suggestions",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get current user's profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.userResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.MangaSuggestion"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1252,49 +1601,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me/favorites": {
+        "/manga/{id}": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieves a paginated list of the current user's favorite manga.",
+                "description": "Retrieves details for a single manga, including its genres, alternative titles and related manga.\nDisplayTitle holds the title in the language requested via lang or Accept-Language, falling back to the main title.\nManga with a content rating the user hasn't opted into (only safe for logged-out visitors) are forbidden.\nThe ETag header holds the manga's version, with a digest of the display languages and content ratings the response was made for: send it as If-None-Match to revalidate a copy, or as If-Match to update or delete the manga.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Social"
+                    "Manga"
                 ],
-                "summary": "List user's favorite manga",
+                "summary": "Get a single manga by ID",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Items per page",
-                        "name": "per_page",
+                        "type": "string",
+                        "description": "Preferred display languages, comma-separated (e.g., en,ja-ro)",
+                        "name": "lang",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Manga"
-                            }
+                            "$ref": "#/definitions/domain.Manga"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1302,40 +1651,89 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/users/me/progress": {
-            "get": {
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a list of all chapters marked as read by the current user.",
+                "description": "Applies a JSON Merge Patch (RFC 7386) to the manga: only the fields in the body change, objects such as links are merged and null clears an optional field.\nLists such as genres, alt_titles and creators are replaced as a whole. Patching author without creators credits the manga to the new author.\nRequires 'manga:manage' permission.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Social"
+                    "Manga"
+                ],
+                "summary": "Partially update a manga",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the manga as last read",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.createMangaRequest"
+                        }
+                    }
                 ],
-                "summary": "List user's read chapters",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Chapter"
+                            "$ref": "#/definitions/domain.Manga"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -1348,6 +1746,51 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1359,223 +1802,4289 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Chapter": {
-            "type": "object",
-            "properties": {
-                "chapterNumber": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "mangaID": {
-                    "type": "string"
-                },
-                "pages": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+        },
+        "/manga/{id}/comments": {
+            "get": {
+                "description": "Retrieves a paginated list of comments for a specific manga. Comments on manga with a content rating the user hasn't opted into are forbidden.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Social"
+                ],
+                "summary": "List manga comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (at most 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, to continue right after it",
+                        "name": "cursor",
+                        "in": "query"
                     }
-                },
-                "title": {
-                    "description": "Optional",
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.listResponse-domain_CommentWithUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a new comment to a specific manga. Manga with a content rating the user hasn't opted into can't be commented on.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Social"
+                ],
+                "summary": "Post a comment on a manga",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment Content",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.createCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Comment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/manga/{id}/covers": {
+            "get": {
+                "description": "Lists every cover of a manga, primary first. Covers of manga with a content rating the user hasn't opted into are forbidden.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Covers"
+                ],
+                "summary": "List manga covers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Cover"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/manga/{id}/covers/{cover_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the cover and its images. If it was the primary cover, the newest remaining cover replaces it. Requires 'manga:manage' permission.",
+                "tags": [
+                    "Covers"
+                ],
+                "summary": "Delete a cover",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cover ID",
+                        "name": "cover_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/manga/{id}/covers/{cover_id}/primary": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Makes the cover the one shown as the manga's cover_image_url. Requires 'manga:manage' permission.",
+                "tags": [
+                    "Covers"
+                ],
+                "summary": "Set the primary cover",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cover ID",
+                        "name": "cover_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/manga/{id}/favorite": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds or removes a manga from the current user's favorites list.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Social"
+                ],
+                "summary": "Toggle manga favorite status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.ToggleFavoriteResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/manga/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the revisions of a manga and its chapters, newest first: who made each change, when, and the old and new value of each changed field.\nRequires 'manga:manage' permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manga"
+                ],
+                "summary": "List a manga's history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (at most 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, to continue right after it",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.listResponse-domain_Revision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/manga/{id}/relations/{related_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records how the related manga relates to the manga, e.g. {\"type\": \"sequel\"} if it is the manga's sequel, replacing any earlier relation between them.\nThe inverse relation (here, prequel) is recorded on the related manga. Requires 'manga:manage' permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manga"
+                ],
+                "summary": "Relate two manga",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related manga ID",
+                        "name": "related_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Relation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.setRelationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes the relation between the two manga, from both sides. Requires 'manga:manage' permission.",
+                "tags": [
+                    "Manga"
+                ],
+                "summary": "Remove a relation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related manga ID",
+                        "name": "related_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/manga/{id}/volumes/{volume}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a link to a CBZ, EPUB or PDF bundle of every published chapter in the volume.\nIf no up-to-date bundle is cached, the worker builds one and 202 is returned with a job to poll via GET /jobs/{id}; the completed job's result holds the link.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Downloads"
+                ],
+                "summary": "Download a volume",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Volume",
+                        "name": "volume",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "cbz",
+                            "epub",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "default": "cbz",
+                        "description": "Download format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.VolumeDownload"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/service.VolumeDownload"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/manga/{manga_id}/chapters": {
+            "get": {
+                "description": "Retrieves a paginated list of chapters for a specific manga. Only published chapters are listed, unless the user has 'chapters:manage'.\nChapters of manga with a content rating the user hasn't opted into are forbidden.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapters"
+                ],
+                "summary": "List chapters for a manga",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "manga_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (at most 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, to continue right after it",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.listResponse-domain_Chapter"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a new chapter to a specific manga. Chapters are drafts unless a publication state is given; scheduled chapters go live at publish_at.\nRequires 'chapters:manage' or 'chapters:submit' permission. Chapters of users with only 'chapters:submit' are drafts pending review, whatever publication state is given; a moderator publishes them on approval.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chapters"
+                ],
+                "summary": "Create a new chapter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "manga_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Chapter Creation Info",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.createChapterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Chapter"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/manga/{manga_id}/covers": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stores a JPEG, PNG or GIF cover and generates a JPEG thumbnail. Covers can be tagged with a volume and language.\nThe manga's first cover, or one uploaded with primary=true, becomes the primary cover shown as cover_image_url. Requires 'manga:manage' permission.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Covers"
+                ],
+                "summary": "Upload a manga cover",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "manga_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Cover image (max 10 MiB)",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Volume the cover belongs to",
+                        "name": "volume",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the edition, e.g. en",
+                        "name": "language",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Make this the primary cover",
+                        "name": "primary",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Cover"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/manga/{manga_id}/history/{revision_id}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the manga, or the chapter the revision is of, back to how it was right after the revision. The revert is recorded as a new revision.\nDeletions can't be reverted; restore from the trash instead. Requires 'manga:manage' permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manga"
+                ],
+                "summary": "Revert to a revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "manga_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Revision ID",
+                        "name": "revision_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the manga or chapter as last read",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The reverted manga, or chapter (domain.Chapter) for revisions of chapters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Manga"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/manga/{manga_id}/suggestions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Proposes changes to a manga's details, given like a PATCH of the manga. Only the fields that differ from the manga as it is are kept.\nThe suggestion waits for a moderator to approve or reject it, see GET /users/me/suggestions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suggestions"
+                ],
+                "summary": "Suggest an edit to a manga",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "manga_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Suggested changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.submitSuggestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.EditSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/review/chapters": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The review queue: chapters submitted by uploaders that wait for approval, oldest first, with their pages to preview.\nRequires 'chapters:manage' permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "List chapters pending review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (at most 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, to continue right after it",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.listResponse-domain_ChapterSubmission"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/review/chapters/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publishes a chapter pending review, or schedules it for publish_at. The uploader is notified. Requires 'chapters:manage' permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "Approve a chapter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chapter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Publication",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.approveChapterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChapterSubmission"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/review/chapters/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a chapter pending review to the trash. The uploader is notified with the reason. Requires 'chapters:manage' permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "Reject a chapter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chapter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.rejectChapterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChapterSubmission"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The moderation queue: pending suggestions, oldest first. Reviewed suggestions are listed newest first.\nRequires 'manga:manage' permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suggestions"
+                ],
+                "summary": "List suggestions",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "default": "pending",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only suggestions for this manga",
+                        "name": "manga_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (at most 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, to continue right after it",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.listResponse-domain_EditSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Only the submitter or a user with 'manga:manage' may view it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suggestions"
+                ],
+                "summary": "Get a suggestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Suggestion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.EditSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applies the suggested changes to the manga. The update appears in the manga's history as made by the submitter.\nFails with 409 if a field it changes has been changed differently since it was submitted. Requires 'manga:manage' permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suggestions"
+                ],
+                "summary": "Approve a suggestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Suggestion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.EditSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/suggestions/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Closes the suggestion without applying it. The reason is shown to the submitter. Requires 'manga:manage' permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suggestions"
+                ],
+                "summary": "Reject a suggestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Suggestion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.rejectSuggestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.EditSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Lists the tags manga can be classified with (genres, themes, formats and content warnings). Use a tag's name or slug in the genres of a manga.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "List tags",
+                "parameters": [
+                    {
+                        "enum": [
+                            "genre",
+                            "theme",
+                            "format",
+                            "content_warning"
+                        ],
+                        "type": "string",
+                        "description": "Only tags of this group",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Tag"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a tag to a group. Requires 'tags:manage' permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Create a tag",
+                "parameters": [
+                    {
+                        "description": "Tag Info",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.createTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renames, regroups or describes a tag. Manga keep the tag. Requires 'tags:manage' permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Update a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag Info",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.createTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the tag and removes it from every manga. Requires 'tags:manage' permission.",
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Delete a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/chapters": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the chapters deleted on their own, most recently deleted first. Chapters deleted along with their manga are restored with it.\nRequires 'chapters:manage' permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "List deleted chapters",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (at most 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, to continue right after it",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.listResponse-domain_Chapter"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/chapters/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Takes a chapter out of the trash. Requires 'chapters:manage' permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a deleted chapter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chapter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Chapter"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "The chapter number has been taken since, or the manga is in the trash",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/manga": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the manga in the trash, most recently deleted first. They are purged for good once the retention period has passed.\nRequires 'manga:manage' permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "List deleted manga",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (at most 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, to continue right after it",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.listResponse-domain_Manga"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/manga/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Takes a manga out of the trash, along with the chapters that were deleted with it. Requires 'manga:manage' permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a deleted manga",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Manga ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Manga"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "An external ID has been taken by another manga since",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the profile information for the currently authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get current user's profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.userResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/chapters": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the chapters the user uploaded, newest first, with their review status and the reason of rejections. Rejected chapters are in the trash.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "List my uploaded chapters",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Filter by review status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (at most 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, to continue right after it",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.listResponse-domain_ChapterSubmission"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/favorites": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a paginated list of the current user's favorite manga.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Social"
+                ],
+                "summary": "List user's favorite manga",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (at most 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, to continue right after it",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred display languages, comma-separated (e.g., en,ja-ro)",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.listResponse-domain_Manga"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the content ratings the user sees. New users only see safe manga.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get current user's preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.preferencesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the content ratings of the manga the user sees, e.g. [\"safe\", \"suggestive\"]. Manga with other ratings are hidden from lists and cannot be opened.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update current user's preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.preferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.preferencesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/progress": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of all published chapters marked as read by the current user, leaving out those of manga with a content rating the user hasn't opted into.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Social"
+                ],
+                "summary": "List user's read chapters",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Chapter"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the edits the user has suggested, newest first, with their review status and the reason of rejections.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suggestions"
+                ],
+                "summary": "List my suggestions",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page (at most 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, to continue right after it",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.listResponse-domain_EditSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.Chapter": {
+            "type": "object",
+            "properties": {
+                "chapterNumber": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "description": "Set while the chapter, or its manga, is in the trash",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mangaID": {
+                    "type": "string"
+                },
+                "pages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "publicationState": {
+                    "$ref": "#/definitions/domain.PublicationState"
+                },
+                "publishAt": {
+                    "description": "When a scheduled chapter goes live, or when a published one did",
+                    "type": "string"
+                },
+                "rejectionReason": {
+                    "type": "string"
+                },
+                "reviewStatus": {
+                    "$ref": "#/definitions/domain.ChapterReviewStatus"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedBy": {
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Optional",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "uploadedBy": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Incremented on every change",
+                    "type": "integer"
+                },
+                "volume": {
+                    "description": "Optional, e.g. \"1\" or \"Extra\"",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ChapterReviewStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "rejected"
+            ],
+            "x-enum-comments": {
+                "ChapterReviewApproved": "Also chapters that didn't need a review",
+                "ChapterReviewRejected": "Moved to the trash"
+            },
+            "x-enum-varnames": [
+                "ChapterReviewPending",
+                "ChapterReviewApproved",
+                "ChapterReviewRejected"
+            ]
+        },
+        "domain.ChapterSubmission": {
+            "type": "object",
+            "properties": {
+                "chapterNumber": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "description": "Set while the chapter, or its manga, is in the trash",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mangaID": {
+                    "type": "string"
+                },
+                "mangaTitle": {
+                    "type": "string"
+                },
+                "pages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "publicationState": {
+                    "$ref": "#/definitions/domain.PublicationState"
+                },
+                "publishAt": {
+                    "description": "When a scheduled chapter goes live, or when a published one did",
+                    "type": "string"
+                },
+                "rejectionReason": {
+                    "type": "string"
+                },
+                "reviewStatus": {
+                    "$ref": "#/definitions/domain.ChapterReviewStatus"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedBy": {
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Optional",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "uploadedBy": {
+                    "type": "string"
+                },
+                "uploaderUsername": {
+                    "description": "Nil if the uploader's account is gone",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Incremented on every change",
+                    "type": "integer"
+                },
+                "volume": {
+                    "description": "Optional, e.g. \"1\" or \"Extra\"",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Comment": {
+            "type": "object",
+            "properties": {
+                "chapterID": {
+                    "description": "Nullable",
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mangaID": {
+                    "description": "Nullable",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CommentWithUser": {
+            "type": "object",
+            "properties": {
+                "chapterID": {
+                    "description": "Nullable",
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mangaID": {
+                    "description": "Nullable",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ContentRating": {
+            "type": "string",
+            "enum": [
+                "safe",
+                "suggestive",
+                "explicit"
+            ],
+            "x-enum-varnames": [
+                "ContentRatingSafe",
+                "ContentRatingSuggestive",
+                "ContentRatingExplicit"
+            ]
+        },
+        "domain.Cover": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isPrimary": {
+                    "type": "boolean"
+                },
+                "language": {
+                    "description": "Set for covers of a localized edition, e.g. \"en\"",
+                    "type": "string"
+                },
+                "mangaID": {
+                    "type": "string"
+                },
+                "thumbnailURL": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "volume": {
+                    "description": "Set for volume-specific covers",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Creator": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreatorRole": {
+            "type": "string",
+            "enum": [
+                "story",
+                "art"
+            ],
+            "x-enum-varnames": [
+                "CreatorRoleStory",
+                "CreatorRoleArt"
+            ]
+        },
+        "domain.Demographic": {
+            "type": "string",
+            "enum": [
+                "shounen",
+                "shoujo",
+                "seinen",
+                "josei"
+            ],
+            "x-enum-varnames": [
+                "DemographicShounen",
+                "DemographicShoujo",
+                "DemographicSeinen",
+                "DemographicJosei"
+            ]
+        },
+        "domain.EditSuggestion": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "By field name, against the manga as it was when submitted",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/domain.FieldChange"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mangaID": {
+                    "type": "string"
+                },
+                "mangaTitle": {
+                    "type": "string"
+                },
+                "note": {
+                    "description": "The submitter's explanation, e.g. a source",
+                    "type": "string"
+                },
+                "rejectionReason": {
+                    "type": "string"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedBy": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.SuggestionStatus"
+                },
+                "userID": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FieldChange": {
+            "type": "object",
+            "properties": {
+                "new": {},
+                "old": {}
+            }
+        },
+        "domain.Job": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "description": "Nullable",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Set if the job fails",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "result": {
+                    "description": "Set once the job completes",
+                    "type": "object"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.JobStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.JobType"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.JobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "JobStatusPending",
+                "JobStatusRunning",
+                "JobStatusCompleted",
+                "JobStatusFailed"
+            ]
+        },
+        "domain.JobType": {
+            "type": "string",
+            "enum": [
+                "chapter.archive",
+                "volume.bundle"
+            ],
+            "x-enum-varnames": [
+                "JobTypeChapterArchive",
+                "JobTypeVolumeBundle"
+            ]
+        },
+        "domain.Manga": {
+            "type": "object",
+            "properties": {
+                "altTitles": {
+                    "description": "Alternative and localized titles",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MangaTitle"
+                    }
+                },
+                "author": {
+                    "description": "Credit line, derived from Creators",
+                    "type": "string"
+                },
+                "contentRating": {
+                    "$ref": "#/definitions/domain.ContentRating"
+                },
+                "coverImageURL": {
+                    "description": "Use a pointer to handle NULL values",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "creators": {
+                    "description": "Credited authors and artists",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MangaCreator"
+                    }
+                },
+                "deletedAt": {
+                    "description": "Set while the manga is in the trash",
+                    "type": "string"
+                },
+                "demographic": {
+                    "description": "Optional publication details",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Demographic"
+                        }
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "displayTitle": {
+                    "description": "Title in the language requested by the client, see Localize",
+                    "type": "string"
+                },
+                "externalID": {
+                    "description": "Key in the catalog the manga was imported from, see internal/catalog",
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastChapter": {
+                    "type": "string"
+                },
+                "lastVolume": {
+                    "description": "Final volume and chapter, once known",
+                    "type": "string"
+                },
+                "links": {
+                    "$ref": "#/definitions/domain.MangaLinks"
+                },
+                "originalLanguage": {
+                    "description": "BCP 47 tag, e.g. \"ja\" or \"ko\"",
+                    "type": "string"
+                },
+                "relations": {
+                    "description": "Sequels, spin-offs, adaptations and so on",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MangaRelation"
+                    }
+                },
+                "searchLanguage": {
+                    "description": "BCP 47 tag of the title and description, picks how they are indexed for search",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.MangaStatus"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Incremented on every change to the manga, its cover or its relations",
+                    "type": "integer"
+                },
+                "year": {
+                    "description": "Year the serialization started",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.MangaCreator": {
+            "type": "object",
+            "properties": {
+                "creatorID": {
+                    "description": "Zero when the creator is given by name only and still has to be created",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/domain.CreatorRole"
+                }
+            }
+        },
+        "domain.MangaFacets": {
+            "type": "object",
+            "properties": {
+                "contentRatings": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "genres": {
+                    "description": "By tag name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "statuses": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "years": {
+                    "description": "Manga without a year are not counted",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "domain.MangaLinks": {
+            "type": "object",
+            "properties": {
+                "aniListID": {
+                    "type": "integer"
+                },
+                "mangaUpdatesID": {
+                    "description": "The base-36 series ID from the mangaupdates.com URL",
+                    "type": "string"
+                },
+                "myAnimeListID": {
+                    "type": "integer"
+                },
+                "officialURL": {
+                    "description": "Official English release or store page",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.MangaRelation": {
+            "type": "object",
+            "properties": {
+                "contentRating": {
+                    "$ref": "#/definitions/domain.ContentRating"
+                },
+                "mangaID": {
+                    "description": "The related manga",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.RelationType"
+                }
+            }
+        },
+        "domain.MangaStatus": {
+            "type": "string",
+            "enum": [
+                "ongoing",
+                "completed",
+                "hiatus",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "StatusOngoing",
+                "StatusCompleted",
+                "StatusHiatus",
+                "StatusCancelled"
+            ]
+        },
+        "domain.MangaSuggestion": {
+            "type": "object",
+            "properties": {
+                "mangaID": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.MangaTitle": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "description": "BCP 47 tag, e.g. \"ja\", \"ja-ro\" (romaji) or \"en\"",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.PublicationState": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "scheduled",
+                "published",
+                "unpublished"
+            ],
+            "x-enum-varnames": [
+                "PublicationDraft",
+                "PublicationScheduled",
+                "PublicationPublished",
+                "PublicationUnpublished"
+            ]
+        },
+        "domain.RelationType": {
+            "type": "string",
+            "enum": [
+                "sequel",
+                "prequel",
+                "side_story",
+                "main_story",
+                "spin_off",
+                "original_story",
+                "alternate_version",
+                "adapted_from",
+                "adaptation"
+            ],
+            "x-enum-varnames": [
+                "RelationSequel",
+                "RelationPrequel",
+                "RelationSideStory",
+                "RelationMainStory",
+                "RelationSpinOff",
+                "RelationOriginalStory",
+                "RelationAlternateVersion",
+                "RelationAdaptedFrom",
+                "RelationAdaptation"
+            ]
+        },
+        "domain.Revision": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/domain.RevisionAction"
+                },
+                "actorID": {
+                    "description": "Nil for changes made by the system, e.g. scheduled publication",
+                    "type": "string"
+                },
+                "actorUsername": {
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "By field name; only the fields that changed",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/domain.FieldChange"
+                    }
+                },
+                "chapterID": {
+                    "description": "Set for revisions of chapters",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mangaID": {
+                    "type": "string"
+                },
+                "revertedFrom": {
+                    "description": "The revision a revert went back to",
+                    "type": "string"
+                },
+                "snapshot": {
+                    "description": "The tracked fields after the change, to revert to. Nil for deletes",
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "domain.RevisionAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete",
+                "restore",
+                "revert"
+            ],
+            "x-enum-comments": {
+                "RevisionDelete": "Moved to the trash",
+                "RevisionRestore": "Taken out of the trash",
+                "RevisionRevert": "Set back to an earlier revision"
+            },
+            "x-enum-varnames": [
+                "RevisionCreate",
+                "RevisionUpdate",
+                "RevisionDelete",
+                "RevisionRestore",
+                "RevisionRevert"
+            ]
+        },
+        "domain.SuggestionStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "SuggestionPending",
+                "SuggestionApproved",
+                "SuggestionRejected"
+            ]
+        },
+        "domain.Tag": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "group": {
+                    "$ref": "#/definitions/domain.TagGroup"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TagGroup": {
+            "type": "string",
+            "enum": [
+                "genre",
+                "theme",
+                "format",
+                "content_warning"
+            ],
+            "x-enum-varnames": [
+                "TagGroupGenre",
+                "TagGroupTheme",
+                "TagGroupFormat",
+                "TagGroupContentWarning"
+            ]
+        },
+        "handler.altTitleRequest": {
+            "type": "object",
+            "required": [
+                "language",
+                "title"
+            ],
+            "properties": {
+                "language": {
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "handler.approveChapterRequest": {
+            "type": "object",
+            "properties": {
+                "publish_at": {
+                    "description": "Schedules the chapter if in the future; published now when omitted",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.createChapterRequest": {
+            "type": "object",
+            "required": [
+                "chapter_number"
+            ],
+            "properties": {
+                "chapter_number": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "pages": {
+                    "description": "Initially, pages might be empty before upload; left unchanged on update when omitted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "publication_state": {
+                    "description": "Defaults to draft on create and to the current state on update",
+                    "enum": [
+                        "draft",
+                        "scheduled",
+                        "published",
+                        "unpublished"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.PublicationState"
+                        }
+                    ]
+                },
+                "publish_at": {
+                    "description": "Required when scheduling",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "volume": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "handler.createCommentRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "minLength": 1
+                }
+            }
+        },
+        "handler.createCreatorRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "handler.createMangaRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "genres",
+                "status",
+                "title"
+            ],
+            "properties": {
+                "alt_titles": {
+                    "description": "Alternative and localized titles, e.g. {\"title\": \"進撃の巨人\", \"language\": \"ja\"}",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.altTitleRequest"
+                    }
+                },
+                "author": {
+                    "description": "Split into creators unless they are given",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 2
+                },
+                "content_rating": {
+                    "description": "Defaults to safe on creation; left unchanged on update when omitted",
+                    "type": "string",
+                    "enum": [
+                        "safe",
+                        "suggestive",
+                        "explicit"
+                    ]
+                },
+                "creators": {
+                    "description": "Credited creators; the author string is derived from them when given",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/handler.creatorCreditRequest"
+                    }
+                },
+                "demographic": {
+                    "description": "Optional publication details",
+                    "type": "string",
+                    "enum": [
+                        "shounen",
+                        "shoujo",
+                        "seinen",
+                        "josei"
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "genres": {
+                    "description": "Tag names or slugs, see GET /tags",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "last_chapter": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "last_volume": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "links": {
+                    "$ref": "#/definitions/handler.mangaLinksRequest"
+                },
+                "original_language": {
+                    "description": "e.g. \"ja\"",
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "search_language": {
+                    "description": "Language of the title and description, \"en\" if omitted",
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ongoing",
+                        "completed",
+                        "hiatus",
+                        "cancelled"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 2
+                },
+                "year": {
+                    "type": "integer",
+                    "maximum": 2200,
+                    "minimum": 1800
+                }
+            }
+        },
+        "handler.createTagRequest": {
+            "type": "object",
+            "required": [
+                "group",
+                "name"
+            ],
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "group": {
+                    "type": "string",
+                    "enum": [
+                        "genre",
+                        "theme",
+                        "format",
+                        "content_warning"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                },
+                "slug": {
+                    "description": "Derived from the name if omitted",
+                    "type": "string",
+                    "maxLength": 60
                 }
             }
         },
-        "domain.Comment": {
+        "handler.creatorCreditRequest": {
             "type": "object",
+            "required": [
+                "role"
+            ],
             "properties": {
-                "chapterID": {
-                    "description": "Nullable",
+                "creator_id": {
                     "type": "string"
                 },
-                "content": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "createdAt": {
-                    "type": "string"
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "story",
+                        "art"
+                    ]
+                }
+            }
+        },
+        "handler.listMangaResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Manga"
+                    }
                 },
-                "id": {
-                    "type": "string"
+                "facets": {
+                    "$ref": "#/definitions/domain.MangaFacets"
                 },
-                "mangaID": {
-                    "description": "Nullable",
+                "next_cursor": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "page": {
+                    "type": "integer"
                 },
-                "userID": {
-                    "type": "string"
+                "per_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.CommentWithUser": {
+        "handler.listResponse-domain_Chapter": {
             "type": "object",
             "properties": {
-                "chapterID": {
-                    "description": "Nullable",
-                    "type": "string"
-                },
-                "content": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Chapter"
+                    }
                 },
-                "mangaID": {
-                    "description": "Nullable",
+                "next_cursor": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "page": {
+                    "type": "integer"
                 },
-                "userID": {
-                    "type": "string"
+                "per_page": {
+                    "type": "integer"
                 },
-                "username": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.Manga": {
+        "handler.listResponse-domain_ChapterSubmission": {
             "type": "object",
             "properties": {
-                "author": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ChapterSubmission"
+                    }
                 },
-                "coverImageURL": {
-                    "description": "Use a pointer to handle NULL values",
+                "next_cursor": {
                     "type": "string"
                 },
-                "createdAt": {
-                    "type": "string"
+                "page": {
+                    "type": "integer"
                 },
-                "description": {
-                    "type": "string"
+                "per_page": {
+                    "type": "integer"
                 },
-                "genres": {
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.listResponse-domain_CommentWithUser": {
+            "type": "object",
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/domain.CommentWithUser"
                     }
                 },
-                "id": {
+                "next_cursor": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/domain.MangaStatus"
+                "page": {
+                    "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "per_page": {
+                    "type": "integer"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.MangaStatus": {
-            "type": "string",
-            "enum": [
-                "ongoing",
-                "completed",
-                "hiatus",
-                "cancelled"
-            ],
-            "x-enum-varnames": [
-                "StatusOngoing",
-                "StatusCompleted",
-                "StatusHiatus",
-                "StatusCancelled"
-            ]
-        },
-        "handler.createChapterRequest": {
+        "handler.listResponse-domain_EditSuggestion": {
             "type": "object",
-            "required": [
-                "chapter_number"
-            ],
             "properties": {
-                "chapter_number": {
-                    "type": "string",
-                    "maxLength": 20
-                },
-                "pages": {
-                    "description": "Initially, pages might be empty before upload",
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/domain.EditSuggestion"
                     }
                 },
-                "title": {
-                    "type": "string",
-                    "maxLength": 255
+                "next_cursor": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.createCommentRequest": {
+        "handler.listResponse-domain_Manga": {
             "type": "object",
-            "required": [
-                "content"
-            ],
             "properties": {
-                "content": {
-                    "type": "string",
-                    "maxLength": 1000,
-                    "minLength": 1
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Manga"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.createMangaRequest": {
+        "handler.listResponse-domain_Revision": {
             "type": "object",
-            "required": [
-                "author",
-                "description",
-                "genres",
-                "status",
-                "title"
-            ],
             "properties": {
-                "author": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
-                },
-                "description": {
-                    "type": "string"
-                },
-                "genres": {
+                "data": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/domain.Revision"
                     }
                 },
-                "status": {
-                    "type": "string",
-                    "enum": [
-                        "ongoing",
-                        "completed",
-                        "hiatus",
-                        "cancelled"
-                    ]
+                "next_cursor": {
+                    "type": "string"
                 },
-                "title": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 2
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
@@ -1605,6 +6114,53 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.mangaLinksRequest": {
+            "type": "object",
+            "properties": {
+                "anilist_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "mal_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "mangaupdates_id": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "official_url": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "handler.preferencesRequest": {
+            "type": "object",
+            "required": [
+                "content_ratings"
+            ],
+            "properties": {
+                "content_ratings": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.ContentRating"
+                    }
+                }
+            }
+        },
+        "handler.preferencesResponse": {
+            "type": "object",
+            "properties": {
+                "content_ratings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ContentRating"
+                    }
+                }
+            }
+        },
         "handler.registerRequest": {
             "type": "object",
             "required": [
@@ -1628,6 +6184,72 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.rejectChapterRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Sent to the uploader",
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "handler.rejectSuggestionRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Shown to the submitter",
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "handler.setRelationRequest": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "sequel",
+                        "prequel",
+                        "side_story",
+                        "main_story",
+                        "spin_off",
+                        "original_story",
+                        "alternate_version",
+                        "adapted_from",
+                        "adaptation"
+                    ]
+                }
+            }
+        },
+        "handler.submitSuggestionRequest": {
+            "type": "object",
+            "required": [
+                "changes"
+            ],
+            "properties": {
+                "changes": {
+                    "description": "The edit, as a JSON Merge Patch of the manga's fields like for PATCH /manga/{id}",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "note": {
+                    "description": "e.g. a source for the change",
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
         "handler.userResponse": {
             "type": "object",
             "properties": {
@@ -1650,6 +6272,34 @@ const docTemplate = `{
                     "type": "boolean"
                 }
             }
+        },
+        "service.CreatorProfile": {
+            "type": "object",
+            "properties": {
+                "creator": {
+                    "$ref": "#/definitions/domain.Creator"
+                },
+                "works": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Manga"
+                    }
+                }
+            }
+        },
+        "service.VolumeDownload": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "job": {
+                    "$ref": "#/definitions/domain.Job"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,23 @@
+// Package actor carries the user a request is made by in its context, so that services can
+// record who changed something without every method taking the user as a parameter.
+package actor
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type contextKey struct{}
+
+// WithUserID returns a copy of the context acting on behalf of the user.
+func WithUserID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, userID)
+}
+
+// UserID returns the user the context acts on behalf of. It returns false for work not done
+// for a user, such as the worker's.
+func UserID(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(contextKey{}).(uuid.UUID)
+	return userID, ok
+}
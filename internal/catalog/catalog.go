@@ -0,0 +1,298 @@
+// Package catalog exports the manga catalog to NDJSON or CSV files and imports it back, for
+// seeding a new installation or backing up and restoring the catalog's metadata.
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/google/uuid"
+)
+
+// exportBatchSize is the number of manga, or chapters of a manga, read at once while exporting.
+const exportBatchSize = 100
+
+type Status string
+
+const (
+	StatusCreated   Status = "created"
+	StatusUpdated   Status = "updated"
+	StatusUnchanged Status = "unchanged" // The manga is as in the record, though its chapters may not be
+	StatusFailed    Status = "failed"
+)
+
+// Result is the outcome for a single row of an import.
+type Result struct {
+	Line            int
+	ExternalID      string
+	Status          Status
+	MangaID         uuid.UUID // Nil for manga a dry run would create
+	ChaptersCreated int
+	ChaptersUpdated int
+	Err             error
+}
+
+// Report summarizes an import.
+type Report struct {
+	DryRun  bool
+	Results []Result
+}
+
+type Catalog struct {
+	mangaService   *service.MangaService
+	chapterService *service.ChapterService
+	tagService     *service.TagService
+}
+
+func New(mangaService *service.MangaService, chapterService *service.ChapterService, tagService *service.TagService) *Catalog {
+	return &Catalog{mangaService: mangaService, chapterService: chapterService, tagService: tagService}
+}
+
+// Export writes every manga of the catalog, oldest first, and returns how many it wrote.
+// Trashed manga and chapters still waiting for review are left out.
+func (c *Catalog) Export(ctx context.Context, w Writer) (int, error) {
+	count := 0
+	params := repository.ListMangaParams{Limit: exportBatchSize, SortBy: "created_at", SortOrder: "asc"}
+	for {
+		page, err := c.mangaService.List(ctx, params)
+		if err != nil {
+			return count, err
+		}
+		for _, manga := range page.Items {
+			chapters, err := c.listChapters(ctx, manga.ID)
+			if err != nil {
+				return count, err
+			}
+			if err := w.Write(recordOf(manga, chapters)); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		params.Cursor = page.NextCursor
+	}
+	return count, w.Flush()
+}
+
+func (c *Catalog) listChapters(ctx context.Context, mangaID uuid.UUID) ([]*domain.Chapter, error) {
+	var chapters []*domain.Chapter
+	params := repository.ListChaptersParams{MangaID: mangaID, Limit: exportBatchSize}
+	for {
+		page, err := c.chapterService.ListByMangaID(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, chapter := range page.Items {
+			if chapter.ReviewStatus == domain.ChapterReviewApproved {
+				chapters = append(chapters, chapter)
+			}
+		}
+		if page.NextCursor == "" {
+			return chapters, nil
+		}
+		params.Cursor = page.NextCursor
+	}
+}
+
+// Import creates the manga of the rows that aren't in the catalog yet and updates the others,
+// matching them by external ID, or by manga ID for exported manga that weren't imported. Their
+// chapters are matched by number. Every row is validated first; a dry run stops there and
+// reports what would be done. A row that fails doesn't stop the rows after it.
+func (c *Catalog) Import(ctx context.Context, rows []Row, dryRun bool) (*Report, error) {
+	tags, err := c.tagService.List(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("could not list tags: %w", err)
+	}
+	knownTags := make(map[string]bool, 2*len(tags))
+	for _, tag := range tags {
+		knownTags[tag.Name] = true
+		knownTags[tag.Slug] = true
+	}
+
+	report := &Report{DryRun: dryRun, Results: make([]Result, 0, len(rows))}
+	firstLines := make(map[string]int, len(rows))
+	for _, row := range rows {
+		result := Result{Line: row.Line}
+		if row.Record != nil {
+			result.ExternalID = row.Record.ExternalID
+		}
+		if row.Err == nil {
+			row.Err = checkRow(row, knownTags, firstLines)
+		}
+		if row.Err != nil {
+			result.Status, result.Err = StatusFailed, row.Err
+		} else {
+			c.importRecord(ctx, row.Record, &result, dryRun)
+		}
+
+		if result.Err != nil {
+			log.Printf("Line %d: %s: %v", result.Line, result.Status, result.Err)
+		} else {
+			log.Printf("Line %d: %s %s", result.Line, result.ExternalID, result.Status)
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+// checkRow validates the row's record and checks that its tags exist and that no earlier row
+// has the same external ID.
+func checkRow(row Row, knownTags map[string]bool, firstLines map[string]int) error {
+	record := row.Record
+	if err := record.Validate(); err != nil {
+		return err
+	}
+	if line, ok := firstLines[record.ExternalID]; ok {
+		return fmt.Errorf("duplicate external_id, first seen on line %d", line)
+	}
+	firstLines[record.ExternalID] = row.Line
+
+	var unknown []string
+	for _, genre := range record.Genres {
+		if !knownTags[genre] {
+			unknown = append(unknown, genre)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", repository.ErrUnknownTags, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func (c *Catalog) importRecord(ctx context.Context, record *Record, result *Result, dryRun bool) {
+	manga, err := c.find(ctx, record.ExternalID)
+	switch {
+	case err == nil:
+		result.MangaID = manga.ID
+		result.Status = StatusUpdated
+		if record.sameAs(manga) {
+			result.Status = StatusUnchanged
+		}
+	case errors.Is(err, repository.ErrMangaNotFound):
+		externalID := record.ExternalID
+		manga = &domain.Manga{ExternalID: &externalID}
+		result.Status = StatusCreated
+	default:
+		result.Status, result.Err = StatusFailed, err
+		return
+	}
+
+	if !dryRun && result.Status != StatusUnchanged {
+		record.applyTo(manga)
+		if result.Status == StatusCreated {
+			err = c.mangaService.Create(ctx, manga)
+		} else {
+			err = c.mangaService.Update(ctx, manga)
+		}
+		if err != nil {
+			result.Status, result.Err = StatusFailed, err
+			return
+		}
+		result.MangaID = manga.ID
+	}
+
+	if err := c.importChapters(ctx, manga.ID, record.Chapters, result, dryRun); err != nil {
+		result.Status, result.Err = StatusFailed, err
+	}
+}
+
+// find returns the manga imported under the external ID or, if there is none and the
+// external ID is a manga ID, that manga.
+func (c *Catalog) find(ctx context.Context, externalID string) (*domain.Manga, error) {
+	manga, err := c.mangaService.GetByExternalID(ctx, externalID)
+	if !errors.Is(err, repository.ErrMangaNotFound) {
+		return manga, err
+	}
+	id, parseErr := uuid.Parse(externalID)
+	if parseErr != nil {
+		return nil, err
+	}
+	return c.mangaService.GetByID(ctx, id)
+}
+
+// importChapters creates the chapters the manga doesn't have yet and updates those that
+// differ, counting them on the result. mangaID is nil for a manga a dry run would create.
+func (c *Catalog) importChapters(ctx context.Context, mangaID uuid.UUID, chapters []Chapter, result *Result, dryRun bool) error {
+	for _, source := range chapters {
+		var existing *domain.Chapter
+		if mangaID != uuid.Nil {
+			var err error
+			existing, err = c.chapterService.GetByNumber(ctx, mangaID, source.Number)
+			if err != nil && !errors.Is(err, repository.ErrChapterNotFound) {
+				return fmt.Errorf("chapter %s: %w", source.Number, err)
+			}
+		}
+
+		switch {
+		case existing == nil:
+			if !dryRun {
+				chapter := &domain.Chapter{MangaID: mangaID, Pages: []string{}}
+				source.applyTo(chapter)
+				if err := c.chapterService.Create(ctx, chapter); err != nil {
+					return fmt.Errorf("chapter %s: %w", source.Number, err)
+				}
+			}
+			result.ChaptersCreated++
+		case !source.sameAs(existing):
+			if !dryRun {
+				source.applyTo(existing)
+				existing.Pages = nil // Keep them
+				if err := c.chapterService.Update(ctx, existing); err != nil {
+					return fmt.Errorf("chapter %s: %w", source.Number, err)
+				}
+			}
+			result.ChaptersUpdated++
+		}
+	}
+	return nil
+}
+
+// Count returns the number of results with the given status.
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Print writes a table of every row followed by the totals.
+func (r *Report) Print(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINE\tEXTERNAL ID\tSTATUS\tMANGA\tCHAPTERS\tERROR")
+	chaptersCreated, chaptersUpdated := 0, 0
+	for _, result := range r.Results {
+		mangaID, errMsg := "", ""
+		if result.MangaID != uuid.Nil {
+			mangaID = result.MangaID.String()
+		}
+		if result.Err != nil {
+			errMsg = result.Err.Error()
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t+%d ~%d\t%s\n", result.Line, result.ExternalID, result.Status,
+			mangaID, result.ChaptersCreated, result.ChaptersUpdated, errMsg)
+		chaptersCreated += result.ChaptersCreated
+		chaptersUpdated += result.ChaptersUpdated
+	}
+	tw.Flush()
+
+	prefix := ""
+	if r.DryRun {
+		prefix = "Dry run, nothing was written. "
+	}
+	fmt.Fprintf(w, "\n%s%d created, %d updated, %d unchanged, %d failed; %d chapters created, %d updated\n", prefix,
+		r.Count(StatusCreated), r.Count(StatusUpdated), r.Count(StatusUnchanged), r.Count(StatusFailed),
+		chaptersCreated, chaptersUpdated)
+}
@@ -0,0 +1,333 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Format is the file format of a catalog export or import.
+type Format string
+
+const (
+	// FormatNDJSON has one JSON record per line.
+	FormatNDJSON Format = "ndjson"
+	// FormatCSV has one manga per row. Genres are separated by "|"; creators, alternative titles
+	// and chapters are JSON arrays, as in NDJSON.
+	FormatCSV Format = "csv"
+)
+
+// maxLineSize bounds an NDJSON line, which holds a manga with all its chapters.
+const maxLineSize = 16 << 20
+
+// ParseFormat returns the format with the given name, or the format implied by the file
+// extension of path if name is empty.
+func ParseFormat(name, path string) (Format, error) {
+	if name == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			return FormatCSV, nil
+		case ".ndjson", ".jsonl":
+			return FormatNDJSON, nil
+		}
+		return "", fmt.Errorf("can't tell the format of %q from its extension", path)
+	}
+	switch format := Format(strings.ToLower(name)); format {
+	case FormatNDJSON, FormatCSV:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown format %q, expected ndjson or csv", name)
+}
+
+// Writer writes records in a format. Flush must be called once all records are written.
+type Writer interface {
+	Write(record *Record) error
+	Flush() error
+}
+
+func NewWriter(w io.Writer, format Format) Writer {
+	if format == FormatCSV {
+		return &csvWriter{w: csv.NewWriter(w)}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &ndjsonWriter{enc: enc}
+}
+
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(record *Record) error {
+	return w.enc.Encode(record) // Encode ends every record with a newline
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return nil
+}
+
+// csvColumns are the columns of a CSV catalog, in the order they are exported.
+var csvColumns = []string{
+	"external_id", "title", "description", "author", "creators", "status", "content_rating",
+	"genres", "alt_titles", "demographic", "year", "original_language", "last_volume",
+	"last_chapter", "official_url", "mal_id", "anilist_id", "mangaupdates_id", "search_language",
+	"chapters",
+}
+
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvWriter) Write(record *Record) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	row, err := csvRow(record)
+	if err != nil {
+		return err
+	}
+	return w.w.Write(row)
+}
+
+// Flush writes the header even if there were no records, so an empty export can be imported.
+func (w *csvWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	return w.w.Write(csvColumns)
+}
+
+func csvRow(r *Record) ([]string, error) {
+	creators, err := jsonCell(r.Creators)
+	if err != nil {
+		return nil, err
+	}
+	altTitles, err := jsonCell(r.AltTitles)
+	if err != nil {
+		return nil, err
+	}
+	chapters, err := jsonCell(r.Chapters)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		r.ExternalID, r.Title, r.Description, r.Author, creators, r.Status, r.ContentRating,
+		strings.Join(r.Genres, "|"), altTitles, r.Demographic, intCell(r.Year), r.OriginalLanguage, r.LastVolume,
+		r.LastChapter, r.OfficialURL, intCell(r.MyAnimeListID), intCell(r.AniListID), r.MangaUpdatesID, r.SearchLanguage,
+		chapters,
+	}, nil
+}
+
+// jsonCell encodes a list as a JSON array, or an empty cell if it is empty.
+func jsonCell[T any](values []T) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(values)
+	return string(data), err
+}
+
+func intCell(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+// Row is a record read from an import file, or why it couldn't be read.
+type Row struct {
+	Line   int // Line of the file the record starts on
+	Record *Record
+	Err    error
+}
+
+// ReadRows reads every record of an import file. Records that can't be decoded are returned
+// as rows with an error; the error returned is for files that can't be read at all.
+func ReadRows(r io.Reader, format Format) ([]Row, error) {
+	if format == FormatCSV {
+		return readCSV(r)
+	}
+	return readNDJSON(r)
+}
+
+func readNDJSON(r io.Reader) ([]Row, error) {
+	var rows []Row
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		row := Row{Line: line}
+		var record Record
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields() // Catches misspelled fields, which would otherwise be dropped silently
+		if err := dec.Decode(&record); err != nil {
+			row.Err = fmt.Errorf("invalid JSON: %w", err)
+		} else if dec.More() {
+			row.Err = errors.New("invalid JSON: more than one record on the line")
+		} else {
+			row.Record = &record
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func readCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the CSV file is empty, expected a header row")
+		}
+		return nil, err
+	}
+	known := make(map[string]bool, len(csvColumns))
+	for _, column := range csvColumns {
+		known[column] = true
+	}
+	for i, column := range header {
+		header[i] = strings.TrimSpace(column)
+		if !known[header[i]] {
+			return nil, fmt.Errorf("unknown CSV column %q", column)
+		}
+	}
+
+	var rows []Row
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount) {
+			rows = append(rows, Row{Line: parseErr.StartLine, Err: fmt.Errorf("expected %d fields, got %d", len(header), len(fields))})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line}
+		row.Record, row.Err = parseCSVRow(header, fields)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseCSVRow(header, fields []string) (*Record, error) {
+	var r Record
+	var problems []string
+	for i, column := range header {
+		value := fields[i]
+		var err error
+		switch column {
+		case "external_id":
+			r.ExternalID = value
+		case "title":
+			r.Title = value
+		case "description":
+			r.Description = value
+		case "author":
+			r.Author = value
+		case "creators":
+			err = parseJSONCell(value, &r.Creators)
+		case "status":
+			r.Status = value
+		case "content_rating":
+			r.ContentRating = value
+		case "genres":
+			r.Genres = splitList(value)
+		case "alt_titles":
+			err = parseJSONCell(value, &r.AltTitles)
+		case "demographic":
+			r.Demographic = value
+		case "year":
+			r.Year, err = parseIntCell(value)
+		case "original_language":
+			r.OriginalLanguage = value
+		case "last_volume":
+			r.LastVolume = value
+		case "last_chapter":
+			r.LastChapter = value
+		case "official_url":
+			r.OfficialURL = value
+		case "mal_id":
+			r.MyAnimeListID, err = parseIntCell(value)
+		case "anilist_id":
+			r.AniListID, err = parseIntCell(value)
+		case "mangaupdates_id":
+			r.MangaUpdatesID = value
+		case "search_language":
+			r.SearchLanguage = value
+		case "chapters":
+			err = parseJSONCell(value, &r.Chapters)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", column, err))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+	return &r, nil
+}
+
+func parseJSONCell[T any](value string, dest *[]T) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	dec := json.NewDecoder(strings.NewReader(value))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dest); err != nil {
+		return fmt.Errorf("invalid JSON array: %w", err)
+	}
+	return nil
+}
+
+func parseIntCell(value string) (*int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a number", value)
+	}
+	return &n, nil
+}
+
+// splitList splits a "|"-separated cell, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, "|") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,110 @@
+package catalog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/catalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleRecord() *catalog.Record {
+	year, malID := 1989, 2
+	publishAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return &catalog.Record{
+		ExternalID:    "berserk",
+		Title:         "Berserk",
+		Description:   "Guts, a former mercenary, \"the Black Swordsman\", seeks revenge.\nSecond line.",
+		Author:        "Kentaro Miura",
+		Creators:      []catalog.Creator{{Name: "Kentaro Miura", Role: "story"}, {Name: "Kentaro Miura", Role: "art"}},
+		Status:        "hiatus",
+		ContentRating: "explicit",
+		Genres:        []string{"Action", "Dark Fantasy"},
+		AltTitles:     []catalog.AltTitle{{Title: "ベルセルク", Language: "ja"}},
+		Demographic:   "seinen",
+		Year:          &year,
+		MyAnimeListID: &malID,
+		Chapters: []catalog.Chapter{
+			{Number: "1", Title: "The Black Swordsman", Volume: "1", PublicationState: "published", PublishAt: &publishAt},
+			{Number: "2"},
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for format, line := range map[catalog.Format]int{catalog.FormatNDJSON: 1, catalog.FormatCSV: 2} {
+		var buf bytes.Buffer
+		w := catalog.NewWriter(&buf, format)
+		require.NoError(t, w.Write(sampleRecord()), format)
+		require.NoError(t, w.Flush(), format)
+
+		rows, err := catalog.ReadRows(&buf, format)
+		require.NoError(t, err, format)
+		require.Len(t, rows, 1, format)
+		require.NoError(t, rows[0].Err, format)
+		assert.Equal(t, line, rows[0].Line, format)
+		assert.Equal(t, sampleRecord(), rows[0].Record, format)
+		assert.NoError(t, rows[0].Record.Validate(), format)
+	}
+}
+
+func TestReadRows_ReportsBadRows(t *testing.T) {
+	ndjson := `{"external_id": "a", "title": "A"}
+
+not json
+{"external_id": "b", "titel": "B"}
+`
+	rows, err := catalog.ReadRows(strings.NewReader(ndjson), catalog.FormatNDJSON)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Error(t, rows[1].Err)
+	assert.ErrorContains(t, rows[2].Err, "titel")
+
+	csv := "external_id,title,year\na,A,1990\nb,B\nc,C,soon\n"
+	rows, err = catalog.ReadRows(strings.NewReader(csv), catalog.FormatCSV)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.NoError(t, rows[0].Err)
+	assert.Equal(t, 1990, *rows[0].Record.Year)
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Error(t, rows[1].Err)
+	assert.ErrorContains(t, rows[2].Err, "year")
+
+	_, err = catalog.ReadRows(strings.NewReader("external_id,name\n"), catalog.FormatCSV)
+	assert.ErrorContains(t, err, "name")
+}
+
+func TestValidate(t *testing.T) {
+	record := sampleRecord()
+	record.Status = "paused"
+	record.Genres = nil
+	record.Chapters = append(record.Chapters,
+		catalog.Chapter{Number: "2"},
+		catalog.Chapter{Number: "3", PublicationState: "scheduled"},
+	)
+
+	err := record.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: oneof")
+	assert.Contains(t, err.Error(), "genres: required")
+	assert.Contains(t, err.Error(), "chapters[3].publish_at: required_if")
+	assert.Contains(t, err.Error(), `duplicate number "2"`)
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := catalog.ParseFormat("", "backup/catalog.CSV")
+	require.NoError(t, err)
+	assert.Equal(t, catalog.FormatCSV, format)
+
+	format, err = catalog.ParseFormat("ndjson", "catalog.txt")
+	require.NoError(t, err)
+	assert.Equal(t, catalog.FormatNDJSON, format)
+
+	_, err = catalog.ParseFormat("", "catalog.txt")
+	assert.Error(t, err)
+}
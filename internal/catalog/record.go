@@ -0,0 +1,268 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/go-playground/validator/v10"
+)
+
+// Record is a manga as it is exported and imported, with the metadata of its chapters. Covers,
+// pages and relations aren't part of the catalog.
+type Record struct {
+	// Key the manga is imported under. Exported manga that weren't imported use their own ID,
+	// so a backup can be restored onto the same database without duplicating anything.
+	ExternalID       string     `json:"external_id" validate:"required,max=100"`
+	Title            string     `json:"title" validate:"required,min=2,max=255"`
+	Description      string     `json:"description" validate:"required"`
+	Author           string     `json:"author,omitempty" validate:"required_without=Creators,omitempty,min=2,max=255"` // Split into creators unless they are given
+	Creators         []Creator  `json:"creators,omitempty" validate:"max=20,dive"`
+	Status           string     `json:"status" validate:"required,oneof=ongoing completed hiatus cancelled"`
+	ContentRating    string     `json:"content_rating,omitempty" validate:"omitempty,oneof=safe suggestive explicit"` // Safe for new manga, unchanged for existing ones if empty
+	Genres           []string   `json:"genres" validate:"required,min=1,dive,required"`                               // Tag names or slugs
+	AltTitles        []AltTitle `json:"alt_titles,omitempty" validate:"dive"`
+	Demographic      string     `json:"demographic,omitempty" validate:"omitempty,oneof=shounen shoujo seinen josei"`
+	Year             *int       `json:"year,omitempty" validate:"omitempty,min=1800,max=2200"`
+	OriginalLanguage string     `json:"original_language,omitempty" validate:"max=10"`
+	LastVolume       string     `json:"last_volume,omitempty" validate:"max=20"`
+	LastChapter      string     `json:"last_chapter,omitempty" validate:"max=20"`
+	OfficialURL      string     `json:"official_url,omitempty" validate:"omitempty,url,max=255"`
+	MyAnimeListID    *int       `json:"mal_id,omitempty" validate:"omitempty,min=1"`
+	AniListID        *int       `json:"anilist_id,omitempty" validate:"omitempty,min=1"`
+	MangaUpdatesID   string     `json:"mangaupdates_id,omitempty" validate:"omitempty,alphanum,max=20"`
+	SearchLanguage   string     `json:"search_language,omitempty" validate:"max=10"` // Like ContentRating, "en" for new manga if empty
+	Chapters         []Chapter  `json:"chapters,omitempty" validate:"dive"`
+}
+
+// Creator credits a creator by name. Creators are matched by name on import, or created.
+type Creator struct {
+	Name string `json:"name" validate:"required,max=100"`
+	Role string `json:"role" validate:"required,oneof=story art"`
+}
+
+type AltTitle struct {
+	Title    string `json:"title" validate:"required,max=255"`
+	Language string `json:"language" validate:"required,max=10"`
+}
+
+// Chapter is the metadata of a chapter, matched by number on import. Chapters that aren't in a
+// record are left as they are.
+type Chapter struct {
+	Number string `json:"number" validate:"required,max=20"`
+	Title  string `json:"title,omitempty" validate:"max=255"`
+	Volume string `json:"volume,omitempty" validate:"max=20"`
+	// Drafts for new chapters and unchanged for existing ones if empty
+	PublicationState string     `json:"publication_state,omitempty" validate:"omitempty,oneof=draft scheduled published unpublished"`
+	PublishAt        *time.Time `json:"publish_at,omitempty" validate:"required_if=PublicationState scheduled"`
+}
+
+var validate = newValidator()
+
+// newValidator returns a validator that names fields by their JSON names, as they appear in
+// import files.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		return name
+	})
+	return v
+}
+
+// Validate checks the record like the API checks a manga, and lists every problem found.
+func (r *Record) Validate() error {
+	var problems []string
+	if err := validate.Struct(r); err != nil {
+		var ve validator.ValidationErrors
+		if !errors.As(err, &ve) {
+			return err
+		}
+		for _, fe := range ve {
+			_, field, _ := strings.Cut(fe.Namespace(), ".") // Drop the "Record." prefix
+			rule := fe.Tag()
+			if fe.Param() != "" {
+				rule += "=" + fe.Param()
+			}
+			problems = append(problems, fmt.Sprintf("%s: %s", field, rule))
+		}
+	}
+
+	seen := make(map[string]bool, len(r.Chapters))
+	for _, chapter := range r.Chapters {
+		if seen[chapter.Number] {
+			problems = append(problems, fmt.Sprintf("chapters: duplicate number %q", chapter.Number))
+		}
+		seen[chapter.Number] = true
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// recordOf returns the record of a manga and its chapters.
+func recordOf(manga *domain.Manga, chapters []*domain.Chapter) *Record {
+	r := &Record{
+		ExternalID:       deref(manga.ExternalID),
+		Title:            manga.Title,
+		Description:      manga.Description,
+		Author:           manga.Author,
+		Status:           string(manga.Status),
+		ContentRating:    string(manga.ContentRating),
+		Genres:           manga.Genres,
+		Year:             manga.Year,
+		OriginalLanguage: deref(manga.OriginalLanguage),
+		LastVolume:       deref(manga.LastVolume),
+		LastChapter:      deref(manga.LastChapter),
+		OfficialURL:      deref(manga.Links.OfficialURL),
+		MyAnimeListID:    manga.Links.MyAnimeListID,
+		AniListID:        manga.Links.AniListID,
+		MangaUpdatesID:   deref(manga.Links.MangaUpdatesID),
+		SearchLanguage:   manga.SearchLanguage,
+	}
+	if r.ExternalID == "" {
+		r.ExternalID = manga.ID.String()
+	}
+	if manga.Demographic != nil {
+		r.Demographic = string(*manga.Demographic)
+	}
+	for _, credit := range manga.Creators {
+		r.Creators = append(r.Creators, Creator{Name: credit.Name, Role: string(credit.Role)})
+	}
+	for _, title := range manga.AltTitles {
+		r.AltTitles = append(r.AltTitles, AltTitle{Title: title.Title, Language: title.Language})
+	}
+	for _, chapter := range chapters {
+		r.Chapters = append(r.Chapters, Chapter{
+			Number:           chapter.ChapterNumber,
+			Title:            deref(chapter.Title),
+			Volume:           deref(chapter.Volume),
+			PublicationState: string(chapter.PublicationState),
+			PublishAt:        chapter.PublishAt,
+		})
+	}
+	return r
+}
+
+// applyTo sets the fields of the record on the manga, leaving its ID, cover and relations alone.
+func (r *Record) applyTo(manga *domain.Manga) {
+	manga.Title = r.Title
+	manga.Description = r.Description
+	manga.Author = r.Author
+	manga.Creators = nil
+	for _, credit := range r.Creators {
+		manga.Creators = append(manga.Creators, domain.MangaCreator{Name: credit.Name, Role: domain.CreatorRole(credit.Role)})
+	}
+	manga.Status = domain.MangaStatus(r.Status)
+	if r.ContentRating != "" {
+		manga.ContentRating = domain.ContentRating(r.ContentRating)
+	}
+	manga.Genres = r.Genres
+	manga.AltTitles = nil
+	for _, title := range r.AltTitles {
+		manga.AltTitles = append(manga.AltTitles, domain.MangaTitle{Title: title.Title, Language: title.Language})
+	}
+	manga.Demographic = nil
+	if r.Demographic != "" {
+		demographic := domain.Demographic(r.Demographic)
+		manga.Demographic = &demographic
+	}
+	manga.Year = r.Year
+	manga.OriginalLanguage = optional(r.OriginalLanguage)
+	manga.LastVolume = optional(r.LastVolume)
+	manga.LastChapter = optional(r.LastChapter)
+	manga.Links = domain.MangaLinks{
+		OfficialURL:    optional(r.OfficialURL),
+		MyAnimeListID:  r.MyAnimeListID,
+		AniListID:      r.AniListID,
+		MangaUpdatesID: optional(r.MangaUpdatesID),
+	}
+	if r.SearchLanguage != "" {
+		manga.SearchLanguage = r.SearchLanguage
+	}
+}
+
+// sameAs reports whether importing the record would leave the manga as it is. Its chapters
+// are compared on their own. Genres given by slug count as a change.
+func (r *Record) sameAs(manga *domain.Manga) bool {
+	current := recordOf(manga, nil)
+	incoming := *r
+	incoming.ExternalID, incoming.Chapters = current.ExternalID, nil
+	if incoming.ContentRating == "" {
+		incoming.ContentRating = current.ContentRating
+	}
+	if incoming.SearchLanguage == "" {
+		incoming.SearchLanguage = current.SearchLanguage
+	}
+	// The author line and the credits are derived from each other, so only the given one counts
+	if len(incoming.Creators) == 0 {
+		current.Creators = nil
+	} else {
+		incoming.Author, current.Author = "", ""
+	}
+	return reflect.DeepEqual(current.normalized(), incoming.normalized())
+}
+
+// normalized returns the record with its lists sorted like the repository lists them, and
+// empty lists as nil.
+func (r Record) normalized() Record {
+	r.Genres = sortedOrNil(r.Genres, strings.Compare)
+	r.Creators = sortedOrNil(r.Creators, func(a, b Creator) int {
+		return strings.Compare(a.Role+"\x00"+a.Name, b.Role+"\x00"+b.Name)
+	})
+	r.AltTitles = sortedOrNil(r.AltTitles, func(a, b AltTitle) int {
+		return strings.Compare(a.Language+"\x00"+a.Title, b.Language+"\x00"+b.Title)
+	})
+	return r
+}
+
+func sortedOrNil[T any](values []T, cmp func(a, b T) int) []T {
+	if len(values) == 0 {
+		return nil
+	}
+	return slices.SortedFunc(slices.Values(values), cmp)
+}
+
+// applyTo sets the metadata on the chapter. Pages are left as they are.
+func (c *Chapter) applyTo(chapter *domain.Chapter) {
+	chapter.ChapterNumber = c.Number
+	chapter.Title = optional(c.Title)
+	chapter.Volume = optional(c.Volume)
+	chapter.PublicationState = domain.PublicationState(c.PublicationState)
+	chapter.PublishAt = c.PublishAt
+}
+
+// sameAs reports whether importing the metadata would leave the chapter as it is.
+func (c *Chapter) sameAs(chapter *domain.Chapter) bool {
+	if c.Title != deref(chapter.Title) || c.Volume != deref(chapter.Volume) {
+		return false
+	}
+	if c.PublicationState == "" {
+		return true
+	}
+	if domain.PublicationState(c.PublicationState) != chapter.PublicationState {
+		return false
+	}
+	return c.PublishAt == nil || (chapter.PublishAt != nil && c.PublishAt.Equal(*chapter.PublishAt))
+}
+
+// optional returns nil for an empty string, which is stored as NULL.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -4,6 +4,9 @@ import (
 	"log"
 	"time"
 
+	"github.com/0xpanadol/manga/internal/search"
+	"github.com/0xpanadol/manga/pkg/archive"
+	"github.com/0xpanadol/manga/pkg/storage"
 	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
 )
@@ -24,8 +27,20 @@ type Config struct {
 	MinioSecretKey      string        `mapstructure:"MINIO_SECRET_KEY"`
 	MinioBucketName     string        `mapstructure:"MINIO_BUCKET_NAME"`
 	MinioUseSSL         bool          `mapstructure:"MINIO_USE_SSL"`
+	StorageDriver       string        `mapstructure:"STORAGE_DRIVER" validate:"omitempty,oneof=minio local"`
+	StorageLocalPath    string        `mapstructure:"STORAGE_LOCAL_PATH" validate:"required_if=StorageDriver local"`
+	StoragePublicURL    string        `mapstructure:"STORAGE_PUBLIC_URL" validate:"required_if=StorageDriver local"`
+	ArchiveMaxPages     int           `mapstructure:"ARCHIVE_MAX_PAGES"`
+	ArchiveMaxSize      int64         `mapstructure:"ARCHIVE_MAX_SIZE"`
+	PublishInterval     time.Duration `mapstructure:"CHAPTER_PUBLISH_INTERVAL"` // How often the worker publishes scheduled chapters
+	TrashRetention      time.Duration `mapstructure:"TRASH_RETENTION"`          // How long deleted manga and chapters stay restorable
+	SearchEngine        string        `mapstructure:"SEARCH_ENGINE" validate:"omitempty,oneof=postgres meilisearch"`
+	MeilisearchURL      string        `mapstructure:"MEILISEARCH_URL" validate:"required_if=SearchEngine meilisearch"`
+	MeilisearchAPIKey   string        `mapstructure:"MEILISEARCH_API_KEY"`
+	MeilisearchIndex    string        `mapstructure:"MEILISEARCH_INDEX"`
 	RedisAddr           string        `mapstructure:"REDIS_ADDR"`
 	CorsAllowedOrigins  []string      `mapstructure:"CORS_ALLOWED_ORIGINS"`
+	RequireIfMatch      bool          `mapstructure:"REQUIRE_IF_MATCH"` // Reject manga and chapter writes without If-Match
 	RabbitMQUrl         string        `mapstructure:"RABBITMQ_URL" validate:"required"`
 	SmtpHost            string        `mapstructure:"SMTP_HOST" validate:"required"`
 	SmtpPort            int           `mapstructure:"SMTP_PORT" validate:"required"`
@@ -70,3 +85,36 @@ func LoadConfig() (config Config, err error) {
 
 	return
 }
+
+// StorageConfig returns the settings for the configured object storage backend.
+func (c Config) StorageConfig() storage.Config {
+	return storage.Config{
+		Driver:          c.StorageDriver,
+		MinioEndpoint:   c.MinioEndpoint,
+		MinioAccessKey:  c.MinioAccessKey,
+		MinioSecretKey:  c.MinioSecretKey,
+		MinioBucketName: c.MinioBucketName,
+		MinioUseSSL:     c.MinioUseSSL,
+		LocalPath:       c.StorageLocalPath,
+		LocalBaseURL:    c.StoragePublicURL,
+	}
+}
+
+// ArchiveLimits returns the limits applied when extracting uploaded chapter archives.
+// Unset values fall back to archive.DefaultLimits.
+func (c Config) ArchiveLimits() archive.Limits {
+	return archive.Limits{
+		MaxPages:     c.ArchiveMaxPages,
+		MaxTotalSize: c.ArchiveMaxSize,
+	}
+}
+
+// SearchConfig returns the settings for the configured search engine.
+func (c Config) SearchConfig() search.Config {
+	return search.Config{
+		Engine:            c.SearchEngine,
+		MeilisearchURL:    c.MeilisearchURL,
+		MeilisearchAPIKey: c.MeilisearchAPIKey,
+		MeilisearchIndex:  c.MeilisearchIndex,
+	}
+}
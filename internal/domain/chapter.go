@@ -6,12 +6,52 @@ import (
 	"github.com/google/uuid"
 )
 
+type PublicationState string
+
+const (
+	PublicationDraft       PublicationState = "draft"
+	PublicationScheduled   PublicationState = "scheduled"
+	PublicationPublished   PublicationState = "published"
+	PublicationUnpublished PublicationState = "unpublished"
+)
+
+// ChapterReviewStatus tells whether a chapter submitted for review may go live.
+type ChapterReviewStatus string
+
+const (
+	ChapterReviewPending  ChapterReviewStatus = "pending"
+	ChapterReviewApproved ChapterReviewStatus = "approved" // Also chapters that didn't need a review
+	ChapterReviewRejected ChapterReviewStatus = "rejected" // Moved to the trash
+)
+
 type Chapter struct {
-	ID            uuid.UUID
-	MangaID       uuid.UUID
-	ChapterNumber string
-	Title         *string // Optional
-	Pages         []string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID               uuid.UUID
+	MangaID          uuid.UUID
+	ChapterNumber    string
+	Title            *string // Optional
+	Volume           *string // Optional, e.g. "1" or "Extra"
+	Pages            []string
+	PublicationState PublicationState
+	PublishAt        *time.Time // When a scheduled chapter goes live, or when a published one did
+	Version          int        // Incremented on every change
+	DeletedAt        *time.Time // Set while the chapter, or its manga, is in the trash
+	UploadedBy       *uuid.UUID
+	ReviewStatus     ChapterReviewStatus
+	ReviewedBy       *uuid.UUID
+	ReviewedAt       *time.Time
+	RejectionReason  *string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// IsPublished reports whether readers can see the chapter.
+func (c *Chapter) IsPublished() bool {
+	return c.PublicationState == PublicationPublished
+}
+
+// ChapterSubmission is a chapter submitted for review, with what its reviewer needs to know.
+type ChapterSubmission struct {
+	Chapter
+	MangaTitle       string
+	UploaderUsername *string // Nil if the uploader's account is gone
 }
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cover is one of a manga's cover images. The primary cover is the one shown as Manga.CoverImageURL.
+type Cover struct {
+	ID           uuid.UUID
+	MangaID      uuid.UUID
+	URL          string
+	ThumbnailURL string
+	Volume       *string // Set for volume-specific covers
+	Language     *string // Set for covers of a localized edition, e.g. "en"
+	IsPrimary    bool
+	CreatedAt    time.Time
+}
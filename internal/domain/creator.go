@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreatorRole string
+
+const (
+	CreatorRoleStory CreatorRole = "story"
+	CreatorRoleArt   CreatorRole = "art"
+)
+
+// Creator is an author or artist.
+type Creator struct {
+	ID        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+// MangaCreator credits a creator for a role on a manga. A creator who wrote and drew
+// a series is credited twice.
+type MangaCreator struct {
+	CreatorID uuid.UUID // Zero when the creator is given by name only and still has to be created
+	Name      string
+	Role      CreatorRole
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+type JobType string
+
+const (
+	JobTypeChapterArchive JobType = "chapter.archive"
+	JobTypeVolumeBundle   JobType = "volume.bundle"
+)
+
+// Job tracks asynchronous work processed by the worker.
+type Job struct {
+	ID        uuid.UUID
+	Type      JobType
+	Status    JobStatus
+	Payload   json.RawMessage `swaggertype:"object"`
+	Result    json.RawMessage `swaggertype:"object"` // Set once the job completes
+	Error     *string         // Set if the job fails
+	CreatedBy *uuid.UUID      // Nullable
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
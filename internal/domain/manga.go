@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,14 +17,127 @@ const (
 	StatusCancelled MangaStatus = "cancelled"
 )
 
+// Demographic is the readership a manga is published for.
+type Demographic string
+
+const (
+	DemographicShounen Demographic = "shounen"
+	DemographicShoujo  Demographic = "shoujo"
+	DemographicSeinen  Demographic = "seinen"
+	DemographicJosei   Demographic = "josei"
+)
+
+// ContentRating tells how mature a manga's content is.
+type ContentRating string
+
+const (
+	ContentRatingSafe       ContentRating = "safe"
+	ContentRatingSuggestive ContentRating = "suggestive"
+	ContentRatingExplicit   ContentRating = "explicit"
+)
+
+// ContentRatings lists every rating, from least to most mature.
+var ContentRatings = []ContentRating{ContentRatingSafe, ContentRatingSuggestive, ContentRatingExplicit}
+
+// DefaultContentRatings are shown to logged-out visitors and to users who haven't chosen otherwise.
+var DefaultContentRatings = []ContentRating{ContentRatingSafe}
+
+// DefaultSearchLanguage is the search language of manga and searches that don't set one.
+const DefaultSearchLanguage = "en"
+
+// AllowedBy reports whether the rating is one of the allowed ones.
+func (r ContentRating) AllowedBy(allowed []ContentRating) bool {
+	return slices.Contains(allowed, r)
+}
+
 type Manga struct {
 	ID            uuid.UUID
 	Title         string
+	DisplayTitle  string       // Title in the language requested by the client, see Localize
+	AltTitles     []MangaTitle // Alternative and localized titles
 	Description   string
-	Author        string
+	Author        string         // Credit line, derived from Creators
+	Creators      []MangaCreator // Credited authors and artists
 	Status        MangaStatus
+	ContentRating ContentRating
 	CoverImageURL *string // Use a pointer to handle NULL values
 	Genres        []string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// Optional publication details
+	Demographic      *Demographic
+	Year             *int    // Year the serialization started
+	OriginalLanguage *string // BCP 47 tag, e.g. "ja" or "ko"
+	LastVolume       *string // Final volume and chapter, once known
+	LastChapter      *string
+	Links            MangaLinks
+	SearchLanguage   string          // BCP 47 tag of the title and description, picks how they are indexed for search
+	Relations        []MangaRelation // Sequels, spin-offs, adaptations and so on
+	ExternalID       *string         // Key in the catalog the manga was imported from, see internal/catalog
+	Version          int             // Incremented on every change to the manga, its cover or its relations
+	DeletedAt        *time.Time      // Set while the manga is in the trash
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// MangaLinks point to the manga in stores and on other catalog sites. All are optional.
+type MangaLinks struct {
+	OfficialURL    *string // Official English release or store page
+	MyAnimeListID  *int
+	AniListID      *int
+	MangaUpdatesID *string // The base-36 series ID from the mangaupdates.com URL
+}
+
+// MangaSuggestion is a manga suggested while typing a search, by its best matching title.
+type MangaSuggestion struct {
+	MangaID uuid.UUID
+	Title   string
+}
+
+// MangaFacets count the manga of a search by tag, status, content rating and year. The counts
+// of a field leave out the search's own filter on it, so they tell how many results picking
+// another value would give.
+type MangaFacets struct {
+	Genres         map[string]int // By tag name
+	Statuses       map[MangaStatus]int
+	ContentRatings map[ContentRating]int
+	Years          map[int]int // Manga without a year are not counted
+}
+
+// MangaTitle is an alternative title, e.g. the Japanese, romaji or English name of a series.
+type MangaTitle struct {
+	Title    string
+	Language string // BCP 47 tag, e.g. "ja", "ja-ro" (romaji) or "en"
+}
+
+// Localize sets DisplayTitle to the title best matching the preferred languages, in order.
+// An exact tag match wins over a match on the base language ("en" matches "en-US" and vice versa).
+// Without a match the main title is used.
+func (m *Manga) Localize(languages []string) {
+	m.DisplayTitle = m.Title
+	for _, lang := range languages {
+		if title, ok := m.titleFor(lang); ok {
+			m.DisplayTitle = title
+			return
+		}
+	}
+}
+
+func (m *Manga) titleFor(lang string) (string, bool) {
+	lang = strings.ToLower(lang)
+	for _, t := range m.AltTitles {
+		if strings.ToLower(t.Language) == lang {
+			return t.Title, true
+		}
+	}
+	base := baseLanguage(lang)
+	for _, t := range m.AltTitles {
+		if baseLanguage(strings.ToLower(t.Language)) == base {
+			return t.Title, true
+		}
+	}
+	return "", false
+}
+
+func baseLanguage(tag string) string {
+	base, _, _ := strings.Cut(tag, "-")
+	return base
 }
@@ -0,0 +1,45 @@
+package domain
+
+import "github.com/google/uuid"
+
+// RelationType tells how a related manga relates to a manga, e.g. "B is a sequel of A".
+type RelationType string
+
+const (
+	RelationSequel           RelationType = "sequel"
+	RelationPrequel          RelationType = "prequel"
+	RelationSideStory        RelationType = "side_story"
+	RelationMainStory        RelationType = "main_story"
+	RelationSpinOff          RelationType = "spin_off"
+	RelationOriginalStory    RelationType = "original_story"
+	RelationAlternateVersion RelationType = "alternate_version"
+	RelationAdaptedFrom      RelationType = "adapted_from"
+	RelationAdaptation       RelationType = "adaptation"
+)
+
+// inverseRelations pairs each relation type with the one seen from the other manga.
+var inverseRelations = map[RelationType]RelationType{
+	RelationSequel:           RelationPrequel,
+	RelationPrequel:          RelationSequel,
+	RelationSideStory:        RelationMainStory,
+	RelationMainStory:        RelationSideStory,
+	RelationSpinOff:          RelationOriginalStory,
+	RelationOriginalStory:    RelationSpinOff,
+	RelationAlternateVersion: RelationAlternateVersion,
+	RelationAdaptedFrom:      RelationAdaptation,
+	RelationAdaptation:       RelationAdaptedFrom,
+}
+
+// Inverse returns the relation from the related manga back to the manga: if B is a sequel
+// of A, A is a prequel of B.
+func (t RelationType) Inverse() RelationType {
+	return inverseRelations[t]
+}
+
+// MangaRelation is a related manga, as listed on a manga.
+type MangaRelation struct {
+	MangaID       uuid.UUID // The related manga
+	Title         string
+	ContentRating ContentRating
+	Type          RelationType
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RevisionAction string
+
+const (
+	RevisionCreate  RevisionAction = "create"
+	RevisionUpdate  RevisionAction = "update"
+	RevisionDelete  RevisionAction = "delete"  // Moved to the trash
+	RevisionRestore RevisionAction = "restore" // Taken out of the trash
+	RevisionRevert  RevisionAction = "revert"  // Set back to an earlier revision
+)
+
+// Revision records a change to a manga or one of its chapters: who made it, when, and how each
+// field changed.
+type Revision struct {
+	ID            uuid.UUID
+	MangaID       uuid.UUID
+	ChapterID     *uuid.UUID // Set for revisions of chapters
+	Action        RevisionAction
+	ActorID       *uuid.UUID // Nil for changes made by the system, e.g. scheduled publication
+	ActorUsername *string
+	Changes       map[string]FieldChange // By field name; only the fields that changed
+	Snapshot      map[string]any         // The tracked fields after the change, to revert to. Nil for deletes
+	RevertedFrom  *uuid.UUID             // The revision a revert went back to
+	CreatedAt     time.Time
+}
+
+// FieldChange holds a field's value before and after a change, as decoded from JSON. Old is nil
+// for creations.
+type FieldChange struct {
+	Old any
+	New any
+}
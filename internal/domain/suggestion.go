@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SuggestionStatus string
+
+const (
+	SuggestionPending  SuggestionStatus = "pending"
+	SuggestionApproved SuggestionStatus = "approved"
+	SuggestionRejected SuggestionStatus = "rejected"
+)
+
+// EditSuggestion is an edit to a manga proposed by a user, applied once a moderator approves it.
+type EditSuggestion struct {
+	ID              uuid.UUID
+	MangaID         uuid.UUID
+	MangaTitle      string
+	UserID          uuid.UUID
+	Username        string
+	Changes         map[string]FieldChange // By field name, against the manga as it was when submitted
+	Note            *string                // The submitter's explanation, e.g. a source
+	Status          SuggestionStatus
+	ReviewedBy      *uuid.UUID
+	ReviewedAt      *time.Time
+	RejectionReason *string
+	CreatedAt       time.Time
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TagGroup string
+
+const (
+	TagGroupGenre          TagGroup = "genre"
+	TagGroupTheme          TagGroup = "theme"
+	TagGroupFormat         TagGroup = "format"
+	TagGroupContentWarning TagGroup = "content_warning"
+)
+
+// Tag classifies manga. Manga.Genres lists the names of a manga's tags, whatever their group.
+type Tag struct {
+	ID          uuid.UUID
+	Name        string
+	Slug        string
+	Description string
+	Group       TagGroup
+	CreatedAt   time.Time
+}
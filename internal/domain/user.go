@@ -7,11 +7,12 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID
-	Username     string
-	Email        string
-	PasswordHash string
-	RoleID       uuid.UUID
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID             uuid.UUID
+	Username       string
+	Email          string
+	PasswordHash   string
+	RoleID         uuid.UUID
+	ContentRatings []ContentRating // Manga content ratings the user has opted into
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
@@ -0,0 +1,229 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/0xpanadol/manga/pkg/archive"
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusCreated Status = "created" // New chapter created and its pages uploaded
+	StatusResumed Status = "resumed" // Existing chapter without pages, e.g. from an interrupted run
+	StatusSkipped Status = "skipped" // Existing chapter that already has pages
+	StatusFailed  Status = "failed"
+)
+
+// Options configures an import run.
+type Options struct {
+	Concurrency int  // Number of chapters processed at once
+	DryRun      bool // Validate sources and report what would happen without writing anything
+	Publish     bool // Publish new chapters right away instead of creating drafts
+	Limits      archive.Limits
+}
+
+// Result is the outcome for a single chapter.
+type Result struct {
+	Chapter Chapter
+	Status  Status
+	Pages   int
+	Err     error
+}
+
+// Report summarizes an import run.
+type Report struct {
+	DryRun  bool
+	Results []Result
+}
+
+type Importer struct {
+	chapterService *service.ChapterService
+	opts           Options
+}
+
+func New(chapterService *service.ChapterService, opts Options) *Importer {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	return &Importer{chapterService: chapterService, opts: opts}
+}
+
+// Run imports the chapters into a manga. Chapters that already have pages are skipped,
+// so an interrupted import can simply be run again. Chapter numbers are matched without
+// their zero padding, so "011" resumes chapter "11".
+func (im *Importer) Run(ctx context.Context, mangaID uuid.UUID, chapters []Chapter) *Report {
+	results := make([]Result, len(chapters))
+	existing, err := im.existingChapters(ctx, mangaID)
+	if err != nil {
+		for i, chapter := range chapters {
+			results[i] = failed(Result{Chapter: chapter}, err)
+		}
+		return &Report{DryRun: im.opts.DryRun, Results: results}
+	}
+	seen := make(map[string]bool, len(chapters))
+
+	sem := make(chan struct{}, im.opts.Concurrency)
+	var wg sync.WaitGroup
+	for i, chapter := range chapters {
+		number := normalizeNumber(chapter.Number)
+		if seen[number] {
+			results[i] = Result{Chapter: chapter, Status: StatusFailed, Err: errors.New("duplicate chapter number")}
+			continue
+		}
+		seen[number] = true
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := im.importChapter(ctx, mangaID, chapter, existing[number])
+			if result.Err != nil {
+				log.Printf("Chapter %s: %s: %v", chapter.Number, result.Status, result.Err)
+			} else {
+				log.Printf("Chapter %s: %s (%d pages)", chapter.Number, result.Status, result.Pages)
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	return &Report{DryRun: im.opts.DryRun, Results: results}
+}
+
+// existingChapters returns the chapters of the manga, trashed ones aside, by their number
+// without zero padding.
+func (im *Importer) existingChapters(ctx context.Context, mangaID uuid.UUID) (map[string]*domain.Chapter, error) {
+	chapters := make(map[string]*domain.Chapter)
+	params := repository.ListChaptersParams{MangaID: mangaID, Limit: 100}
+	for {
+		page, err := im.chapterService.ListByMangaID(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list existing chapters: %w", err)
+		}
+		for _, chapter := range page.Items {
+			chapters[normalizeNumber(chapter.ChapterNumber)] = chapter
+		}
+		if page.NextCursor == "" {
+			return chapters, nil
+		}
+		params.Cursor = page.NextCursor
+	}
+}
+
+// importChapter imports a chapter into the existing chapter with its number, if there is one.
+func (im *Importer) importChapter(ctx context.Context, mangaID uuid.UUID, source Chapter, existing *domain.Chapter) Result {
+	result := Result{Chapter: source, Status: StatusCreated}
+
+	switch {
+	case existing != nil && len(existing.Pages) > 0:
+		result.Status = StatusSkipped
+		result.Pages = len(existing.Pages)
+		return result
+	case existing != nil:
+		result.Status = StatusResumed
+	}
+
+	// Load and validate the pages before creating anything, so a broken source doesn't leave an empty chapter.
+	pages, err := loadPages(source.Path, im.opts.Limits)
+	if err != nil {
+		return failed(result, err)
+	}
+	result.Pages = len(pages)
+	if im.opts.DryRun {
+		return result
+	}
+
+	if existing == nil {
+		existing = &domain.Chapter{
+			MangaID:       mangaID,
+			ChapterNumber: source.Number,
+			Title:         source.Title,
+			Volume:        source.Volume,
+			Pages:         []string{},
+		}
+		if im.opts.Publish {
+			existing.PublicationState = domain.PublicationPublished
+		}
+		if err := im.chapterService.Create(ctx, existing); err != nil {
+			return failed(result, err)
+		}
+	}
+
+	if err := im.chapterService.ReplacePages(ctx, existing.ID, pages); err != nil {
+		return failed(result, err)
+	}
+	return result
+}
+
+func failed(result Result, err error) Result {
+	result.Status = StatusFailed
+	result.Err = err
+	return result
+}
+
+// loadPages reads the pages of a chapter folder or CBZ/ZIP archive.
+func loadPages(path string, limits archive.Limits) ([]archive.Page, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return archive.ExtractDir(os.DirFS(path), limits)
+	}
+	if !isArchiveName(path) {
+		return nil, fmt.Errorf("%s is neither a folder nor a .cbz/.zip archive", path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return archive.ExtractPages(f, info.Size(), limits)
+}
+
+// Count returns the number of results with the given status.
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Print writes a table of every chapter followed by the totals.
+func (r *Report) Print(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHAPTER\tSTATUS\tPAGES\tSOURCE\tERROR")
+	for _, result := range r.Results {
+		errMsg := ""
+		if result.Err != nil {
+			errMsg = result.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", result.Chapter.Number, result.Status, result.Pages, result.Chapter.Path, errMsg)
+	}
+	tw.Flush()
+
+	prefix := ""
+	if r.DryRun {
+		prefix = "Dry run, nothing was written. "
+	}
+	fmt.Fprintf(w, "\n%s%d created, %d resumed, %d skipped, %d failed\n", prefix,
+		r.Count(StatusCreated), r.Count(StatusResumed), r.Count(StatusSkipped), r.Count(StatusFailed))
+}
@@ -0,0 +1,155 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/0xpanadol/manga/pkg/archive"
+	"gopkg.in/yaml.v3"
+)
+
+// Chapter is a single chapter to import and the folder or archive holding its pages.
+type Chapter struct {
+	Number string  `json:"number" yaml:"number"`
+	Title  *string `json:"title,omitempty" yaml:"title,omitempty"`
+	Volume *string `json:"volume,omitempty" yaml:"volume,omitempty"`
+	Path   string  `json:"path" yaml:"path"` // Folder of images or a .cbz/.zip archive
+}
+
+// Manifest lists chapters explicitly, for sources whose names can't be parsed.
+type Manifest struct {
+	MangaID  string    `json:"manga_id,omitempty" yaml:"manga_id,omitempty"`
+	Chapters []Chapter `json:"chapters" yaml:"chapters"`
+}
+
+// LoadManifest reads a JSON or YAML manifest. Relative chapter paths are resolved
+// against the manifest's own directory.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &manifest)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &manifest)
+	default:
+		return nil, fmt.Errorf("manifest must be a .json, .yaml or .yml file")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	base := filepath.Dir(path)
+	for i, chapter := range manifest.Chapters {
+		if chapter.Number == "" || chapter.Path == "" {
+			return nil, fmt.Errorf("manifest chapter %d: number and path are required", i+1)
+		}
+		if !filepath.IsAbs(chapter.Path) {
+			manifest.Chapters[i].Path = filepath.Join(base, chapter.Path)
+		}
+	}
+	return &manifest, nil
+}
+
+// ScanDir treats every folder and .cbz/.zip archive directly under root as a chapter,
+// taking chapter and volume numbers from names like "Vol.02 Ch.011.5" or "chapter-12".
+func ScanDir(root string) ([]Chapter, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	var chapters []Chapter
+	var unparsed []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || name == "__MACOSX" {
+			continue
+		}
+		if !entry.IsDir() && !isArchiveName(name) {
+			continue
+		}
+
+		number, volume, ok := ParseChapterName(name)
+		if !ok {
+			unparsed = append(unparsed, name)
+			continue
+		}
+		chapter := Chapter{Number: number, Path: filepath.Join(root, name)}
+		if volume != "" {
+			chapter.Volume = &volume
+		}
+		chapters = append(chapters, chapter)
+	}
+
+	if len(unparsed) > 0 {
+		return nil, fmt.Errorf("cannot determine chapter numbers for %s; use a manifest instead", strings.Join(unparsed, ", "))
+	}
+	if len(chapters) == 0 {
+		return nil, errors.New("no chapter folders or archives found")
+	}
+
+	sort.SliceStable(chapters, func(i, j int) bool {
+		return archive.NaturalLess(chapters[i].Number, chapters[j].Number)
+	})
+	return chapters, nil
+}
+
+var (
+	volumePattern  = regexp.MustCompile(`(?i)(?:^|[^a-z])(?:volume|vol|v)[ ._-]*(\d+(?:\.\d+)?)`)
+	chapterPattern = regexp.MustCompile(`(?i)(?:^|[^a-z])(?:chapter|ch|c)[ ._-]*(\d+(?:\.\d+)?)`)
+	numberPattern  = regexp.MustCompile(`\d+(?:\.\d+)?`)
+)
+
+// ParseChapterName extracts the chapter number, and the volume if present, from a folder or
+// archive name. Without an explicit chapter marker the last number in the name is used.
+func ParseChapterName(name string) (number, volume string, ok bool) {
+	if isArchiveName(name) {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+
+	if m := volumePattern.FindStringSubmatchIndex(name); m != nil {
+		volume = normalizeNumber(name[m[2]:m[3]])
+		name = name[:m[0]] + " " + name[m[1]:]
+	}
+
+	if m := chapterPattern.FindStringSubmatch(name); m != nil {
+		return normalizeNumber(m[1]), volume, true
+	}
+	numbers := numberPattern.FindAllString(name, -1)
+	if len(numbers) == 0 {
+		return "", "", false
+	}
+	return normalizeNumber(numbers[len(numbers)-1]), volume, true
+}
+
+// normalizeNumber strips zero padding so "011.5" and "11.5" refer to the same chapter.
+func normalizeNumber(s string) string {
+	whole, fraction, hasFraction := strings.Cut(s, ".")
+	whole = strings.TrimLeft(whole, "0")
+	if whole == "" {
+		whole = "0"
+	}
+	if hasFraction {
+		return whole + "." + fraction
+	}
+	return whole
+}
+
+func isArchiveName(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".cbz", ".zip":
+		return true
+	}
+	return false
+}
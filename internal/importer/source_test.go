@@ -0,0 +1,74 @@
+package importer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xpanadol/manga/internal/importer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChapterName(t *testing.T) {
+	cases := []struct {
+		name, number, volume string
+	}{
+		{"Chapter 012", "12", ""},
+		{"Vol.02 Ch.011.5", "11.5", "2"},
+		{"berserk_v03_c020.cbz", "20", "3"},
+		{"045", "45", ""},
+		{"Series Name - 7.zip", "7", ""},
+		{"ch000", "0", ""},
+	}
+	for _, tc := range cases {
+		number, volume, ok := importer.ParseChapterName(tc.name)
+		require.True(t, ok, tc.name)
+		assert.Equal(t, tc.number, number, tc.name)
+		assert.Equal(t, tc.volume, volume, tc.name)
+	}
+
+	_, _, ok := importer.ParseChapterName("extras")
+	assert.False(t, ok)
+}
+
+func TestScanDir(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "Chapter 10"), 0o755))
+	require.NoError(t, os.Mkdir(filepath.Join(root, "Chapter 2"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "Chapter 3.cbz"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "notes.txt"), nil, 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".cache"), 0o755))
+
+	chapters, err := importer.ScanDir(root)
+	require.NoError(t, err)
+	require.Len(t, chapters, 3)
+	assert.Equal(t, "2", chapters[0].Number)
+	assert.Equal(t, "3", chapters[1].Number)
+	assert.Equal(t, "10", chapters[2].Number)
+	assert.Equal(t, filepath.Join(root, "Chapter 3.cbz"), chapters[1].Path)
+}
+
+func TestLoadManifest_ResolvesRelativePaths(t *testing.T) {
+	dir := t.TempDir()
+	manifest := `
+manga_id: 7d3c2a8e-3f7b-4c3e-9a55-2f0b6f1c9b10
+chapters:
+  - number: "1"
+    title: The Black Swordsman
+    volume: "1"
+    path: v01/c001
+  - number: "2"
+    path: /srv/import/c002.cbz
+`
+	path := filepath.Join(dir, "manifest.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(manifest), 0o644))
+
+	m, err := importer.LoadManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, "7d3c2a8e-3f7b-4c3e-9a55-2f0b6f1c9b10", m.MangaID)
+	require.Len(t, m.Chapters, 2)
+	assert.Equal(t, filepath.Join(dir, "v01/c001"), m.Chapters[0].Path)
+	assert.Equal(t, "The Black Swordsman", *m.Chapters[0].Title)
+	assert.Equal(t, "/srv/import/c002.cbz", m.Chapters[1].Path)
+}
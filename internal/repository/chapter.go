@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/0xpanadol/manga/internal/domain"
 	"github.com/google/uuid"
@@ -11,19 +12,56 @@ import (
 var (
 	ErrChapterNotFound      = errors.New("chapter not found")
 	ErrChapterAlreadyExists = errors.New("chapter with this number already exists for this manga")
+	ErrChapterReviewed      = errors.New("chapter has already been reviewed")
 )
 
 type ListChaptersParams struct {
-	MangaID uuid.UUID
-	Limit   int
-	Offset  int
+	MangaID       uuid.UUID
+	Limit         int
+	Offset        int
+	Cursor        string // NextCursor of the previous page; replaces Offset
+	PublishedOnly bool   // Hide drafts, scheduled and unpublished chapters
+}
+
+// ListSubmissionsParams pages through chapters submitted for review.
+type ListSubmissionsParams struct {
+	UploadedBy  uuid.UUID                  // Only chapters uploaded by this user, trashed ones included, if set
+	Status      domain.ChapterReviewStatus // Only chapters with this review status, if set
+	OldestFirst bool                       // As a queue, rather than newest first
+	Limit       int
+	Offset      int
+	Cursor      string // NextCursor of the previous page; replaces Offset
 }
 
 type ChapterRepository interface {
-	Create(ctx context.Context, chapter *domain.Chapter) error
+	Create(ctx context.Context, chapter *domain.Chapter, revise Revise[*domain.Chapter]) error
 	FindByID(ctx context.Context, id uuid.UUID) (*domain.Chapter, error)
-	ListByMangaID(ctx context.Context, params ListChaptersParams) ([]*domain.Chapter, error)
-	Update(ctx context.Context, chapter *domain.Chapter) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	FindByMangaAndNumber(ctx context.Context, mangaID uuid.UUID, chapterNumber string) (*domain.Chapter, error)
+	ListByMangaID(ctx context.Context, params ListChaptersParams) (*Page[*domain.Chapter], error)
+	ListByVolume(ctx context.Context, mangaID uuid.UUID, volume string) ([]*domain.Chapter, error)
+	// Update and Delete are conditional on the version like those of MangaRepository. Delete
+	// moves the chapter to the trash; like trashed manga, trashed chapters are left out by every
+	// other method but those below. Create, Update, Delete and PublishDue record the revisions
+	// made by revise like MangaRepository.Update, one for each chapter PublishDue publishes.
+	Update(ctx context.Context, chapter *domain.Chapter, revise Revise[*domain.Chapter]) error
+	Delete(ctx context.Context, id uuid.UUID, version int, revise Revise[uuid.UUID]) error
 	UpdatePages(ctx context.Context, id uuid.UUID, pages []string) error
+	PublishDue(ctx context.Context, now time.Time, revise Revise[*domain.Chapter]) ([]*domain.Chapter, error)
+
+	// ListSubmissions lists submitted chapters. Unless they are listed by uploader, trashed
+	// chapters are left out.
+	ListSubmissions(ctx context.Context, params ListSubmissionsParams) (*Page[*domain.ChapterSubmission], error)
+	// Approve and Reject review a pending chapter, returning ErrChapterReviewed if it isn't
+	// pending anymore. Approve sets its publication state; Reject moves it to the trash.
+	Approve(ctx context.Context, id, reviewerID uuid.UUID, state domain.PublicationState, publishAt *time.Time) (*domain.ChapterSubmission, error)
+	Reject(ctx context.Context, id, reviewerID uuid.UUID, reason string) (*domain.ChapterSubmission, error)
+
+	// ListDeleted and ListDeletedBefore list the chapters trashed on their own, not along with
+	// their manga. Restore returns ErrMangaNotFound while the chapter's manga is in the trash.
+	ListDeleted(ctx context.Context, params ListTrashParams) (*Page[*domain.Chapter], error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	ListDeletedBefore(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+	// ListIDsByMangaID lists the IDs of all of a manga's chapters, trashed or not, for purging.
+	ListIDsByMangaID(ctx context.Context, mangaID uuid.UUID) ([]uuid.UUID, error)
+	Purge(ctx context.Context, id uuid.UUID) error
 }
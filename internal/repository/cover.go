@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCoverNotFound = errors.New("cover not found")
+)
+
+type CoverRepository interface {
+	// Create stores a cover. It becomes primary if requested or if the manga has no cover yet.
+	Create(ctx context.Context, cover *domain.Cover) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Cover, error)
+	ListByMangaID(ctx context.Context, mangaID uuid.UUID) ([]*domain.Cover, error)
+	SetPrimary(ctx context.Context, mangaID, coverID uuid.UUID) error
+	// Delete removes a cover. If it was primary, the newest remaining cover takes its place.
+	Delete(ctx context.Context, mangaID, coverID uuid.UUID) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCreatorNotFound      = errors.New("creator not found")
+	ErrCreatorAlreadyExists = errors.New("creator with this name already exists")
+)
+
+// ListCreatorsParams defines the parameters for listing creators.
+type ListCreatorsParams struct {
+	Limit  int
+	Offset int
+	Query  string // Case-insensitive substring of the name
+}
+
+type CreatorRepository interface {
+	Create(ctx context.Context, creator *domain.Creator) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Creator, error)
+	List(ctx context.Context, params ListCreatorsParams) ([]*domain.Creator, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+)
+
+type JobRepository interface {
+	Create(ctx context.Context, job *domain.Job) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.JobStatus, result json.RawMessage, errMsg *string) error
+	// Start moves a pending job to running and reports whether it did. It returns false when the
+	// job has already been started, e.g. by a consumer of a redelivered message.
+	Start(ctx context.Context, id uuid.UUID) (bool, error)
+}
@@ -3,30 +3,85 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/0xpanadol/manga/internal/domain"
 	"github.com/google/uuid"
 )
 
 var (
-	ErrMangaNotFound = errors.New("manga not found")
+	ErrMangaNotFound      = errors.New("manga not found")
+	ErrExternalIDConflict = errors.New("another manga already has this external ID")
+	ErrRelationNotFound   = errors.New("relation not found")
 )
 
+// Tag match modes for ListMangaParams.
+const (
+	TagModeAll = "all" // The manga has every listed tag
+	TagModeAny = "any" // The manga has at least one listed tag
+)
+
+// SortByRelevance lists the best matches of a search first. It is the default when searching.
+const SortByRelevance = "relevance"
+
 // ListMangaParams defines the parameters for listing manga.
 type ListMangaParams struct {
-	Limit       int
-	Offset      int
-	SearchQuery string
-	Genres      []string
-	Status      string
-	SortBy      string // e.g., "title", "created_at"
-	SortOrder   string // "asc" or "desc"
+	Limit               int
+	Offset              int
+	Cursor              string      // NextCursor of the previous page; replaces Offset
+	IDs                 []uuid.UUID // Only these manga, if not nil
+	SearchQuery         string
+	SearchLanguage      string   // BCP 47 tag the search is in, domain.DefaultSearchLanguage if empty
+	Genres              []string // Tag names or slugs
+	GenreMode           string   // TagModeAll (default) or TagModeAny
+	ExcludedGenres      []string // Tag names or slugs
+	ExcludedGenreMode   string   // TagModeAny (default) excludes manga with any of them, TagModeAll only those with all of them
+	Status              string
+	ContentRatings      []domain.ContentRating // Only manga with one of these ratings (the ones the user may see), if not nil
+	ContentRatingFilter []domain.ContentRating // Narrows ContentRatings down to these, if not nil. Unlike ContentRatings, it doesn't apply to the content rating facet
+	CreatorID           uuid.UUID              // Only manga credited to this creator, if set
+	Demographics        []domain.Demographic   // Only manga with one of these demographics, if not nil
+	YearFrom            int                    // Inclusive bounds on the year, if set
+	YearTo              int
+	OriginalLanguage    string
+	MyAnimeListID       int // Look up manga by their ID on other sites, if set
+	AniListID           int
+	MangaUpdatesID      string
+	SortBy              string // e.g., "title", "year", "created_at" or SortByRelevance
+	SortOrder           string // "asc" or "desc"
+	Facets              bool   // Also count the matches by facet
+}
+
+// MangaPage is a page of manga, with the facets of all pages if ListMangaParams.Facets is set.
+type MangaPage struct {
+	Page[*domain.Manga]
+	Facets *domain.MangaFacets
 }
 
 type MangaRepository interface {
-	Create(ctx context.Context, manga *domain.Manga) error
+	// Create saves a new manga. Like Update and Delete, it records the revision made by revise,
+	// if not nil, along with the change.
+	Create(ctx context.Context, manga *domain.Manga, revise Revise[*domain.Manga]) error
 	FindByID(ctx context.Context, id uuid.UUID) (*domain.Manga, error)
-	List(ctx context.Context, params ListMangaParams) ([]*domain.Manga, error)
-	Update(ctx context.Context, manga *domain.Manga) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	// FindByExternalID finds a manga by the key it was imported under. Update leaves that key as it is.
+	FindByExternalID(ctx context.Context, externalID string) (*domain.Manga, error)
+	List(ctx context.Context, params ListMangaParams) (*MangaPage, error)
+	Autocomplete(ctx context.Context, search string, ratings []domain.ContentRating, limit int) ([]*domain.MangaSuggestion, error)
+	// Update saves the manga if it is still at manga.Version, or whatever its version if that is
+	// 0, and sets manga.Version to the new version. Otherwise it returns ErrVersionConflict. The
+	// revision made by revise, if not nil, is recorded along with the change.
+	Update(ctx context.Context, manga *domain.Manga, revise Revise[*domain.Manga]) error
+	// Delete moves the manga and its chapters to the trash if it is at the version, or whatever
+	// its version if that is 0. Trashed manga are left out by every other method but those below.
+	Delete(ctx context.Context, id uuid.UUID, version int, revise Revise[uuid.UUID]) error
+	ListDeleted(ctx context.Context, params ListTrashParams) (*Page[*domain.Manga], error)
+	// Restore takes the manga out of the trash, with the chapters trashed along with it.
+	Restore(ctx context.Context, id uuid.UUID) error
+	ListDeletedBefore(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+	// Purge deletes a trashed manga for good, with everything that belongs to it.
+	Purge(ctx context.Context, id uuid.UUID) error
+
+	// Relations are kept in both directions: setting "B is a sequel of A" also makes A a prequel of B.
+	SetRelation(ctx context.Context, mangaID, relatedID uuid.UUID, relationType domain.RelationType) error
+	DeleteRelation(ctx context.Context, mangaID, relatedID uuid.UUID) error
 }
@@ -6,6 +6,8 @@ package mocks
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/0xpanadol/manga/internal/domain"
 	"github.com/0xpanadol/manga/internal/repository"
@@ -40,17 +42,103 @@ func (_m *MockChapterRepository) EXPECT() *MockChapterRepository_Expecter {
 	return &MockChapterRepository_Expecter{mock: &_m.Mock}
 }
 
+// Approve provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) Approve(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, state domain.PublicationState, publishAt *time.Time) (*domain.ChapterSubmission, error) {
+	ret := _mock.Called(ctx, id, reviewerID, state, publishAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Approve")
+	}
+
+	var r0 *domain.ChapterSubmission
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, domain.PublicationState, *time.Time) (*domain.ChapterSubmission, error)); ok {
+		return returnFunc(ctx, id, reviewerID, state, publishAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, domain.PublicationState, *time.Time) *domain.ChapterSubmission); ok {
+		r0 = returnFunc(ctx, id, reviewerID, state, publishAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ChapterSubmission)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, domain.PublicationState, *time.Time) error); ok {
+		r1 = returnFunc(ctx, id, reviewerID, state, publishAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChapterRepository_Approve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Approve'
+type MockChapterRepository_Approve_Call struct {
+	*mock.Call
+}
+
+// Approve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - reviewerID uuid.UUID
+//   - state domain.PublicationState
+//   - publishAt *time.Time
+func (_e *MockChapterRepository_Expecter) Approve(ctx interface{}, id interface{}, reviewerID interface{}, state interface{}, publishAt interface{}) *MockChapterRepository_Approve_Call {
+	return &MockChapterRepository_Approve_Call{Call: _e.mock.On("Approve", ctx, id, reviewerID, state, publishAt)}
+}
+
+func (_c *MockChapterRepository_Approve_Call) Run(run func(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, state domain.PublicationState, publishAt *time.Time)) *MockChapterRepository_Approve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 domain.PublicationState
+		if args[3] != nil {
+			arg3 = args[3].(domain.PublicationState)
+		}
+		var arg4 *time.Time
+		if args[4] != nil {
+			arg4 = args[4].(*time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_Approve_Call) Return(chapterSubmission *domain.ChapterSubmission, err error) *MockChapterRepository_Approve_Call {
+	_c.Call.Return(chapterSubmission, err)
+	return _c
+}
+
+func (_c *MockChapterRepository_Approve_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, state domain.PublicationState, publishAt *time.Time) (*domain.ChapterSubmission, error)) *MockChapterRepository_Approve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockChapterRepository
-func (_mock *MockChapterRepository) Create(ctx context.Context, chapter *domain.Chapter) error {
-	ret := _mock.Called(ctx, chapter)
+func (_mock *MockChapterRepository) Create(ctx context.Context, chapter *domain.Chapter, revise repository.Revise[*domain.Chapter]) error {
+	ret := _mock.Called(ctx, chapter, revise)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Chapter) error); ok {
-		r0 = returnFunc(ctx, chapter)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Chapter, repository.Revise[*domain.Chapter]) error); ok {
+		r0 = returnFunc(ctx, chapter, revise)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -65,11 +153,12 @@ type MockChapterRepository_Create_Call struct {
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
 //   - chapter *domain.Chapter
-func (_e *MockChapterRepository_Expecter) Create(ctx interface{}, chapter interface{}) *MockChapterRepository_Create_Call {
-	return &MockChapterRepository_Create_Call{Call: _e.mock.On("Create", ctx, chapter)}
+//   - revise repository.Revise[*domain.Chapter]
+func (_e *MockChapterRepository_Expecter) Create(ctx interface{}, chapter interface{}, revise interface{}) *MockChapterRepository_Create_Call {
+	return &MockChapterRepository_Create_Call{Call: _e.mock.On("Create", ctx, chapter, revise)}
 }
 
-func (_c *MockChapterRepository_Create_Call) Run(run func(ctx context.Context, chapter *domain.Chapter)) *MockChapterRepository_Create_Call {
+func (_c *MockChapterRepository_Create_Call) Run(run func(ctx context.Context, chapter *domain.Chapter, revise repository.Revise[*domain.Chapter])) *MockChapterRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -79,9 +168,14 @@ func (_c *MockChapterRepository_Create_Call) Run(run func(ctx context.Context, c
 		if args[1] != nil {
 			arg1 = args[1].(*domain.Chapter)
 		}
+		var arg2 repository.Revise[*domain.Chapter]
+		if args[2] != nil {
+			arg2 = args[2].(repository.Revise[*domain.Chapter])
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -92,22 +186,22 @@ func (_c *MockChapterRepository_Create_Call) Return(err error) *MockChapterRepos
 	return _c
 }
 
-func (_c *MockChapterRepository_Create_Call) RunAndReturn(run func(ctx context.Context, chapter *domain.Chapter) error) *MockChapterRepository_Create_Call {
+func (_c *MockChapterRepository_Create_Call) RunAndReturn(run func(ctx context.Context, chapter *domain.Chapter, revise repository.Revise[*domain.Chapter]) error) *MockChapterRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Delete provides a mock function for the type MockChapterRepository
-func (_mock *MockChapterRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	ret := _mock.Called(ctx, id)
+func (_mock *MockChapterRepository) Delete(ctx context.Context, id uuid.UUID, version int, revise repository.Revise[uuid.UUID]) error {
+	ret := _mock.Called(ctx, id, version, revise)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, repository.Revise[uuid.UUID]) error); ok {
+		r0 = returnFunc(ctx, id, version, revise)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -122,11 +216,13 @@ type MockChapterRepository_Delete_Call struct {
 // Delete is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockChapterRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockChapterRepository_Delete_Call {
-	return &MockChapterRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+//   - version int
+//   - revise repository.Revise[uuid.UUID]
+func (_e *MockChapterRepository_Expecter) Delete(ctx interface{}, id interface{}, version interface{}, revise interface{}) *MockChapterRepository_Delete_Call {
+	return &MockChapterRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id, version, revise)}
 }
 
-func (_c *MockChapterRepository_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockChapterRepository_Delete_Call {
+func (_c *MockChapterRepository_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID, version int, revise repository.Revise[uuid.UUID])) *MockChapterRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -136,9 +232,19 @@ func (_c *MockChapterRepository_Delete_Call) Run(run func(ctx context.Context, i
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 repository.Revise[uuid.UUID]
+		if args[3] != nil {
+			arg3 = args[3].(repository.Revise[uuid.UUID])
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -149,7 +255,7 @@ func (_c *MockChapterRepository_Delete_Call) Return(err error) *MockChapterRepos
 	return _c
 }
 
-func (_c *MockChapterRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockChapterRepository_Delete_Call {
+func (_c *MockChapterRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, version int, revise repository.Revise[uuid.UUID]) error) *MockChapterRepository_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -217,167 +323,3267 @@ func (_c *MockChapterRepository_FindByID_Call) Return(chapter *domain.Chapter, e
 	return _c
 }
 
-func (_c *MockChapterRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Chapter, error)) *MockChapterRepository_FindByID_Call {
+func (_c *MockChapterRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Chapter, error)) *MockChapterRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByMangaAndNumber provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) FindByMangaAndNumber(ctx context.Context, mangaID uuid.UUID, chapterNumber string) (*domain.Chapter, error) {
+	ret := _mock.Called(ctx, mangaID, chapterNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByMangaAndNumber")
+	}
+
+	var r0 *domain.Chapter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*domain.Chapter, error)); ok {
+		return returnFunc(ctx, mangaID, chapterNumber)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *domain.Chapter); ok {
+		r0 = returnFunc(ctx, mangaID, chapterNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Chapter)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = returnFunc(ctx, mangaID, chapterNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChapterRepository_FindByMangaAndNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByMangaAndNumber'
+type MockChapterRepository_FindByMangaAndNumber_Call struct {
+	*mock.Call
+}
+
+// FindByMangaAndNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mangaID uuid.UUID
+//   - chapterNumber string
+func (_e *MockChapterRepository_Expecter) FindByMangaAndNumber(ctx interface{}, mangaID interface{}, chapterNumber interface{}) *MockChapterRepository_FindByMangaAndNumber_Call {
+	return &MockChapterRepository_FindByMangaAndNumber_Call{Call: _e.mock.On("FindByMangaAndNumber", ctx, mangaID, chapterNumber)}
+}
+
+func (_c *MockChapterRepository_FindByMangaAndNumber_Call) Run(run func(ctx context.Context, mangaID uuid.UUID, chapterNumber string)) *MockChapterRepository_FindByMangaAndNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_FindByMangaAndNumber_Call) Return(chapter *domain.Chapter, err error) *MockChapterRepository_FindByMangaAndNumber_Call {
+	_c.Call.Return(chapter, err)
+	return _c
+}
+
+func (_c *MockChapterRepository_FindByMangaAndNumber_Call) RunAndReturn(run func(ctx context.Context, mangaID uuid.UUID, chapterNumber string) (*domain.Chapter, error)) *MockChapterRepository_FindByMangaAndNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByMangaID provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) ListByMangaID(ctx context.Context, params repository.ListChaptersParams) (*repository.Page[*domain.Chapter], error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByMangaID")
+	}
+
+	var r0 *repository.Page[*domain.Chapter]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListChaptersParams) (*repository.Page[*domain.Chapter], error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListChaptersParams) *repository.Page[*domain.Chapter]); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Page[*domain.Chapter])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListChaptersParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChapterRepository_ListByMangaID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByMangaID'
+type MockChapterRepository_ListByMangaID_Call struct {
+	*mock.Call
+}
+
+// ListByMangaID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params repository.ListChaptersParams
+func (_e *MockChapterRepository_Expecter) ListByMangaID(ctx interface{}, params interface{}) *MockChapterRepository_ListByMangaID_Call {
+	return &MockChapterRepository_ListByMangaID_Call{Call: _e.mock.On("ListByMangaID", ctx, params)}
+}
+
+func (_c *MockChapterRepository_ListByMangaID_Call) Run(run func(ctx context.Context, params repository.ListChaptersParams)) *MockChapterRepository_ListByMangaID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repository.ListChaptersParams
+		if args[1] != nil {
+			arg1 = args[1].(repository.ListChaptersParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_ListByMangaID_Call) Return(page *repository.Page[*domain.Chapter], err error) *MockChapterRepository_ListByMangaID_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *MockChapterRepository_ListByMangaID_Call) RunAndReturn(run func(ctx context.Context, params repository.ListChaptersParams) (*repository.Page[*domain.Chapter], error)) *MockChapterRepository_ListByMangaID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByVolume provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) ListByVolume(ctx context.Context, mangaID uuid.UUID, volume string) ([]*domain.Chapter, error) {
+	ret := _mock.Called(ctx, mangaID, volume)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByVolume")
+	}
+
+	var r0 []*domain.Chapter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) ([]*domain.Chapter, error)); ok {
+		return returnFunc(ctx, mangaID, volume)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) []*domain.Chapter); ok {
+		r0 = returnFunc(ctx, mangaID, volume)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Chapter)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = returnFunc(ctx, mangaID, volume)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChapterRepository_ListByVolume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByVolume'
+type MockChapterRepository_ListByVolume_Call struct {
+	*mock.Call
+}
+
+// ListByVolume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mangaID uuid.UUID
+//   - volume string
+func (_e *MockChapterRepository_Expecter) ListByVolume(ctx interface{}, mangaID interface{}, volume interface{}) *MockChapterRepository_ListByVolume_Call {
+	return &MockChapterRepository_ListByVolume_Call{Call: _e.mock.On("ListByVolume", ctx, mangaID, volume)}
+}
+
+func (_c *MockChapterRepository_ListByVolume_Call) Run(run func(ctx context.Context, mangaID uuid.UUID, volume string)) *MockChapterRepository_ListByVolume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_ListByVolume_Call) Return(chapters []*domain.Chapter, err error) *MockChapterRepository_ListByVolume_Call {
+	_c.Call.Return(chapters, err)
+	return _c
+}
+
+func (_c *MockChapterRepository_ListByVolume_Call) RunAndReturn(run func(ctx context.Context, mangaID uuid.UUID, volume string) ([]*domain.Chapter, error)) *MockChapterRepository_ListByVolume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeleted provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) ListDeleted(ctx context.Context, params repository.ListTrashParams) (*repository.Page[*domain.Chapter], error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeleted")
+	}
+
+	var r0 *repository.Page[*domain.Chapter]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListTrashParams) (*repository.Page[*domain.Chapter], error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListTrashParams) *repository.Page[*domain.Chapter]); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Page[*domain.Chapter])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListTrashParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChapterRepository_ListDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeleted'
+type MockChapterRepository_ListDeleted_Call struct {
+	*mock.Call
+}
+
+// ListDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params repository.ListTrashParams
+func (_e *MockChapterRepository_Expecter) ListDeleted(ctx interface{}, params interface{}) *MockChapterRepository_ListDeleted_Call {
+	return &MockChapterRepository_ListDeleted_Call{Call: _e.mock.On("ListDeleted", ctx, params)}
+}
+
+func (_c *MockChapterRepository_ListDeleted_Call) Run(run func(ctx context.Context, params repository.ListTrashParams)) *MockChapterRepository_ListDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repository.ListTrashParams
+		if args[1] != nil {
+			arg1 = args[1].(repository.ListTrashParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_ListDeleted_Call) Return(page *repository.Page[*domain.Chapter], err error) *MockChapterRepository_ListDeleted_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *MockChapterRepository_ListDeleted_Call) RunAndReturn(run func(ctx context.Context, params repository.ListTrashParams) (*repository.Page[*domain.Chapter], error)) *MockChapterRepository_ListDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeletedBefore provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) ListDeletedBefore(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeletedBefore")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]uuid.UUID, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []uuid.UUID); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChapterRepository_ListDeletedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeletedBefore'
+type MockChapterRepository_ListDeletedBefore_Call struct {
+	*mock.Call
+}
+
+// ListDeletedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockChapterRepository_Expecter) ListDeletedBefore(ctx interface{}, before interface{}) *MockChapterRepository_ListDeletedBefore_Call {
+	return &MockChapterRepository_ListDeletedBefore_Call{Call: _e.mock.On("ListDeletedBefore", ctx, before)}
+}
+
+func (_c *MockChapterRepository_ListDeletedBefore_Call) Run(run func(ctx context.Context, before time.Time)) *MockChapterRepository_ListDeletedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_ListDeletedBefore_Call) Return(uUIDs []uuid.UUID, err error) *MockChapterRepository_ListDeletedBefore_Call {
+	_c.Call.Return(uUIDs, err)
+	return _c
+}
+
+func (_c *MockChapterRepository_ListDeletedBefore_Call) RunAndReturn(run func(ctx context.Context, before time.Time) ([]uuid.UUID, error)) *MockChapterRepository_ListDeletedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIDsByMangaID provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) ListIDsByMangaID(ctx context.Context, mangaID uuid.UUID) ([]uuid.UUID, error) {
+	ret := _mock.Called(ctx, mangaID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIDsByMangaID")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]uuid.UUID, error)); ok {
+		return returnFunc(ctx, mangaID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []uuid.UUID); ok {
+		r0 = returnFunc(ctx, mangaID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, mangaID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChapterRepository_ListIDsByMangaID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIDsByMangaID'
+type MockChapterRepository_ListIDsByMangaID_Call struct {
+	*mock.Call
+}
+
+// ListIDsByMangaID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mangaID uuid.UUID
+func (_e *MockChapterRepository_Expecter) ListIDsByMangaID(ctx interface{}, mangaID interface{}) *MockChapterRepository_ListIDsByMangaID_Call {
+	return &MockChapterRepository_ListIDsByMangaID_Call{Call: _e.mock.On("ListIDsByMangaID", ctx, mangaID)}
+}
+
+func (_c *MockChapterRepository_ListIDsByMangaID_Call) Run(run func(ctx context.Context, mangaID uuid.UUID)) *MockChapterRepository_ListIDsByMangaID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_ListIDsByMangaID_Call) Return(uUIDs []uuid.UUID, err error) *MockChapterRepository_ListIDsByMangaID_Call {
+	_c.Call.Return(uUIDs, err)
+	return _c
+}
+
+func (_c *MockChapterRepository_ListIDsByMangaID_Call) RunAndReturn(run func(ctx context.Context, mangaID uuid.UUID) ([]uuid.UUID, error)) *MockChapterRepository_ListIDsByMangaID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSubmissions provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) ListSubmissions(ctx context.Context, params repository.ListSubmissionsParams) (*repository.Page[*domain.ChapterSubmission], error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubmissions")
+	}
+
+	var r0 *repository.Page[*domain.ChapterSubmission]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListSubmissionsParams) (*repository.Page[*domain.ChapterSubmission], error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListSubmissionsParams) *repository.Page[*domain.ChapterSubmission]); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Page[*domain.ChapterSubmission])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListSubmissionsParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChapterRepository_ListSubmissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSubmissions'
+type MockChapterRepository_ListSubmissions_Call struct {
+	*mock.Call
+}
+
+// ListSubmissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params repository.ListSubmissionsParams
+func (_e *MockChapterRepository_Expecter) ListSubmissions(ctx interface{}, params interface{}) *MockChapterRepository_ListSubmissions_Call {
+	return &MockChapterRepository_ListSubmissions_Call{Call: _e.mock.On("ListSubmissions", ctx, params)}
+}
+
+func (_c *MockChapterRepository_ListSubmissions_Call) Run(run func(ctx context.Context, params repository.ListSubmissionsParams)) *MockChapterRepository_ListSubmissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repository.ListSubmissionsParams
+		if args[1] != nil {
+			arg1 = args[1].(repository.ListSubmissionsParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_ListSubmissions_Call) Return(page *repository.Page[*domain.ChapterSubmission], err error) *MockChapterRepository_ListSubmissions_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *MockChapterRepository_ListSubmissions_Call) RunAndReturn(run func(ctx context.Context, params repository.ListSubmissionsParams) (*repository.Page[*domain.ChapterSubmission], error)) *MockChapterRepository_ListSubmissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishDue provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) PublishDue(ctx context.Context, now time.Time, revise repository.Revise[*domain.Chapter]) ([]*domain.Chapter, error) {
+	ret := _mock.Called(ctx, now, revise)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishDue")
+	}
+
+	var r0 []*domain.Chapter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, repository.Revise[*domain.Chapter]) ([]*domain.Chapter, error)); ok {
+		return returnFunc(ctx, now, revise)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, repository.Revise[*domain.Chapter]) []*domain.Chapter); ok {
+		r0 = returnFunc(ctx, now, revise)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Chapter)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, repository.Revise[*domain.Chapter]) error); ok {
+		r1 = returnFunc(ctx, now, revise)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChapterRepository_PublishDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishDue'
+type MockChapterRepository_PublishDue_Call struct {
+	*mock.Call
+}
+
+// PublishDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - revise repository.Revise[*domain.Chapter]
+func (_e *MockChapterRepository_Expecter) PublishDue(ctx interface{}, now interface{}, revise interface{}) *MockChapterRepository_PublishDue_Call {
+	return &MockChapterRepository_PublishDue_Call{Call: _e.mock.On("PublishDue", ctx, now, revise)}
+}
+
+func (_c *MockChapterRepository_PublishDue_Call) Run(run func(ctx context.Context, now time.Time, revise repository.Revise[*domain.Chapter])) *MockChapterRepository_PublishDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 repository.Revise[*domain.Chapter]
+		if args[2] != nil {
+			arg2 = args[2].(repository.Revise[*domain.Chapter])
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_PublishDue_Call) Return(chapters []*domain.Chapter, err error) *MockChapterRepository_PublishDue_Call {
+	_c.Call.Return(chapters, err)
+	return _c
+}
+
+func (_c *MockChapterRepository_PublishDue_Call) RunAndReturn(run func(ctx context.Context, now time.Time, revise repository.Revise[*domain.Chapter]) ([]*domain.Chapter, error)) *MockChapterRepository_PublishDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChapterRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type MockChapterRepository_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockChapterRepository_Expecter) Purge(ctx interface{}, id interface{}) *MockChapterRepository_Purge_Call {
+	return &MockChapterRepository_Purge_Call{Call: _e.mock.On("Purge", ctx, id)}
+}
+
+func (_c *MockChapterRepository_Purge_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockChapterRepository_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_Purge_Call) Return(err error) *MockChapterRepository_Purge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChapterRepository_Purge_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockChapterRepository_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reject provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) Reject(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, reason string) (*domain.ChapterSubmission, error) {
+	ret := _mock.Called(ctx, id, reviewerID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reject")
+	}
+
+	var r0 *domain.ChapterSubmission
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) (*domain.ChapterSubmission, error)); ok {
+		return returnFunc(ctx, id, reviewerID, reason)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) *domain.ChapterSubmission); ok {
+		r0 = returnFunc(ctx, id, reviewerID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ChapterSubmission)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r1 = returnFunc(ctx, id, reviewerID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChapterRepository_Reject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reject'
+type MockChapterRepository_Reject_Call struct {
+	*mock.Call
+}
+
+// Reject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - reviewerID uuid.UUID
+//   - reason string
+func (_e *MockChapterRepository_Expecter) Reject(ctx interface{}, id interface{}, reviewerID interface{}, reason interface{}) *MockChapterRepository_Reject_Call {
+	return &MockChapterRepository_Reject_Call{Call: _e.mock.On("Reject", ctx, id, reviewerID, reason)}
+}
+
+func (_c *MockChapterRepository_Reject_Call) Run(run func(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, reason string)) *MockChapterRepository_Reject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_Reject_Call) Return(chapterSubmission *domain.ChapterSubmission, err error) *MockChapterRepository_Reject_Call {
+	_c.Call.Return(chapterSubmission, err)
+	return _c
+}
+
+func (_c *MockChapterRepository_Reject_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, reason string) (*domain.ChapterSubmission, error)) *MockChapterRepository_Reject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChapterRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockChapterRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockChapterRepository_Expecter) Restore(ctx interface{}, id interface{}) *MockChapterRepository_Restore_Call {
+	return &MockChapterRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *MockChapterRepository_Restore_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockChapterRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_Restore_Call) Return(err error) *MockChapterRepository_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChapterRepository_Restore_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockChapterRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) Update(ctx context.Context, chapter *domain.Chapter, revise repository.Revise[*domain.Chapter]) error {
+	ret := _mock.Called(ctx, chapter, revise)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Chapter, repository.Revise[*domain.Chapter]) error); ok {
+		r0 = returnFunc(ctx, chapter, revise)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChapterRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockChapterRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chapter *domain.Chapter
+//   - revise repository.Revise[*domain.Chapter]
+func (_e *MockChapterRepository_Expecter) Update(ctx interface{}, chapter interface{}, revise interface{}) *MockChapterRepository_Update_Call {
+	return &MockChapterRepository_Update_Call{Call: _e.mock.On("Update", ctx, chapter, revise)}
+}
+
+func (_c *MockChapterRepository_Update_Call) Run(run func(ctx context.Context, chapter *domain.Chapter, revise repository.Revise[*domain.Chapter])) *MockChapterRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Chapter
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Chapter)
+		}
+		var arg2 repository.Revise[*domain.Chapter]
+		if args[2] != nil {
+			arg2 = args[2].(repository.Revise[*domain.Chapter])
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_Update_Call) Return(err error) *MockChapterRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChapterRepository_Update_Call) RunAndReturn(run func(ctx context.Context, chapter *domain.Chapter, revise repository.Revise[*domain.Chapter]) error) *MockChapterRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePages provides a mock function for the type MockChapterRepository
+func (_mock *MockChapterRepository) UpdatePages(ctx context.Context, id uuid.UUID, pages []string) error {
+	ret := _mock.Called(ctx, id, pages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePages")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string) error); ok {
+		r0 = returnFunc(ctx, id, pages)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChapterRepository_UpdatePages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePages'
+type MockChapterRepository_UpdatePages_Call struct {
+	*mock.Call
+}
+
+// UpdatePages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - pages []string
+func (_e *MockChapterRepository_Expecter) UpdatePages(ctx interface{}, id interface{}, pages interface{}) *MockChapterRepository_UpdatePages_Call {
+	return &MockChapterRepository_UpdatePages_Call{Call: _e.mock.On("UpdatePages", ctx, id, pages)}
+}
+
+func (_c *MockChapterRepository_UpdatePages_Call) Run(run func(ctx context.Context, id uuid.UUID, pages []string)) *MockChapterRepository_UpdatePages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChapterRepository_UpdatePages_Call) Return(err error) *MockChapterRepository_UpdatePages_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChapterRepository_UpdatePages_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, pages []string) error) *MockChapterRepository_UpdatePages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCoverRepository creates a new instance of MockCoverRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCoverRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCoverRepository {
+	mock := &MockCoverRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCoverRepository is an autogenerated mock type for the CoverRepository type
+type MockCoverRepository struct {
+	mock.Mock
+}
+
+type MockCoverRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCoverRepository) EXPECT() *MockCoverRepository_Expecter {
+	return &MockCoverRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockCoverRepository
+func (_mock *MockCoverRepository) Create(ctx context.Context, cover *domain.Cover) error {
+	ret := _mock.Called(ctx, cover)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Cover) error); ok {
+		r0 = returnFunc(ctx, cover)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCoverRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockCoverRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cover *domain.Cover
+func (_e *MockCoverRepository_Expecter) Create(ctx interface{}, cover interface{}) *MockCoverRepository_Create_Call {
+	return &MockCoverRepository_Create_Call{Call: _e.mock.On("Create", ctx, cover)}
+}
+
+func (_c *MockCoverRepository_Create_Call) Run(run func(ctx context.Context, cover *domain.Cover)) *MockCoverRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Cover
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Cover)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverRepository_Create_Call) Return(err error) *MockCoverRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCoverRepository_Create_Call) RunAndReturn(run func(ctx context.Context, cover *domain.Cover) error) *MockCoverRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockCoverRepository
+func (_mock *MockCoverRepository) Delete(ctx context.Context, mangaID uuid.UUID, coverID uuid.UUID) error {
+	ret := _mock.Called(ctx, mangaID, coverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, mangaID, coverID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCoverRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockCoverRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mangaID uuid.UUID
+//   - coverID uuid.UUID
+func (_e *MockCoverRepository_Expecter) Delete(ctx interface{}, mangaID interface{}, coverID interface{}) *MockCoverRepository_Delete_Call {
+	return &MockCoverRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, mangaID, coverID)}
+}
+
+func (_c *MockCoverRepository_Delete_Call) Run(run func(ctx context.Context, mangaID uuid.UUID, coverID uuid.UUID)) *MockCoverRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverRepository_Delete_Call) Return(err error) *MockCoverRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCoverRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, mangaID uuid.UUID, coverID uuid.UUID) error) *MockCoverRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockCoverRepository
+func (_mock *MockCoverRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Cover, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Cover
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Cover, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Cover); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Cover)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCoverRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockCoverRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockCoverRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockCoverRepository_FindByID_Call {
+	return &MockCoverRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockCoverRepository_FindByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockCoverRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverRepository_FindByID_Call) Return(cover *domain.Cover, err error) *MockCoverRepository_FindByID_Call {
+	_c.Call.Return(cover, err)
+	return _c
+}
+
+func (_c *MockCoverRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Cover, error)) *MockCoverRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByMangaID provides a mock function for the type MockCoverRepository
+func (_mock *MockCoverRepository) ListByMangaID(ctx context.Context, mangaID uuid.UUID) ([]*domain.Cover, error) {
+	ret := _mock.Called(ctx, mangaID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByMangaID")
+	}
+
+	var r0 []*domain.Cover
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*domain.Cover, error)); ok {
+		return returnFunc(ctx, mangaID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*domain.Cover); ok {
+		r0 = returnFunc(ctx, mangaID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Cover)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, mangaID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCoverRepository_ListByMangaID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByMangaID'
+type MockCoverRepository_ListByMangaID_Call struct {
+	*mock.Call
+}
+
+// ListByMangaID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mangaID uuid.UUID
+func (_e *MockCoverRepository_Expecter) ListByMangaID(ctx interface{}, mangaID interface{}) *MockCoverRepository_ListByMangaID_Call {
+	return &MockCoverRepository_ListByMangaID_Call{Call: _e.mock.On("ListByMangaID", ctx, mangaID)}
+}
+
+func (_c *MockCoverRepository_ListByMangaID_Call) Run(run func(ctx context.Context, mangaID uuid.UUID)) *MockCoverRepository_ListByMangaID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverRepository_ListByMangaID_Call) Return(covers []*domain.Cover, err error) *MockCoverRepository_ListByMangaID_Call {
+	_c.Call.Return(covers, err)
+	return _c
+}
+
+func (_c *MockCoverRepository_ListByMangaID_Call) RunAndReturn(run func(ctx context.Context, mangaID uuid.UUID) ([]*domain.Cover, error)) *MockCoverRepository_ListByMangaID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPrimary provides a mock function for the type MockCoverRepository
+func (_mock *MockCoverRepository) SetPrimary(ctx context.Context, mangaID uuid.UUID, coverID uuid.UUID) error {
+	ret := _mock.Called(ctx, mangaID, coverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPrimary")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, mangaID, coverID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCoverRepository_SetPrimary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPrimary'
+type MockCoverRepository_SetPrimary_Call struct {
+	*mock.Call
+}
+
+// SetPrimary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mangaID uuid.UUID
+//   - coverID uuid.UUID
+func (_e *MockCoverRepository_Expecter) SetPrimary(ctx interface{}, mangaID interface{}, coverID interface{}) *MockCoverRepository_SetPrimary_Call {
+	return &MockCoverRepository_SetPrimary_Call{Call: _e.mock.On("SetPrimary", ctx, mangaID, coverID)}
+}
+
+func (_c *MockCoverRepository_SetPrimary_Call) Run(run func(ctx context.Context, mangaID uuid.UUID, coverID uuid.UUID)) *MockCoverRepository_SetPrimary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCoverRepository_SetPrimary_Call) Return(err error) *MockCoverRepository_SetPrimary_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCoverRepository_SetPrimary_Call) RunAndReturn(run func(ctx context.Context, mangaID uuid.UUID, coverID uuid.UUID) error) *MockCoverRepository_SetPrimary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCreatorRepository creates a new instance of MockCreatorRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCreatorRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCreatorRepository {
+	mock := &MockCreatorRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCreatorRepository is an autogenerated mock type for the CreatorRepository type
+type MockCreatorRepository struct {
+	mock.Mock
+}
+
+type MockCreatorRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCreatorRepository) EXPECT() *MockCreatorRepository_Expecter {
+	return &MockCreatorRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockCreatorRepository
+func (_mock *MockCreatorRepository) Create(ctx context.Context, creator *domain.Creator) error {
+	ret := _mock.Called(ctx, creator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Creator) error); ok {
+		r0 = returnFunc(ctx, creator)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCreatorRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockCreatorRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - creator *domain.Creator
+func (_e *MockCreatorRepository_Expecter) Create(ctx interface{}, creator interface{}) *MockCreatorRepository_Create_Call {
+	return &MockCreatorRepository_Create_Call{Call: _e.mock.On("Create", ctx, creator)}
+}
+
+func (_c *MockCreatorRepository_Create_Call) Run(run func(ctx context.Context, creator *domain.Creator)) *MockCreatorRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Creator
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Creator)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCreatorRepository_Create_Call) Return(err error) *MockCreatorRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCreatorRepository_Create_Call) RunAndReturn(run func(ctx context.Context, creator *domain.Creator) error) *MockCreatorRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockCreatorRepository
+func (_mock *MockCreatorRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Creator, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Creator
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Creator, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Creator); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Creator)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCreatorRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockCreatorRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockCreatorRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockCreatorRepository_FindByID_Call {
+	return &MockCreatorRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockCreatorRepository_FindByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockCreatorRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCreatorRepository_FindByID_Call) Return(creator *domain.Creator, err error) *MockCreatorRepository_FindByID_Call {
+	_c.Call.Return(creator, err)
+	return _c
+}
+
+func (_c *MockCreatorRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Creator, error)) *MockCreatorRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockCreatorRepository
+func (_mock *MockCreatorRepository) List(ctx context.Context, params repository.ListCreatorsParams) ([]*domain.Creator, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.Creator
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListCreatorsParams) ([]*domain.Creator, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListCreatorsParams) []*domain.Creator); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Creator)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListCreatorsParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCreatorRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockCreatorRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params repository.ListCreatorsParams
+func (_e *MockCreatorRepository_Expecter) List(ctx interface{}, params interface{}) *MockCreatorRepository_List_Call {
+	return &MockCreatorRepository_List_Call{Call: _e.mock.On("List", ctx, params)}
+}
+
+func (_c *MockCreatorRepository_List_Call) Run(run func(ctx context.Context, params repository.ListCreatorsParams)) *MockCreatorRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repository.ListCreatorsParams
+		if args[1] != nil {
+			arg1 = args[1].(repository.ListCreatorsParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCreatorRepository_List_Call) Return(creators []*domain.Creator, err error) *MockCreatorRepository_List_Call {
+	_c.Call.Return(creators, err)
+	return _c
+}
+
+func (_c *MockCreatorRepository_List_Call) RunAndReturn(run func(ctx context.Context, params repository.ListCreatorsParams) ([]*domain.Creator, error)) *MockCreatorRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockJobRepository creates a new instance of MockJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJobRepository {
+	mock := &MockJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockJobRepository is an autogenerated mock type for the JobRepository type
+type MockJobRepository struct {
+	mock.Mock
+}
+
+type MockJobRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJobRepository) EXPECT() *MockJobRepository_Expecter {
+	return &MockJobRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) Create(ctx context.Context, job *domain.Job) error {
+	ret := _mock.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Job) error); ok {
+		r0 = returnFunc(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockJobRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *domain.Job
+func (_e *MockJobRepository_Expecter) Create(ctx interface{}, job interface{}) *MockJobRepository_Create_Call {
+	return &MockJobRepository_Create_Call{Call: _e.mock.On("Create", ctx, job)}
+}
+
+func (_c *MockJobRepository_Create_Call) Run(run func(ctx context.Context, job *domain.Job)) *MockJobRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Job
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Job)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_Create_Call) Return(err error) *MockJobRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobRepository_Create_Call) RunAndReturn(run func(ctx context.Context, job *domain.Job) error) *MockJobRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Job, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Job); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockJobRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockJobRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockJobRepository_FindByID_Call {
+	return &MockJobRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockJobRepository_FindByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockJobRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_FindByID_Call) Return(job *domain.Job, err error) *MockJobRepository_FindByID_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *MockJobRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Job, error)) *MockJobRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) Start(ctx context.Context, id uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(bool)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockJobRepository_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockJobRepository_Expecter) Start(ctx interface{}, id interface{}) *MockJobRepository_Start_Call {
+	return &MockJobRepository_Start_Call{Call: _e.mock.On("Start", ctx, id)}
+}
+
+func (_c *MockJobRepository_Start_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockJobRepository_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_Start_Call) Return(b bool, err error) *MockJobRepository_Start_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockJobRepository_Start_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (bool, error)) *MockJobRepository_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.JobStatus, result json.RawMessage, errMsg *string) error {
+	ret := _mock.Called(ctx, id, status, result, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.JobStatus, json.RawMessage, *string) error); ok {
+		r0 = returnFunc(ctx, id, status, result, errMsg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobRepository_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type MockJobRepository_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - status domain.JobStatus
+//   - result json.RawMessage
+//   - errMsg *string
+func (_e *MockJobRepository_Expecter) UpdateStatus(ctx interface{}, id interface{}, status interface{}, result interface{}, errMsg interface{}) *MockJobRepository_UpdateStatus_Call {
+	return &MockJobRepository_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, id, status, result, errMsg)}
+}
+
+func (_c *MockJobRepository_UpdateStatus_Call) Run(run func(ctx context.Context, id uuid.UUID, status domain.JobStatus, result json.RawMessage, errMsg *string)) *MockJobRepository_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 domain.JobStatus
+		if args[2] != nil {
+			arg2 = args[2].(domain.JobStatus)
+		}
+		var arg3 json.RawMessage
+		if args[3] != nil {
+			arg3 = args[3].(json.RawMessage)
+		}
+		var arg4 *string
+		if args[4] != nil {
+			arg4 = args[4].(*string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_UpdateStatus_Call) Return(err error) *MockJobRepository_UpdateStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobRepository_UpdateStatus_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, status domain.JobStatus, result json.RawMessage, errMsg *string) error) *MockJobRepository_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMangaRepository creates a new instance of MockMangaRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMangaRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMangaRepository {
+	mock := &MockMangaRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMangaRepository is an autogenerated mock type for the MangaRepository type
+type MockMangaRepository struct {
+	mock.Mock
+}
+
+type MockMangaRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMangaRepository) EXPECT() *MockMangaRepository_Expecter {
+	return &MockMangaRepository_Expecter{mock: &_m.Mock}
+}
+
+// Autocomplete provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) Autocomplete(ctx context.Context, search string, ratings []domain.ContentRating, limit int) ([]*domain.MangaSuggestion, error) {
+	ret := _mock.Called(ctx, search, ratings, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Autocomplete")
+	}
+
+	var r0 []*domain.MangaSuggestion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []domain.ContentRating, int) ([]*domain.MangaSuggestion, error)); ok {
+		return returnFunc(ctx, search, ratings, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []domain.ContentRating, int) []*domain.MangaSuggestion); ok {
+		r0 = returnFunc(ctx, search, ratings, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.MangaSuggestion)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []domain.ContentRating, int) error); ok {
+		r1 = returnFunc(ctx, search, ratings, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMangaRepository_Autocomplete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Autocomplete'
+type MockMangaRepository_Autocomplete_Call struct {
+	*mock.Call
+}
+
+// Autocomplete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - search string
+//   - ratings []domain.ContentRating
+//   - limit int
+func (_e *MockMangaRepository_Expecter) Autocomplete(ctx interface{}, search interface{}, ratings interface{}, limit interface{}) *MockMangaRepository_Autocomplete_Call {
+	return &MockMangaRepository_Autocomplete_Call{Call: _e.mock.On("Autocomplete", ctx, search, ratings, limit)}
+}
+
+func (_c *MockMangaRepository_Autocomplete_Call) Run(run func(ctx context.Context, search string, ratings []domain.ContentRating, limit int)) *MockMangaRepository_Autocomplete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []domain.ContentRating
+		if args[2] != nil {
+			arg2 = args[2].([]domain.ContentRating)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_Autocomplete_Call) Return(mangaSuggestions []*domain.MangaSuggestion, err error) *MockMangaRepository_Autocomplete_Call {
+	_c.Call.Return(mangaSuggestions, err)
+	return _c
+}
+
+func (_c *MockMangaRepository_Autocomplete_Call) RunAndReturn(run func(ctx context.Context, search string, ratings []domain.ContentRating, limit int) ([]*domain.MangaSuggestion, error)) *MockMangaRepository_Autocomplete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) Create(ctx context.Context, manga *domain.Manga, revise repository.Revise[*domain.Manga]) error {
+	ret := _mock.Called(ctx, manga, revise)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Manga, repository.Revise[*domain.Manga]) error); ok {
+		r0 = returnFunc(ctx, manga, revise)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMangaRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockMangaRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - manga *domain.Manga
+//   - revise repository.Revise[*domain.Manga]
+func (_e *MockMangaRepository_Expecter) Create(ctx interface{}, manga interface{}, revise interface{}) *MockMangaRepository_Create_Call {
+	return &MockMangaRepository_Create_Call{Call: _e.mock.On("Create", ctx, manga, revise)}
+}
+
+func (_c *MockMangaRepository_Create_Call) Run(run func(ctx context.Context, manga *domain.Manga, revise repository.Revise[*domain.Manga])) *MockMangaRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Manga
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Manga)
+		}
+		var arg2 repository.Revise[*domain.Manga]
+		if args[2] != nil {
+			arg2 = args[2].(repository.Revise[*domain.Manga])
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_Create_Call) Return(err error) *MockMangaRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMangaRepository_Create_Call) RunAndReturn(run func(ctx context.Context, manga *domain.Manga, revise repository.Revise[*domain.Manga]) error) *MockMangaRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) Delete(ctx context.Context, id uuid.UUID, version int, revise repository.Revise[uuid.UUID]) error {
+	ret := _mock.Called(ctx, id, version, revise)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, repository.Revise[uuid.UUID]) error); ok {
+		r0 = returnFunc(ctx, id, version, revise)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMangaRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockMangaRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - version int
+//   - revise repository.Revise[uuid.UUID]
+func (_e *MockMangaRepository_Expecter) Delete(ctx interface{}, id interface{}, version interface{}, revise interface{}) *MockMangaRepository_Delete_Call {
+	return &MockMangaRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id, version, revise)}
+}
+
+func (_c *MockMangaRepository_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID, version int, revise repository.Revise[uuid.UUID])) *MockMangaRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 repository.Revise[uuid.UUID]
+		if args[3] != nil {
+			arg3 = args[3].(repository.Revise[uuid.UUID])
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_Delete_Call) Return(err error) *MockMangaRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMangaRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, version int, revise repository.Revise[uuid.UUID]) error) *MockMangaRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRelation provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) DeleteRelation(ctx context.Context, mangaID uuid.UUID, relatedID uuid.UUID) error {
+	ret := _mock.Called(ctx, mangaID, relatedID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRelation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, mangaID, relatedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMangaRepository_DeleteRelation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRelation'
+type MockMangaRepository_DeleteRelation_Call struct {
+	*mock.Call
+}
+
+// DeleteRelation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mangaID uuid.UUID
+//   - relatedID uuid.UUID
+func (_e *MockMangaRepository_Expecter) DeleteRelation(ctx interface{}, mangaID interface{}, relatedID interface{}) *MockMangaRepository_DeleteRelation_Call {
+	return &MockMangaRepository_DeleteRelation_Call{Call: _e.mock.On("DeleteRelation", ctx, mangaID, relatedID)}
+}
+
+func (_c *MockMangaRepository_DeleteRelation_Call) Run(run func(ctx context.Context, mangaID uuid.UUID, relatedID uuid.UUID)) *MockMangaRepository_DeleteRelation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_DeleteRelation_Call) Return(err error) *MockMangaRepository_DeleteRelation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMangaRepository_DeleteRelation_Call) RunAndReturn(run func(ctx context.Context, mangaID uuid.UUID, relatedID uuid.UUID) error) *MockMangaRepository_DeleteRelation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByExternalID provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) FindByExternalID(ctx context.Context, externalID string) (*domain.Manga, error) {
+	ret := _mock.Called(ctx, externalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByExternalID")
+	}
+
+	var r0 *domain.Manga
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Manga, error)); ok {
+		return returnFunc(ctx, externalID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Manga); ok {
+		r0 = returnFunc(ctx, externalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Manga)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMangaRepository_FindByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByExternalID'
+type MockMangaRepository_FindByExternalID_Call struct {
+	*mock.Call
+}
+
+// FindByExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+func (_e *MockMangaRepository_Expecter) FindByExternalID(ctx interface{}, externalID interface{}) *MockMangaRepository_FindByExternalID_Call {
+	return &MockMangaRepository_FindByExternalID_Call{Call: _e.mock.On("FindByExternalID", ctx, externalID)}
+}
+
+func (_c *MockMangaRepository_FindByExternalID_Call) Run(run func(ctx context.Context, externalID string)) *MockMangaRepository_FindByExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_FindByExternalID_Call) Return(manga *domain.Manga, err error) *MockMangaRepository_FindByExternalID_Call {
+	_c.Call.Return(manga, err)
+	return _c
+}
+
+func (_c *MockMangaRepository_FindByExternalID_Call) RunAndReturn(run func(ctx context.Context, externalID string) (*domain.Manga, error)) *MockMangaRepository_FindByExternalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Manga, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Manga
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Manga, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Manga); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Manga)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMangaRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockMangaRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockMangaRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockMangaRepository_FindByID_Call {
+	return &MockMangaRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockMangaRepository_FindByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockMangaRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_FindByID_Call) Return(manga *domain.Manga, err error) *MockMangaRepository_FindByID_Call {
+	_c.Call.Return(manga, err)
+	return _c
+}
+
+func (_c *MockMangaRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Manga, error)) *MockMangaRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) List(ctx context.Context, params repository.ListMangaParams) (*repository.MangaPage, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *repository.MangaPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListMangaParams) (*repository.MangaPage, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListMangaParams) *repository.MangaPage); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.MangaPage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListMangaParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMangaRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockMangaRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params repository.ListMangaParams
+func (_e *MockMangaRepository_Expecter) List(ctx interface{}, params interface{}) *MockMangaRepository_List_Call {
+	return &MockMangaRepository_List_Call{Call: _e.mock.On("List", ctx, params)}
+}
+
+func (_c *MockMangaRepository_List_Call) Run(run func(ctx context.Context, params repository.ListMangaParams)) *MockMangaRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repository.ListMangaParams
+		if args[1] != nil {
+			arg1 = args[1].(repository.ListMangaParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_List_Call) Return(mangaPage *repository.MangaPage, err error) *MockMangaRepository_List_Call {
+	_c.Call.Return(mangaPage, err)
+	return _c
+}
+
+func (_c *MockMangaRepository_List_Call) RunAndReturn(run func(ctx context.Context, params repository.ListMangaParams) (*repository.MangaPage, error)) *MockMangaRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeleted provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) ListDeleted(ctx context.Context, params repository.ListTrashParams) (*repository.Page[*domain.Manga], error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeleted")
+	}
+
+	var r0 *repository.Page[*domain.Manga]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListTrashParams) (*repository.Page[*domain.Manga], error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListTrashParams) *repository.Page[*domain.Manga]); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Page[*domain.Manga])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListTrashParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMangaRepository_ListDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeleted'
+type MockMangaRepository_ListDeleted_Call struct {
+	*mock.Call
+}
+
+// ListDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params repository.ListTrashParams
+func (_e *MockMangaRepository_Expecter) ListDeleted(ctx interface{}, params interface{}) *MockMangaRepository_ListDeleted_Call {
+	return &MockMangaRepository_ListDeleted_Call{Call: _e.mock.On("ListDeleted", ctx, params)}
+}
+
+func (_c *MockMangaRepository_ListDeleted_Call) Run(run func(ctx context.Context, params repository.ListTrashParams)) *MockMangaRepository_ListDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repository.ListTrashParams
+		if args[1] != nil {
+			arg1 = args[1].(repository.ListTrashParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_ListDeleted_Call) Return(page *repository.Page[*domain.Manga], err error) *MockMangaRepository_ListDeleted_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *MockMangaRepository_ListDeleted_Call) RunAndReturn(run func(ctx context.Context, params repository.ListTrashParams) (*repository.Page[*domain.Manga], error)) *MockMangaRepository_ListDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeletedBefore provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) ListDeletedBefore(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeletedBefore")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]uuid.UUID, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []uuid.UUID); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMangaRepository_ListDeletedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeletedBefore'
+type MockMangaRepository_ListDeletedBefore_Call struct {
+	*mock.Call
+}
+
+// ListDeletedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockMangaRepository_Expecter) ListDeletedBefore(ctx interface{}, before interface{}) *MockMangaRepository_ListDeletedBefore_Call {
+	return &MockMangaRepository_ListDeletedBefore_Call{Call: _e.mock.On("ListDeletedBefore", ctx, before)}
+}
+
+func (_c *MockMangaRepository_ListDeletedBefore_Call) Run(run func(ctx context.Context, before time.Time)) *MockMangaRepository_ListDeletedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_ListDeletedBefore_Call) Return(uUIDs []uuid.UUID, err error) *MockMangaRepository_ListDeletedBefore_Call {
+	_c.Call.Return(uUIDs, err)
+	return _c
+}
+
+func (_c *MockMangaRepository_ListDeletedBefore_Call) RunAndReturn(run func(ctx context.Context, before time.Time) ([]uuid.UUID, error)) *MockMangaRepository_ListDeletedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMangaRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type MockMangaRepository_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockMangaRepository_Expecter) Purge(ctx interface{}, id interface{}) *MockMangaRepository_Purge_Call {
+	return &MockMangaRepository_Purge_Call{Call: _e.mock.On("Purge", ctx, id)}
+}
+
+func (_c *MockMangaRepository_Purge_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockMangaRepository_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_Purge_Call) Return(err error) *MockMangaRepository_Purge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMangaRepository_Purge_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockMangaRepository_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMangaRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockMangaRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockMangaRepository_Expecter) Restore(ctx interface{}, id interface{}) *MockMangaRepository_Restore_Call {
+	return &MockMangaRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *MockMangaRepository_Restore_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockMangaRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_Restore_Call) Return(err error) *MockMangaRepository_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMangaRepository_Restore_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockMangaRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRelation provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) SetRelation(ctx context.Context, mangaID uuid.UUID, relatedID uuid.UUID, relationType domain.RelationType) error {
+	ret := _mock.Called(ctx, mangaID, relatedID, relationType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRelation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, domain.RelationType) error); ok {
+		r0 = returnFunc(ctx, mangaID, relatedID, relationType)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMangaRepository_SetRelation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRelation'
+type MockMangaRepository_SetRelation_Call struct {
+	*mock.Call
+}
+
+// SetRelation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mangaID uuid.UUID
+//   - relatedID uuid.UUID
+//   - relationType domain.RelationType
+func (_e *MockMangaRepository_Expecter) SetRelation(ctx interface{}, mangaID interface{}, relatedID interface{}, relationType interface{}) *MockMangaRepository_SetRelation_Call {
+	return &MockMangaRepository_SetRelation_Call{Call: _e.mock.On("SetRelation", ctx, mangaID, relatedID, relationType)}
+}
+
+func (_c *MockMangaRepository_SetRelation_Call) Run(run func(ctx context.Context, mangaID uuid.UUID, relatedID uuid.UUID, relationType domain.RelationType)) *MockMangaRepository_SetRelation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 domain.RelationType
+		if args[3] != nil {
+			arg3 = args[3].(domain.RelationType)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_SetRelation_Call) Return(err error) *MockMangaRepository_SetRelation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMangaRepository_SetRelation_Call) RunAndReturn(run func(ctx context.Context, mangaID uuid.UUID, relatedID uuid.UUID, relationType domain.RelationType) error) *MockMangaRepository_SetRelation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockMangaRepository
+func (_mock *MockMangaRepository) Update(ctx context.Context, manga *domain.Manga, revise repository.Revise[*domain.Manga]) error {
+	ret := _mock.Called(ctx, manga, revise)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Manga, repository.Revise[*domain.Manga]) error); ok {
+		r0 = returnFunc(ctx, manga, revise)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMangaRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockMangaRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - manga *domain.Manga
+//   - revise repository.Revise[*domain.Manga]
+func (_e *MockMangaRepository_Expecter) Update(ctx interface{}, manga interface{}, revise interface{}) *MockMangaRepository_Update_Call {
+	return &MockMangaRepository_Update_Call{Call: _e.mock.On("Update", ctx, manga, revise)}
+}
+
+func (_c *MockMangaRepository_Update_Call) Run(run func(ctx context.Context, manga *domain.Manga, revise repository.Revise[*domain.Manga])) *MockMangaRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Manga
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Manga)
+		}
+		var arg2 repository.Revise[*domain.Manga]
+		if args[2] != nil {
+			arg2 = args[2].(repository.Revise[*domain.Manga])
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMangaRepository_Update_Call) Return(err error) *MockMangaRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMangaRepository_Update_Call) RunAndReturn(run func(ctx context.Context, manga *domain.Manga, revise repository.Revise[*domain.Manga]) error) *MockMangaRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRevisionRepository creates a new instance of MockRevisionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRevisionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRevisionRepository {
+	mock := &MockRevisionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRevisionRepository is an autogenerated mock type for the RevisionRepository type
+type MockRevisionRepository struct {
+	mock.Mock
+}
+
+type MockRevisionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRevisionRepository) EXPECT() *MockRevisionRepository_Expecter {
+	return &MockRevisionRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockRevisionRepository
+func (_mock *MockRevisionRepository) Create(ctx context.Context, revision *domain.Revision) error {
+	ret := _mock.Called(ctx, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Revision) error); ok {
+		r0 = returnFunc(ctx, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRevisionRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockRevisionRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - revision *domain.Revision
+func (_e *MockRevisionRepository_Expecter) Create(ctx interface{}, revision interface{}) *MockRevisionRepository_Create_Call {
+	return &MockRevisionRepository_Create_Call{Call: _e.mock.On("Create", ctx, revision)}
+}
+
+func (_c *MockRevisionRepository_Create_Call) Run(run func(ctx context.Context, revision *domain.Revision)) *MockRevisionRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Revision
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Revision)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRevisionRepository_Create_Call) Return(err error) *MockRevisionRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRevisionRepository_Create_Call) RunAndReturn(run func(ctx context.Context, revision *domain.Revision) error) *MockRevisionRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockRevisionRepository
+func (_mock *MockRevisionRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Revision, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *domain.Revision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Revision, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Revision); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Revision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRevisionRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockRevisionRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockRevisionRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockRevisionRepository_FindByID_Call {
+	return &MockRevisionRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockRevisionRepository_FindByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockRevisionRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRevisionRepository_FindByID_Call) Return(revision *domain.Revision, err error) *MockRevisionRepository_FindByID_Call {
+	_c.Call.Return(revision, err)
+	return _c
+}
+
+func (_c *MockRevisionRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Revision, error)) *MockRevisionRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByMangaID provides a mock function for the type MockRevisionRepository
+func (_mock *MockRevisionRepository) ListByMangaID(ctx context.Context, params repository.ListRevisionsParams) (*repository.Page[*domain.Revision], error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByMangaID")
+	}
+
+	var r0 *repository.Page[*domain.Revision]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListRevisionsParams) (*repository.Page[*domain.Revision], error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListRevisionsParams) *repository.Page[*domain.Revision]); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Page[*domain.Revision])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListRevisionsParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRevisionRepository_ListByMangaID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByMangaID'
+type MockRevisionRepository_ListByMangaID_Call struct {
+	*mock.Call
+}
+
+// ListByMangaID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params repository.ListRevisionsParams
+func (_e *MockRevisionRepository_Expecter) ListByMangaID(ctx interface{}, params interface{}) *MockRevisionRepository_ListByMangaID_Call {
+	return &MockRevisionRepository_ListByMangaID_Call{Call: _e.mock.On("ListByMangaID", ctx, params)}
+}
+
+func (_c *MockRevisionRepository_ListByMangaID_Call) Run(run func(ctx context.Context, params repository.ListRevisionsParams)) *MockRevisionRepository_ListByMangaID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repository.ListRevisionsParams
+		if args[1] != nil {
+			arg1 = args[1].(repository.ListRevisionsParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRevisionRepository_ListByMangaID_Call) Return(page *repository.Page[*domain.Revision], err error) *MockRevisionRepository_ListByMangaID_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *MockRevisionRepository_ListByMangaID_Call) RunAndReturn(run func(ctx context.Context, params repository.ListRevisionsParams) (*repository.Page[*domain.Revision], error)) *MockRevisionRepository_ListByMangaID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSocialRepository creates a new instance of MockSocialRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSocialRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSocialRepository {
+	mock := &MockSocialRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSocialRepository is an autogenerated mock type for the SocialRepository type
+type MockSocialRepository struct {
+	mock.Mock
+}
+
+type MockSocialRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSocialRepository) EXPECT() *MockSocialRepository_Expecter {
+	return &MockSocialRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateComment provides a mock function for the type MockSocialRepository
+func (_mock *MockSocialRepository) CreateComment(ctx context.Context, comment *domain.Comment) error {
+	ret := _mock.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateComment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Comment) error); ok {
+		r0 = returnFunc(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSocialRepository_CreateComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateComment'
+type MockSocialRepository_CreateComment_Call struct {
+	*mock.Call
+}
+
+// CreateComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - comment *domain.Comment
+func (_e *MockSocialRepository_Expecter) CreateComment(ctx interface{}, comment interface{}) *MockSocialRepository_CreateComment_Call {
+	return &MockSocialRepository_CreateComment_Call{Call: _e.mock.On("CreateComment", ctx, comment)}
+}
+
+func (_c *MockSocialRepository_CreateComment_Call) Run(run func(ctx context.Context, comment *domain.Comment)) *MockSocialRepository_CreateComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Comment
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Comment)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSocialRepository_CreateComment_Call) Return(err error) *MockSocialRepository_CreateComment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSocialRepository_CreateComment_Call) RunAndReturn(run func(ctx context.Context, comment *domain.Comment) error) *MockSocialRepository_CreateComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListComments provides a mock function for the type MockSocialRepository
+func (_mock *MockSocialRepository) ListComments(ctx context.Context, params repository.ListCommentsParams) (*repository.Page[*domain.CommentWithUser], error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListComments")
+	}
+
+	var r0 *repository.Page[*domain.CommentWithUser]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListCommentsParams) (*repository.Page[*domain.CommentWithUser], error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListCommentsParams) *repository.Page[*domain.CommentWithUser]); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Page[*domain.CommentWithUser])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListCommentsParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSocialRepository_ListComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListComments'
+type MockSocialRepository_ListComments_Call struct {
+	*mock.Call
+}
+
+// ListComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params repository.ListCommentsParams
+func (_e *MockSocialRepository_Expecter) ListComments(ctx interface{}, params interface{}) *MockSocialRepository_ListComments_Call {
+	return &MockSocialRepository_ListComments_Call{Call: _e.mock.On("ListComments", ctx, params)}
+}
+
+func (_c *MockSocialRepository_ListComments_Call) Run(run func(ctx context.Context, params repository.ListCommentsParams)) *MockSocialRepository_ListComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repository.ListCommentsParams
+		if args[1] != nil {
+			arg1 = args[1].(repository.ListCommentsParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSocialRepository_ListComments_Call) Return(page *repository.Page[*domain.CommentWithUser], err error) *MockSocialRepository_ListComments_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *MockSocialRepository_ListComments_Call) RunAndReturn(run func(ctx context.Context, params repository.ListCommentsParams) (*repository.Page[*domain.CommentWithUser], error)) *MockSocialRepository_ListComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFavorites provides a mock function for the type MockSocialRepository
+func (_mock *MockSocialRepository) ListFavorites(ctx context.Context, userID uuid.UUID, params repository.ListMangaParams) (*repository.Page[*domain.Manga], error) {
+	ret := _mock.Called(ctx, userID, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFavorites")
+	}
+
+	var r0 *repository.Page[*domain.Manga]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, repository.ListMangaParams) (*repository.Page[*domain.Manga], error)); ok {
+		return returnFunc(ctx, userID, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, repository.ListMangaParams) *repository.Page[*domain.Manga]); ok {
+		r0 = returnFunc(ctx, userID, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Page[*domain.Manga])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, repository.ListMangaParams) error); ok {
+		r1 = returnFunc(ctx, userID, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSocialRepository_ListFavorites_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFavorites'
+type MockSocialRepository_ListFavorites_Call struct {
+	*mock.Call
+}
+
+// ListFavorites is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - params repository.ListMangaParams
+func (_e *MockSocialRepository_Expecter) ListFavorites(ctx interface{}, userID interface{}, params interface{}) *MockSocialRepository_ListFavorites_Call {
+	return &MockSocialRepository_ListFavorites_Call{Call: _e.mock.On("ListFavorites", ctx, userID, params)}
+}
+
+func (_c *MockSocialRepository_ListFavorites_Call) Run(run func(ctx context.Context, userID uuid.UUID, params repository.ListMangaParams)) *MockSocialRepository_ListFavorites_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 repository.ListMangaParams
+		if args[2] != nil {
+			arg2 = args[2].(repository.ListMangaParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSocialRepository_ListFavorites_Call) Return(page *repository.Page[*domain.Manga], err error) *MockSocialRepository_ListFavorites_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *MockSocialRepository_ListFavorites_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, params repository.ListMangaParams) (*repository.Page[*domain.Manga], error)) *MockSocialRepository_ListFavorites_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListByMangaID provides a mock function for the type MockChapterRepository
-func (_mock *MockChapterRepository) ListByMangaID(ctx context.Context, params repository.ListChaptersParams) ([]*domain.Chapter, error) {
-	ret := _mock.Called(ctx, params)
+// ListReadChapters provides a mock function for the type MockSocialRepository
+func (_mock *MockSocialRepository) ListReadChapters(ctx context.Context, userID uuid.UUID, ratings []domain.ContentRating) ([]*domain.Chapter, error) {
+	ret := _mock.Called(ctx, userID, ratings)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListByMangaID")
+		panic("no return value specified for ListReadChapters")
 	}
 
 	var r0 []*domain.Chapter
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListChaptersParams) ([]*domain.Chapter, error)); ok {
-		return returnFunc(ctx, params)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []domain.ContentRating) ([]*domain.Chapter, error)); ok {
+		return returnFunc(ctx, userID, ratings)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListChaptersParams) []*domain.Chapter); ok {
-		r0 = returnFunc(ctx, params)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []domain.ContentRating) []*domain.Chapter); ok {
+		r0 = returnFunc(ctx, userID, ratings)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Chapter)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListChaptersParams) error); ok {
-		r1 = returnFunc(ctx, params)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, []domain.ContentRating) error); ok {
+		r1 = returnFunc(ctx, userID, ratings)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockChapterRepository_ListByMangaID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByMangaID'
-type MockChapterRepository_ListByMangaID_Call struct {
+// MockSocialRepository_ListReadChapters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReadChapters'
+type MockSocialRepository_ListReadChapters_Call struct {
 	*mock.Call
 }
 
-// ListByMangaID is a helper method to define mock.On call
+// ListReadChapters is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params repository.ListChaptersParams
-func (_e *MockChapterRepository_Expecter) ListByMangaID(ctx interface{}, params interface{}) *MockChapterRepository_ListByMangaID_Call {
-	return &MockChapterRepository_ListByMangaID_Call{Call: _e.mock.On("ListByMangaID", ctx, params)}
+//   - userID uuid.UUID
+//   - ratings []domain.ContentRating
+func (_e *MockSocialRepository_Expecter) ListReadChapters(ctx interface{}, userID interface{}, ratings interface{}) *MockSocialRepository_ListReadChapters_Call {
+	return &MockSocialRepository_ListReadChapters_Call{Call: _e.mock.On("ListReadChapters", ctx, userID, ratings)}
 }
 
-func (_c *MockChapterRepository_ListByMangaID_Call) Run(run func(ctx context.Context, params repository.ListChaptersParams)) *MockChapterRepository_ListByMangaID_Call {
+func (_c *MockSocialRepository_ListReadChapters_Call) Run(run func(ctx context.Context, userID uuid.UUID, ratings []domain.ContentRating)) *MockSocialRepository_ListReadChapters_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 repository.ListChaptersParams
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(repository.ListChaptersParams)
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []domain.ContentRating
+		if args[2] != nil {
+			arg2 = args[2].([]domain.ContentRating)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockChapterRepository_ListByMangaID_Call) Return(chapters []*domain.Chapter, err error) *MockChapterRepository_ListByMangaID_Call {
+func (_c *MockSocialRepository_ListReadChapters_Call) Return(chapters []*domain.Chapter, err error) *MockSocialRepository_ListReadChapters_Call {
 	_c.Call.Return(chapters, err)
 	return _c
 }
 
-func (_c *MockChapterRepository_ListByMangaID_Call) RunAndReturn(run func(ctx context.Context, params repository.ListChaptersParams) ([]*domain.Chapter, error)) *MockChapterRepository_ListByMangaID_Call {
+func (_c *MockSocialRepository_ListReadChapters_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, ratings []domain.ContentRating) ([]*domain.Chapter, error)) *MockSocialRepository_ListReadChapters_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type MockChapterRepository
-func (_mock *MockChapterRepository) Update(ctx context.Context, chapter *domain.Chapter) error {
-	ret := _mock.Called(ctx, chapter)
+// MarkChapterAsRead provides a mock function for the type MockSocialRepository
+func (_mock *MockSocialRepository) MarkChapterAsRead(ctx context.Context, userID uuid.UUID, chapterID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, chapterID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for MarkChapterAsRead")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Chapter) error); ok {
-		r0 = returnFunc(ctx, chapter)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, chapterID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockChapterRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockChapterRepository_Update_Call struct {
+// MockSocialRepository_MarkChapterAsRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkChapterAsRead'
+type MockSocialRepository_MarkChapterAsRead_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// MarkChapterAsRead is a helper method to define mock.On call
 //   - ctx context.Context
-//   - chapter *domain.Chapter
-func (_e *MockChapterRepository_Expecter) Update(ctx interface{}, chapter interface{}) *MockChapterRepository_Update_Call {
-	return &MockChapterRepository_Update_Call{Call: _e.mock.On("Update", ctx, chapter)}
+//   - userID uuid.UUID
+//   - chapterID uuid.UUID
+func (_e *MockSocialRepository_Expecter) MarkChapterAsRead(ctx interface{}, userID interface{}, chapterID interface{}) *MockSocialRepository_MarkChapterAsRead_Call {
+	return &MockSocialRepository_MarkChapterAsRead_Call{Call: _e.mock.On("MarkChapterAsRead", ctx, userID, chapterID)}
 }
 
-func (_c *MockChapterRepository_Update_Call) Run(run func(ctx context.Context, chapter *domain.Chapter)) *MockChapterRepository_Update_Call {
+func (_c *MockSocialRepository_MarkChapterAsRead_Call) Run(run func(ctx context.Context, userID uuid.UUID, chapterID uuid.UUID)) *MockSocialRepository_MarkChapterAsRead_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.Chapter
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(*domain.Chapter)
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockChapterRepository_Update_Call) Return(err error) *MockChapterRepository_Update_Call {
+func (_c *MockSocialRepository_MarkChapterAsRead_Call) Return(err error) *MockSocialRepository_MarkChapterAsRead_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockChapterRepository_Update_Call) RunAndReturn(run func(ctx context.Context, chapter *domain.Chapter) error) *MockChapterRepository_Update_Call {
+func (_c *MockSocialRepository_MarkChapterAsRead_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, chapterID uuid.UUID) error) *MockSocialRepository_MarkChapterAsRead_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdatePages provides a mock function for the type MockChapterRepository
-func (_mock *MockChapterRepository) UpdatePages(ctx context.Context, id uuid.UUID, pages []string) error {
-	ret := _mock.Called(ctx, id, pages)
+// ToggleFavorite provides a mock function for the type MockSocialRepository
+func (_mock *MockSocialRepository) ToggleFavorite(ctx context.Context, userID uuid.UUID, mangaID uuid.UUID) (*repository.ToggleFavoriteResult, error) {
+	ret := _mock.Called(ctx, userID, mangaID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePages")
+		panic("no return value specified for ToggleFavorite")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string) error); ok {
-		r0 = returnFunc(ctx, id, pages)
+	var r0 *repository.ToggleFavoriteResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*repository.ToggleFavoriteResult, error)); ok {
+		return returnFunc(ctx, userID, mangaID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *repository.ToggleFavoriteResult); ok {
+		r0 = returnFunc(ctx, userID, mangaID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.ToggleFavoriteResult)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID, mangaID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockChapterRepository_UpdatePages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePages'
-type MockChapterRepository_UpdatePages_Call struct {
+// MockSocialRepository_ToggleFavorite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ToggleFavorite'
+type MockSocialRepository_ToggleFavorite_Call struct {
 	*mock.Call
 }
 
-// UpdatePages is a helper method to define mock.On call
+// ToggleFavorite is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-//   - pages []string
-func (_e *MockChapterRepository_Expecter) UpdatePages(ctx interface{}, id interface{}, pages interface{}) *MockChapterRepository_UpdatePages_Call {
-	return &MockChapterRepository_UpdatePages_Call{Call: _e.mock.On("UpdatePages", ctx, id, pages)}
+//   - userID uuid.UUID
+//   - mangaID uuid.UUID
+func (_e *MockSocialRepository_Expecter) ToggleFavorite(ctx interface{}, userID interface{}, mangaID interface{}) *MockSocialRepository_ToggleFavorite_Call {
+	return &MockSocialRepository_ToggleFavorite_Call{Call: _e.mock.On("ToggleFavorite", ctx, userID, mangaID)}
 }
 
-func (_c *MockChapterRepository_UpdatePages_Call) Run(run func(ctx context.Context, id uuid.UUID, pages []string)) *MockChapterRepository_UpdatePages_Call {
+func (_c *MockSocialRepository_ToggleFavorite_Call) Run(run func(ctx context.Context, userID uuid.UUID, mangaID uuid.UUID)) *MockSocialRepository_ToggleFavorite_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -387,9 +3593,9 @@ func (_c *MockChapterRepository_UpdatePages_Call) Run(run func(ctx context.Conte
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 []string
+		var arg2 uuid.UUID
 		if args[2] != nil {
-			arg2 = args[2].([]string)
+			arg2 = args[2].(uuid.UUID)
 		}
 		run(
 			arg0,
@@ -400,23 +3606,23 @@ func (_c *MockChapterRepository_UpdatePages_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockChapterRepository_UpdatePages_Call) Return(err error) *MockChapterRepository_UpdatePages_Call {
-	_c.Call.Return(err)
+func (_c *MockSocialRepository_ToggleFavorite_Call) Return(toggleFavoriteResult *repository.ToggleFavoriteResult, err error) *MockSocialRepository_ToggleFavorite_Call {
+	_c.Call.Return(toggleFavoriteResult, err)
 	return _c
 }
 
-func (_c *MockChapterRepository_UpdatePages_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, pages []string) error) *MockChapterRepository_UpdatePages_Call {
+func (_c *MockSocialRepository_ToggleFavorite_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, mangaID uuid.UUID) (*repository.ToggleFavoriteResult, error)) *MockSocialRepository_ToggleFavorite_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockMangaRepository creates a new instance of MockMangaRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockSuggestionRepository creates a new instance of MockSuggestionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockMangaRepository(t interface {
+func NewMockSuggestionRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockMangaRepository {
-	mock := &MockMangaRepository{}
+}) *MockSuggestionRepository {
+	mock := &MockSuggestionRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -424,114 +3630,57 @@ func NewMockMangaRepository(t interface {
 	return mock
 }
 
-// MockMangaRepository is an autogenerated mock type for the MangaRepository type
-type MockMangaRepository struct {
+// MockSuggestionRepository is an autogenerated mock type for the SuggestionRepository type
+type MockSuggestionRepository struct {
 	mock.Mock
 }
 
-type MockMangaRepository_Expecter struct {
+type MockSuggestionRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockMangaRepository) EXPECT() *MockMangaRepository_Expecter {
-	return &MockMangaRepository_Expecter{mock: &_m.Mock}
+func (_m *MockSuggestionRepository) EXPECT() *MockSuggestionRepository_Expecter {
+	return &MockSuggestionRepository_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function for the type MockMangaRepository
-func (_mock *MockMangaRepository) Create(ctx context.Context, manga *domain.Manga) error {
-	ret := _mock.Called(ctx, manga)
+// Create provides a mock function for the type MockSuggestionRepository
+func (_mock *MockSuggestionRepository) Create(ctx context.Context, suggestion *domain.EditSuggestion) error {
+	ret := _mock.Called(ctx, suggestion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Manga) error); ok {
-		r0 = returnFunc(ctx, manga)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.EditSuggestion) error); ok {
+		r0 = returnFunc(ctx, suggestion)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockMangaRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockMangaRepository_Create_Call struct {
+// MockSuggestionRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSuggestionRepository_Create_Call struct {
 	*mock.Call
 }
 
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
-//   - manga *domain.Manga
-func (_e *MockMangaRepository_Expecter) Create(ctx interface{}, manga interface{}) *MockMangaRepository_Create_Call {
-	return &MockMangaRepository_Create_Call{Call: _e.mock.On("Create", ctx, manga)}
-}
-
-func (_c *MockMangaRepository_Create_Call) Run(run func(ctx context.Context, manga *domain.Manga)) *MockMangaRepository_Create_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
-		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 *domain.Manga
-		if args[1] != nil {
-			arg1 = args[1].(*domain.Manga)
-		}
-		run(
-			arg0,
-			arg1,
-		)
-	})
-	return _c
-}
-
-func (_c *MockMangaRepository_Create_Call) Return(err error) *MockMangaRepository_Create_Call {
-	_c.Call.Return(err)
-	return _c
-}
-
-func (_c *MockMangaRepository_Create_Call) RunAndReturn(run func(ctx context.Context, manga *domain.Manga) error) *MockMangaRepository_Create_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// Delete provides a mock function for the type MockMangaRepository
-func (_mock *MockMangaRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	ret := _mock.Called(ctx, id)
-
-	if len(ret) == 0 {
-		panic("no return value specified for Delete")
-	}
-
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = returnFunc(ctx, id)
-	} else {
-		r0 = ret.Error(0)
-	}
-	return r0
-}
-
-// MockMangaRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockMangaRepository_Delete_Call struct {
-	*mock.Call
+//   - suggestion *domain.EditSuggestion
+func (_e *MockSuggestionRepository_Expecter) Create(ctx interface{}, suggestion interface{}) *MockSuggestionRepository_Create_Call {
+	return &MockSuggestionRepository_Create_Call{Call: _e.mock.On("Create", ctx, suggestion)}
 }
 
-// Delete is a helper method to define mock.On call
-//   - ctx context.Context
-//   - id uuid.UUID
-func (_e *MockMangaRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockMangaRepository_Delete_Call {
-	return &MockMangaRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
-}
-
-func (_c *MockMangaRepository_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockMangaRepository_Delete_Call {
+func (_c *MockSuggestionRepository_Create_Call) Run(run func(ctx context.Context, suggestion *domain.EditSuggestion)) *MockSuggestionRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uuid.UUID
+		var arg1 *domain.EditSuggestion
 		if args[1] != nil {
-			arg1 = args[1].(uuid.UUID)
+			arg1 = args[1].(*domain.EditSuggestion)
 		}
 		run(
 			arg0,
@@ -541,34 +3690,34 @@ func (_c *MockMangaRepository_Delete_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockMangaRepository_Delete_Call) Return(err error) *MockMangaRepository_Delete_Call {
+func (_c *MockSuggestionRepository_Create_Call) Return(err error) *MockSuggestionRepository_Create_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMangaRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockMangaRepository_Delete_Call {
+func (_c *MockSuggestionRepository_Create_Call) RunAndReturn(run func(ctx context.Context, suggestion *domain.EditSuggestion) error) *MockSuggestionRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FindByID provides a mock function for the type MockMangaRepository
-func (_mock *MockMangaRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Manga, error) {
+// FindByID provides a mock function for the type MockSuggestionRepository
+func (_mock *MockSuggestionRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.EditSuggestion, error) {
 	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByID")
 	}
 
-	var r0 *domain.Manga
+	var r0 *domain.EditSuggestion
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Manga, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.EditSuggestion, error)); ok {
 		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Manga); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.EditSuggestion); ok {
 		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Manga)
+			r0 = ret.Get(0).(*domain.EditSuggestion)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
@@ -579,19 +3728,19 @@ func (_mock *MockMangaRepository) FindByID(ctx context.Context, id uuid.UUID) (*
 	return r0, r1
 }
 
-// MockMangaRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
-type MockMangaRepository_FindByID_Call struct {
+// MockSuggestionRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockSuggestionRepository_FindByID_Call struct {
 	*mock.Call
 }
 
 // FindByID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockMangaRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockMangaRepository_FindByID_Call {
-	return &MockMangaRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+func (_e *MockSuggestionRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockSuggestionRepository_FindByID_Call {
+	return &MockSuggestionRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
 }
 
-func (_c *MockMangaRepository_FindByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockMangaRepository_FindByID_Call {
+func (_c *MockSuggestionRepository_FindByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSuggestionRepository_FindByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -609,37 +3758,37 @@ func (_c *MockMangaRepository_FindByID_Call) Run(run func(ctx context.Context, i
 	return _c
 }
 
-func (_c *MockMangaRepository_FindByID_Call) Return(manga *domain.Manga, err error) *MockMangaRepository_FindByID_Call {
-	_c.Call.Return(manga, err)
+func (_c *MockSuggestionRepository_FindByID_Call) Return(editSuggestion *domain.EditSuggestion, err error) *MockSuggestionRepository_FindByID_Call {
+	_c.Call.Return(editSuggestion, err)
 	return _c
 }
 
-func (_c *MockMangaRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Manga, error)) *MockMangaRepository_FindByID_Call {
+func (_c *MockSuggestionRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.EditSuggestion, error)) *MockSuggestionRepository_FindByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// List provides a mock function for the type MockMangaRepository
-func (_mock *MockMangaRepository) List(ctx context.Context, params repository.ListMangaParams) ([]*domain.Manga, error) {
+// List provides a mock function for the type MockSuggestionRepository
+func (_mock *MockSuggestionRepository) List(ctx context.Context, params repository.ListSuggestionsParams) (*repository.Page[*domain.EditSuggestion], error) {
 	ret := _mock.Called(ctx, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
 	}
 
-	var r0 []*domain.Manga
+	var r0 *repository.Page[*domain.EditSuggestion]
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListMangaParams) ([]*domain.Manga, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListSuggestionsParams) (*repository.Page[*domain.EditSuggestion], error)); ok {
 		return returnFunc(ctx, params)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListMangaParams) []*domain.Manga); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListSuggestionsParams) *repository.Page[*domain.EditSuggestion]); ok {
 		r0 = returnFunc(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.Manga)
+			r0 = ret.Get(0).(*repository.Page[*domain.EditSuggestion])
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListMangaParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListSuggestionsParams) error); ok {
 		r1 = returnFunc(ctx, params)
 	} else {
 		r1 = ret.Error(1)
@@ -647,27 +3796,27 @@ func (_mock *MockMangaRepository) List(ctx context.Context, params repository.Li
 	return r0, r1
 }
 
-// MockMangaRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
-type MockMangaRepository_List_Call struct {
+// MockSuggestionRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockSuggestionRepository_List_Call struct {
 	*mock.Call
 }
 
 // List is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params repository.ListMangaParams
-func (_e *MockMangaRepository_Expecter) List(ctx interface{}, params interface{}) *MockMangaRepository_List_Call {
-	return &MockMangaRepository_List_Call{Call: _e.mock.On("List", ctx, params)}
+//   - params repository.ListSuggestionsParams
+func (_e *MockSuggestionRepository_Expecter) List(ctx interface{}, params interface{}) *MockSuggestionRepository_List_Call {
+	return &MockSuggestionRepository_List_Call{Call: _e.mock.On("List", ctx, params)}
 }
 
-func (_c *MockMangaRepository_List_Call) Run(run func(ctx context.Context, params repository.ListMangaParams)) *MockMangaRepository_List_Call {
+func (_c *MockSuggestionRepository_List_Call) Run(run func(ctx context.Context, params repository.ListSuggestionsParams)) *MockSuggestionRepository_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 repository.ListMangaParams
+		var arg1 repository.ListSuggestionsParams
 		if args[1] != nil {
-			arg1 = args[1].(repository.ListMangaParams)
+			arg1 = args[1].(repository.ListSuggestionsParams)
 		}
 		run(
 			arg0,
@@ -677,80 +3826,98 @@ func (_c *MockMangaRepository_List_Call) Run(run func(ctx context.Context, param
 	return _c
 }
 
-func (_c *MockMangaRepository_List_Call) Return(mangas []*domain.Manga, err error) *MockMangaRepository_List_Call {
-	_c.Call.Return(mangas, err)
+func (_c *MockSuggestionRepository_List_Call) Return(page *repository.Page[*domain.EditSuggestion], err error) *MockSuggestionRepository_List_Call {
+	_c.Call.Return(page, err)
 	return _c
 }
 
-func (_c *MockMangaRepository_List_Call) RunAndReturn(run func(ctx context.Context, params repository.ListMangaParams) ([]*domain.Manga, error)) *MockMangaRepository_List_Call {
+func (_c *MockSuggestionRepository_List_Call) RunAndReturn(run func(ctx context.Context, params repository.ListSuggestionsParams) (*repository.Page[*domain.EditSuggestion], error)) *MockSuggestionRepository_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type MockMangaRepository
-func (_mock *MockMangaRepository) Update(ctx context.Context, manga *domain.Manga) error {
-	ret := _mock.Called(ctx, manga)
+// Review provides a mock function for the type MockSuggestionRepository
+func (_mock *MockSuggestionRepository) Review(ctx context.Context, id uuid.UUID, status domain.SuggestionStatus, reviewerID uuid.UUID, reason *string) error {
+	ret := _mock.Called(ctx, id, status, reviewerID, reason)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for Review")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Manga) error); ok {
-		r0 = returnFunc(ctx, manga)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain.SuggestionStatus, uuid.UUID, *string) error); ok {
+		r0 = returnFunc(ctx, id, status, reviewerID, reason)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockMangaRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockMangaRepository_Update_Call struct {
+// MockSuggestionRepository_Review_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Review'
+type MockSuggestionRepository_Review_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// Review is a helper method to define mock.On call
 //   - ctx context.Context
-//   - manga *domain.Manga
-func (_e *MockMangaRepository_Expecter) Update(ctx interface{}, manga interface{}) *MockMangaRepository_Update_Call {
-	return &MockMangaRepository_Update_Call{Call: _e.mock.On("Update", ctx, manga)}
+//   - id uuid.UUID
+//   - status domain.SuggestionStatus
+//   - reviewerID uuid.UUID
+//   - reason *string
+func (_e *MockSuggestionRepository_Expecter) Review(ctx interface{}, id interface{}, status interface{}, reviewerID interface{}, reason interface{}) *MockSuggestionRepository_Review_Call {
+	return &MockSuggestionRepository_Review_Call{Call: _e.mock.On("Review", ctx, id, status, reviewerID, reason)}
 }
 
-func (_c *MockMangaRepository_Update_Call) Run(run func(ctx context.Context, manga *domain.Manga)) *MockMangaRepository_Update_Call {
+func (_c *MockSuggestionRepository_Review_Call) Run(run func(ctx context.Context, id uuid.UUID, status domain.SuggestionStatus, reviewerID uuid.UUID, reason *string)) *MockSuggestionRepository_Review_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.Manga
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(*domain.Manga)
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 domain.SuggestionStatus
+		if args[2] != nil {
+			arg2 = args[2].(domain.SuggestionStatus)
+		}
+		var arg3 uuid.UUID
+		if args[3] != nil {
+			arg3 = args[3].(uuid.UUID)
+		}
+		var arg4 *string
+		if args[4] != nil {
+			arg4 = args[4].(*string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockMangaRepository_Update_Call) Return(err error) *MockMangaRepository_Update_Call {
+func (_c *MockSuggestionRepository_Review_Call) Return(err error) *MockSuggestionRepository_Review_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockMangaRepository_Update_Call) RunAndReturn(run func(ctx context.Context, manga *domain.Manga) error) *MockMangaRepository_Update_Call {
+func (_c *MockSuggestionRepository_Review_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, status domain.SuggestionStatus, reviewerID uuid.UUID, reason *string) error) *MockSuggestionRepository_Review_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockSocialRepository creates a new instance of MockSocialRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockTagRepository creates a new instance of MockTagRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockSocialRepository(t interface {
+func NewMockTagRepository(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockSocialRepository {
-	mock := &MockSocialRepository{}
+}) *MockTagRepository {
+	mock := &MockTagRepository{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -758,57 +3925,57 @@ func NewMockSocialRepository(t interface {
 	return mock
 }
 
-// MockSocialRepository is an autogenerated mock type for the SocialRepository type
-type MockSocialRepository struct {
+// MockTagRepository is an autogenerated mock type for the TagRepository type
+type MockTagRepository struct {
 	mock.Mock
 }
 
-type MockSocialRepository_Expecter struct {
+type MockTagRepository_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockSocialRepository) EXPECT() *MockSocialRepository_Expecter {
-	return &MockSocialRepository_Expecter{mock: &_m.Mock}
+func (_m *MockTagRepository) EXPECT() *MockTagRepository_Expecter {
+	return &MockTagRepository_Expecter{mock: &_m.Mock}
 }
 
-// CreateComment provides a mock function for the type MockSocialRepository
-func (_mock *MockSocialRepository) CreateComment(ctx context.Context, comment *domain.Comment) error {
-	ret := _mock.Called(ctx, comment)
+// Create provides a mock function for the type MockTagRepository
+func (_mock *MockTagRepository) Create(ctx context.Context, tag *domain.Tag) error {
+	ret := _mock.Called(ctx, tag)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateComment")
+		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Comment) error); ok {
-		r0 = returnFunc(ctx, comment)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Tag) error); ok {
+		r0 = returnFunc(ctx, tag)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockSocialRepository_CreateComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateComment'
-type MockSocialRepository_CreateComment_Call struct {
+// MockTagRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockTagRepository_Create_Call struct {
 	*mock.Call
 }
 
-// CreateComment is a helper method to define mock.On call
+// Create is a helper method to define mock.On call
 //   - ctx context.Context
-//   - comment *domain.Comment
-func (_e *MockSocialRepository_Expecter) CreateComment(ctx interface{}, comment interface{}) *MockSocialRepository_CreateComment_Call {
-	return &MockSocialRepository_CreateComment_Call{Call: _e.mock.On("CreateComment", ctx, comment)}
+//   - tag *domain.Tag
+func (_e *MockTagRepository_Expecter) Create(ctx interface{}, tag interface{}) *MockTagRepository_Create_Call {
+	return &MockTagRepository_Create_Call{Call: _e.mock.On("Create", ctx, tag)}
 }
 
-func (_c *MockSocialRepository_CreateComment_Call) Run(run func(ctx context.Context, comment *domain.Comment)) *MockSocialRepository_CreateComment_Call {
+func (_c *MockTagRepository_Create_Call) Run(run func(ctx context.Context, tag *domain.Tag)) *MockTagRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.Comment
+		var arg1 *domain.Tag
 		if args[1] != nil {
-			arg1 = args[1].(*domain.Comment)
+			arg1 = args[1].(*domain.Tag)
 		}
 		run(
 			arg0,
@@ -818,65 +3985,54 @@ func (_c *MockSocialRepository_CreateComment_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *MockSocialRepository_CreateComment_Call) Return(err error) *MockSocialRepository_CreateComment_Call {
+func (_c *MockTagRepository_Create_Call) Return(err error) *MockTagRepository_Create_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSocialRepository_CreateComment_Call) RunAndReturn(run func(ctx context.Context, comment *domain.Comment) error) *MockSocialRepository_CreateComment_Call {
+func (_c *MockTagRepository_Create_Call) RunAndReturn(run func(ctx context.Context, tag *domain.Tag) error) *MockTagRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListComments provides a mock function for the type MockSocialRepository
-func (_mock *MockSocialRepository) ListComments(ctx context.Context, params repository.ListCommentsParams) ([]*domain.CommentWithUser, error) {
-	ret := _mock.Called(ctx, params)
+// Delete provides a mock function for the type MockTagRepository
+func (_mock *MockTagRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListComments")
+		panic("no return value specified for Delete")
 	}
 
-	var r0 []*domain.CommentWithUser
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListCommentsParams) ([]*domain.CommentWithUser, error)); ok {
-		return returnFunc(ctx, params)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.ListCommentsParams) []*domain.CommentWithUser); ok {
-		r0 = returnFunc(ctx, params)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.CommentWithUser)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.ListCommentsParams) error); ok {
-		r1 = returnFunc(ctx, params)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockSocialRepository_ListComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListComments'
-type MockSocialRepository_ListComments_Call struct {
+// MockTagRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockTagRepository_Delete_Call struct {
 	*mock.Call
 }
 
-// ListComments is a helper method to define mock.On call
+// Delete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params repository.ListCommentsParams
-func (_e *MockSocialRepository_Expecter) ListComments(ctx interface{}, params interface{}) *MockSocialRepository_ListComments_Call {
-	return &MockSocialRepository_ListComments_Call{Call: _e.mock.On("ListComments", ctx, params)}
+//   - id uuid.UUID
+func (_e *MockTagRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockTagRepository_Delete_Call {
+	return &MockTagRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
 }
 
-func (_c *MockSocialRepository_ListComments_Call) Run(run func(ctx context.Context, params repository.ListCommentsParams)) *MockSocialRepository_ListComments_Call {
+func (_c *MockTagRepository_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockTagRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 repository.ListCommentsParams
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(repository.ListCommentsParams)
+			arg1 = args[1].(uuid.UUID)
 		}
 		run(
 			arg0,
@@ -886,58 +4042,57 @@ func (_c *MockSocialRepository_ListComments_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockSocialRepository_ListComments_Call) Return(commentWithUsers []*domain.CommentWithUser, err error) *MockSocialRepository_ListComments_Call {
-	_c.Call.Return(commentWithUsers, err)
+func (_c *MockTagRepository_Delete_Call) Return(err error) *MockTagRepository_Delete_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSocialRepository_ListComments_Call) RunAndReturn(run func(ctx context.Context, params repository.ListCommentsParams) ([]*domain.CommentWithUser, error)) *MockSocialRepository_ListComments_Call {
+func (_c *MockTagRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockTagRepository_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListFavorites provides a mock function for the type MockSocialRepository
-func (_mock *MockSocialRepository) ListFavorites(ctx context.Context, userID uuid.UUID, params repository.ListMangaParams) ([]*domain.Manga, error) {
-	ret := _mock.Called(ctx, userID, params)
+// FindByID provides a mock function for the type MockTagRepository
+func (_mock *MockTagRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Tag, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListFavorites")
+		panic("no return value specified for FindByID")
 	}
 
-	var r0 []*domain.Manga
+	var r0 *domain.Tag
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, repository.ListMangaParams) ([]*domain.Manga, error)); ok {
-		return returnFunc(ctx, userID, params)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Tag, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, repository.ListMangaParams) []*domain.Manga); ok {
-		r0 = returnFunc(ctx, userID, params)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Tag); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.Manga)
+			r0 = ret.Get(0).(*domain.Tag)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, repository.ListMangaParams) error); ok {
-		r1 = returnFunc(ctx, userID, params)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSocialRepository_ListFavorites_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFavorites'
-type MockSocialRepository_ListFavorites_Call struct {
+// MockTagRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockTagRepository_FindByID_Call struct {
 	*mock.Call
 }
 
-// ListFavorites is a helper method to define mock.On call
+// FindByID is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-//   - params repository.ListMangaParams
-func (_e *MockSocialRepository_Expecter) ListFavorites(ctx interface{}, userID interface{}, params interface{}) *MockSocialRepository_ListFavorites_Call {
-	return &MockSocialRepository_ListFavorites_Call{Call: _e.mock.On("ListFavorites", ctx, userID, params)}
+//   - id uuid.UUID
+func (_e *MockTagRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockTagRepository_FindByID_Call {
+	return &MockTagRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
 }
 
-func (_c *MockSocialRepository_ListFavorites_Call) Run(run func(ctx context.Context, userID uuid.UUID, params repository.ListMangaParams)) *MockSocialRepository_ListFavorites_Call {
+func (_c *MockTagRepository_FindByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockTagRepository_FindByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -947,78 +4102,73 @@ func (_c *MockSocialRepository_ListFavorites_Call) Run(run func(ctx context.Cont
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 repository.ListMangaParams
-		if args[2] != nil {
-			arg2 = args[2].(repository.ListMangaParams)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSocialRepository_ListFavorites_Call) Return(mangas []*domain.Manga, err error) *MockSocialRepository_ListFavorites_Call {
-	_c.Call.Return(mangas, err)
+func (_c *MockTagRepository_FindByID_Call) Return(tag *domain.Tag, err error) *MockTagRepository_FindByID_Call {
+	_c.Call.Return(tag, err)
 	return _c
 }
 
-func (_c *MockSocialRepository_ListFavorites_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, params repository.ListMangaParams) ([]*domain.Manga, error)) *MockSocialRepository_ListFavorites_Call {
+func (_c *MockTagRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Tag, error)) *MockTagRepository_FindByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListReadChapters provides a mock function for the type MockSocialRepository
-func (_mock *MockSocialRepository) ListReadChapters(ctx context.Context, userID uuid.UUID) ([]*domain.Chapter, error) {
-	ret := _mock.Called(ctx, userID)
+// List provides a mock function for the type MockTagRepository
+func (_mock *MockTagRepository) List(ctx context.Context, group domain.TagGroup) ([]*domain.Tag, error) {
+	ret := _mock.Called(ctx, group)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListReadChapters")
+		panic("no return value specified for List")
 	}
 
-	var r0 []*domain.Chapter
+	var r0 []*domain.Tag
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*domain.Chapter, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TagGroup) ([]*domain.Tag, error)); ok {
+		return returnFunc(ctx, group)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*domain.Chapter); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.TagGroup) []*domain.Tag); ok {
+		r0 = returnFunc(ctx, group)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.Chapter)
+			r0 = ret.Get(0).([]*domain.Tag)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.TagGroup) error); ok {
+		r1 = returnFunc(ctx, group)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSocialRepository_ListReadChapters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReadChapters'
-type MockSocialRepository_ListReadChapters_Call struct {
+// MockTagRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockTagRepository_List_Call struct {
 	*mock.Call
 }
 
-// ListReadChapters is a helper method to define mock.On call
+// List is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-func (_e *MockSocialRepository_Expecter) ListReadChapters(ctx interface{}, userID interface{}) *MockSocialRepository_ListReadChapters_Call {
-	return &MockSocialRepository_ListReadChapters_Call{Call: _e.mock.On("ListReadChapters", ctx, userID)}
+//   - group domain.TagGroup
+func (_e *MockTagRepository_Expecter) List(ctx interface{}, group interface{}) *MockTagRepository_List_Call {
+	return &MockTagRepository_List_Call{Call: _e.mock.On("List", ctx, group)}
 }
 
-func (_c *MockSocialRepository_ListReadChapters_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSocialRepository_ListReadChapters_Call {
+func (_c *MockTagRepository_List_Call) Run(run func(ctx context.Context, group domain.TagGroup)) *MockTagRepository_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uuid.UUID
+		var arg1 domain.TagGroup
 		if args[1] != nil {
-			arg1 = args[1].(uuid.UUID)
+			arg1 = args[1].(domain.TagGroup)
 		}
 		run(
 			arg0,
@@ -1028,47 +4178,57 @@ func (_c *MockSocialRepository_ListReadChapters_Call) Run(run func(ctx context.C
 	return _c
 }
 
-func (_c *MockSocialRepository_ListReadChapters_Call) Return(chapters []*domain.Chapter, err error) *MockSocialRepository_ListReadChapters_Call {
-	_c.Call.Return(chapters, err)
+func (_c *MockTagRepository_List_Call) Return(tags []*domain.Tag, err error) *MockTagRepository_List_Call {
+	_c.Call.Return(tags, err)
 	return _c
 }
 
-func (_c *MockSocialRepository_ListReadChapters_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]*domain.Chapter, error)) *MockSocialRepository_ListReadChapters_Call {
+func (_c *MockTagRepository_List_Call) RunAndReturn(run func(ctx context.Context, group domain.TagGroup) ([]*domain.Tag, error)) *MockTagRepository_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MarkChapterAsRead provides a mock function for the type MockSocialRepository
-func (_mock *MockSocialRepository) MarkChapterAsRead(ctx context.Context, userID uuid.UUID, chapterID uuid.UUID) error {
-	ret := _mock.Called(ctx, userID, chapterID)
+// ListMangaIDs provides a mock function for the type MockTagRepository
+func (_mock *MockTagRepository) ListMangaIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MarkChapterAsRead")
+		panic("no return value specified for ListMangaIDs")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
-		r0 = returnFunc(ctx, userID, chapterID)
+	var r0 []uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]uuid.UUID, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []uuid.UUID); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockSocialRepository_MarkChapterAsRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkChapterAsRead'
-type MockSocialRepository_MarkChapterAsRead_Call struct {
+// MockTagRepository_ListMangaIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMangaIDs'
+type MockTagRepository_ListMangaIDs_Call struct {
 	*mock.Call
 }
 
-// MarkChapterAsRead is a helper method to define mock.On call
+// ListMangaIDs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-//   - chapterID uuid.UUID
-func (_e *MockSocialRepository_Expecter) MarkChapterAsRead(ctx interface{}, userID interface{}, chapterID interface{}) *MockSocialRepository_MarkChapterAsRead_Call {
-	return &MockSocialRepository_MarkChapterAsRead_Call{Call: _e.mock.On("MarkChapterAsRead", ctx, userID, chapterID)}
+//   - id uuid.UUID
+func (_e *MockTagRepository_Expecter) ListMangaIDs(ctx interface{}, id interface{}) *MockTagRepository_ListMangaIDs_Call {
+	return &MockTagRepository_ListMangaIDs_Call{Call: _e.mock.On("ListMangaIDs", ctx, id)}
 }
 
-func (_c *MockSocialRepository_MarkChapterAsRead_Call) Run(run func(ctx context.Context, userID uuid.UUID, chapterID uuid.UUID)) *MockSocialRepository_MarkChapterAsRead_Call {
+func (_c *MockTagRepository_ListMangaIDs_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockTagRepository_ListMangaIDs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1078,99 +4238,77 @@ func (_c *MockSocialRepository_MarkChapterAsRead_Call) Run(run func(ctx context.
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
-		var arg2 uuid.UUID
-		if args[2] != nil {
-			arg2 = args[2].(uuid.UUID)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSocialRepository_MarkChapterAsRead_Call) Return(err error) *MockSocialRepository_MarkChapterAsRead_Call {
-	_c.Call.Return(err)
+func (_c *MockTagRepository_ListMangaIDs_Call) Return(uUIDs []uuid.UUID, err error) *MockTagRepository_ListMangaIDs_Call {
+	_c.Call.Return(uUIDs, err)
 	return _c
 }
 
-func (_c *MockSocialRepository_MarkChapterAsRead_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, chapterID uuid.UUID) error) *MockSocialRepository_MarkChapterAsRead_Call {
+func (_c *MockTagRepository_ListMangaIDs_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)) *MockTagRepository_ListMangaIDs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ToggleFavorite provides a mock function for the type MockSocialRepository
-func (_mock *MockSocialRepository) ToggleFavorite(ctx context.Context, userID uuid.UUID, mangaID uuid.UUID) (*repository.ToggleFavoriteResult, error) {
-	ret := _mock.Called(ctx, userID, mangaID)
+// Update provides a mock function for the type MockTagRepository
+func (_mock *MockTagRepository) Update(ctx context.Context, tag *domain.Tag) error {
+	ret := _mock.Called(ctx, tag)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ToggleFavorite")
+		panic("no return value specified for Update")
 	}
 
-	var r0 *repository.ToggleFavoriteResult
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*repository.ToggleFavoriteResult, error)); ok {
-		return returnFunc(ctx, userID, mangaID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *repository.ToggleFavoriteResult); ok {
-		r0 = returnFunc(ctx, userID, mangaID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*repository.ToggleFavoriteResult)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, userID, mangaID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Tag) error); ok {
+		r0 = returnFunc(ctx, tag)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockSocialRepository_ToggleFavorite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ToggleFavorite'
-type MockSocialRepository_ToggleFavorite_Call struct {
+// MockTagRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockTagRepository_Update_Call struct {
 	*mock.Call
 }
 
-// ToggleFavorite is a helper method to define mock.On call
+// Update is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-//   - mangaID uuid.UUID
-func (_e *MockSocialRepository_Expecter) ToggleFavorite(ctx interface{}, userID interface{}, mangaID interface{}) *MockSocialRepository_ToggleFavorite_Call {
-	return &MockSocialRepository_ToggleFavorite_Call{Call: _e.mock.On("ToggleFavorite", ctx, userID, mangaID)}
+//   - tag *domain.Tag
+func (_e *MockTagRepository_Expecter) Update(ctx interface{}, tag interface{}) *MockTagRepository_Update_Call {
+	return &MockTagRepository_Update_Call{Call: _e.mock.On("Update", ctx, tag)}
 }
 
-func (_c *MockSocialRepository_ToggleFavorite_Call) Run(run func(ctx context.Context, userID uuid.UUID, mangaID uuid.UUID)) *MockSocialRepository_ToggleFavorite_Call {
+func (_c *MockTagRepository_Update_Call) Run(run func(ctx context.Context, tag *domain.Tag)) *MockTagRepository_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uuid.UUID
+		var arg1 *domain.Tag
 		if args[1] != nil {
-			arg1 = args[1].(uuid.UUID)
-		}
-		var arg2 uuid.UUID
-		if args[2] != nil {
-			arg2 = args[2].(uuid.UUID)
+			arg1 = args[1].(*domain.Tag)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSocialRepository_ToggleFavorite_Call) Return(toggleFavoriteResult *repository.ToggleFavoriteResult, err error) *MockSocialRepository_ToggleFavorite_Call {
-	_c.Call.Return(toggleFavoriteResult, err)
+func (_c *MockTagRepository_Update_Call) Return(err error) *MockTagRepository_Update_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSocialRepository_ToggleFavorite_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, mangaID uuid.UUID) (*repository.ToggleFavoriteResult, error)) *MockSocialRepository_ToggleFavorite_Call {
+func (_c *MockTagRepository_Update_Call) RunAndReturn(run func(ctx context.Context, tag *domain.Tag) error) *MockTagRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1259,6 +4397,75 @@ func (_c *MockUserRepository_Create_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// CreatePasswordResetToken provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) CreatePasswordResetToken(ctx context.Context, userID uuid.UUID, tokenHash []byte, expiresAt time.Time) error {
+	ret := _mock.Called(ctx, userID, tokenHash, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordResetToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []byte, time.Time) error); ok {
+		r0 = returnFunc(ctx, userID, tokenHash, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_CreatePasswordResetToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePasswordResetToken'
+type MockUserRepository_CreatePasswordResetToken_Call struct {
+	*mock.Call
+}
+
+// CreatePasswordResetToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - tokenHash []byte
+//   - expiresAt time.Time
+func (_e *MockUserRepository_Expecter) CreatePasswordResetToken(ctx interface{}, userID interface{}, tokenHash interface{}, expiresAt interface{}) *MockUserRepository_CreatePasswordResetToken_Call {
+	return &MockUserRepository_CreatePasswordResetToken_Call{Call: _e.mock.On("CreatePasswordResetToken", ctx, userID, tokenHash, expiresAt)}
+}
+
+func (_c *MockUserRepository_CreatePasswordResetToken_Call) Run(run func(ctx context.Context, userID uuid.UUID, tokenHash []byte, expiresAt time.Time)) *MockUserRepository_CreatePasswordResetToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_CreatePasswordResetToken_Call) Return(err error) *MockUserRepository_CreatePasswordResetToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_CreatePasswordResetToken_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, tokenHash []byte, expiresAt time.Time) error) *MockUserRepository_CreatePasswordResetToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindByEmail provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	ret := _mock.Called(ctx, email)
@@ -1524,3 +4731,66 @@ func (_c *MockUserRepository_GetRoleAndPermissions_Call) RunAndReturn(run func(c
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateContentRatings provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) UpdateContentRatings(ctx context.Context, userID uuid.UUID, ratings []domain.ContentRating) error {
+	ret := _mock.Called(ctx, userID, ratings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateContentRatings")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []domain.ContentRating) error); ok {
+		r0 = returnFunc(ctx, userID, ratings)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_UpdateContentRatings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateContentRatings'
+type MockUserRepository_UpdateContentRatings_Call struct {
+	*mock.Call
+}
+
+// UpdateContentRatings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - ratings []domain.ContentRating
+func (_e *MockUserRepository_Expecter) UpdateContentRatings(ctx interface{}, userID interface{}, ratings interface{}) *MockUserRepository_UpdateContentRatings_Call {
+	return &MockUserRepository_UpdateContentRatings_Call{Call: _e.mock.On("UpdateContentRatings", ctx, userID, ratings)}
+}
+
+func (_c *MockUserRepository_UpdateContentRatings_Call) Run(run func(ctx context.Context, userID uuid.UUID, ratings []domain.ContentRating)) *MockUserRepository_UpdateContentRatings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []domain.ContentRating
+		if args[2] != nil {
+			arg2 = args[2].([]domain.ContentRating)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UpdateContentRatings_Call) Return(err error) *MockUserRepository_UpdateContentRatings_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_UpdateContentRatings_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, ratings []domain.ContentRating) error) *MockUserRepository_UpdateContentRatings_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "errors"
+
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Page is one page of a list. Lists are paginated by offset or, for deep pages, by passing
+// the previous page's NextCursor, which continues right after its last item.
+type Page[T any] struct {
+	Items      []T
+	Total      int    // Items across all pages
+	NextCursor string // Empty on the last page
+}
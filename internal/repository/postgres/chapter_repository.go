@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/0xpanadol/manga/internal/domain"
 	"github.com/0xpanadol/manga/internal/repository"
@@ -13,6 +14,10 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// chapterColumns lists the columns scanned by scanChapter, in order.
+const chapterColumns = `id, manga_id, chapter_number, title, volume, pages, publication_state, publish_at, version, deleted_at,
+            uploaded_by, review_status, reviewed_by, reviewed_at, rejection_reason, created_at, updated_at`
+
 type PostgresChapterRepository struct {
 	DB *pgxpool.Pool
 }
@@ -21,82 +26,179 @@ func NewPostgresChapterRepository(db *pgxpool.Pool) *PostgresChapterRepository {
 	return &PostgresChapterRepository{DB: db}
 }
 
-func (r *PostgresChapterRepository) Create(ctx context.Context, chapter *domain.Chapter) error {
+// scanChapter scans a row of chapterColumns, followed by any extra columns into extra.
+func scanChapter(row pgx.Row, extra ...any) (*domain.Chapter, error) {
+	var chapter domain.Chapter
+	dest := []any{
+		&chapter.ID, &chapter.MangaID, &chapter.ChapterNumber, &chapter.Title, &chapter.Volume, &chapter.Pages,
+		&chapter.PublicationState, &chapter.PublishAt, &chapter.Version, &chapter.DeletedAt,
+		&chapter.UploadedBy, &chapter.ReviewStatus, &chapter.ReviewedBy, &chapter.ReviewedAt, &chapter.RejectionReason,
+		&chapter.CreatedAt, &chapter.UpdatedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &chapter, nil
+}
+
+func scanChapters(rows pgx.Rows) ([]*domain.Chapter, error) {
+	defer rows.Close()
+
+	var chapters []*domain.Chapter
+	for rows.Next() {
+		chapter, err := scanChapter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan chapter row: %w", err)
+		}
+		chapters = append(chapters, chapter)
+	}
+	return chapters, rows.Err()
+}
+
+// Create adds a chapter to a manga, returning ErrMangaNotFound if the manga is missing or in
+// the trash. An empty review status is stored as approved.
+func (r *PostgresChapterRepository) Create(ctx context.Context, chapter *domain.Chapter, revise repository.Revise[*domain.Chapter]) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-        INSERT INTO chapters (manga_id, chapter_number, title, pages)
-        VALUES ($1, $2, $3, $4)
-        RETURNING id, created_at, updated_at`
+        INSERT INTO chapters (manga_id, chapter_number, title, volume, pages, publication_state, publish_at, uploaded_by, review_status)
+        SELECT $1::uuid, $2::varchar, $3::varchar, $4::varchar, $5::text[], $6::chapter_publication_state, $7::timestamptz,
+            $8::uuid, COALESCE(NULLIF($9::text, '')::chapter_review_status, 'approved')
+        WHERE EXISTS (SELECT 1 FROM manga WHERE id = $1 AND deleted_at IS NULL)
+        RETURNING id, review_status, version, created_at, updated_at`
 
-	err := r.DB.QueryRow(ctx, query, chapter.MangaID, chapter.ChapterNumber, chapter.Title, chapter.Pages).Scan(
+	err = tx.QueryRow(ctx, query,
+		chapter.MangaID, chapter.ChapterNumber, chapter.Title, chapter.Volume, chapter.Pages, chapter.PublicationState, chapter.PublishAt,
+		chapter.UploadedBy, string(chapter.ReviewStatus),
+	).Scan(
 		&chapter.ID,
+		&chapter.ReviewStatus,
+		&chapter.Version,
 		&chapter.CreatedAt,
 		&chapter.UpdatedAt,
 	)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrMangaNotFound
+		}
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
 			return repository.ErrChapterAlreadyExists
 		}
 		return fmt.Errorf("failed to create chapter: %w", err)
 	}
-	return nil
+	if err := reviseInTx(ctx, tx, revise, chapter); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 func (r *PostgresChapterRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Chapter, error) {
-	var chapter domain.Chapter
-	query := `SELECT id, manga_id, chapter_number, title, pages, created_at, updated_at FROM chapters WHERE id = $1`
+	query := `SELECT ` + chapterColumns + ` FROM chapters WHERE id = $1 AND deleted_at IS NULL`
 
-	err := r.DB.QueryRow(ctx, query, id).Scan(
-		&chapter.ID, &chapter.MangaID, &chapter.ChapterNumber, &chapter.Title, &chapter.Pages, &chapter.CreatedAt, &chapter.UpdatedAt,
-	)
+	chapter, err := scanChapter(r.DB.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, repository.ErrChapterNotFound
 		}
 		return nil, fmt.Errorf("failed to find chapter by id: %w", err)
 	}
-	return &chapter, nil
+	return chapter, nil
 }
 
-func (r *PostgresChapterRepository) ListByMangaID(ctx context.Context, params repository.ListChaptersParams) ([]*domain.Chapter, error) {
-	query := `
-        SELECT id, manga_id, chapter_number, title, pages, created_at, updated_at
-        FROM chapters
-        WHERE manga_id = $1
-        ORDER BY chapter_number DESC -- Or however you want to sort
-        LIMIT $2 OFFSET $3`
+func (r *PostgresChapterRepository) FindByMangaAndNumber(ctx context.Context, mangaID uuid.UUID, chapterNumber string) (*domain.Chapter, error) {
+	query := `SELECT ` + chapterColumns + ` FROM chapters WHERE manga_id = $1 AND chapter_number = $2 AND deleted_at IS NULL`
 
-	rows, err := r.DB.Query(ctx, query, params.MangaID, params.Limit, params.Offset)
+	chapter, err := scanChapter(r.DB.QueryRow(ctx, query, mangaID, chapterNumber))
 	if err != nil {
-		return nil, fmt.Errorf("failed to list chapters: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrChapterNotFound
+		}
+		return nil, fmt.Errorf("failed to find chapter by number: %w", err)
 	}
-	defer rows.Close()
+	return chapter, nil
+}
 
-	var chapters []*domain.Chapter
-	for rows.Next() {
-		var chapter domain.Chapter
-		if err := rows.Scan(
-			&chapter.ID, &chapter.MangaID, &chapter.ChapterNumber, &chapter.Title, &chapter.Pages, &chapter.CreatedAt, &chapter.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan chapter row: %w", err)
+// ListByMangaID lists a manga's chapters by chapter number, highest first.
+func (r *PostgresChapterRepository) ListByMangaID(ctx context.Context, params repository.ListChaptersParams) (*repository.Page[*domain.Chapter], error) {
+	where := `WHERE manga_id = $1 AND deleted_at IS NULL AND (NOT $2 OR publication_state = 'published')`
+	args := []interface{}{params.MangaID, params.PublishedOnly}
+
+	var total int
+	if err := r.DB.QueryRow(ctx, `SELECT count(*) FROM chapters `+where, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count chapters: %w", err)
+	}
+
+	const sortName = "chapter_number:desc"
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor, sortName, "text")
+		if err != nil {
+			return nil, err
 		}
-		chapters = append(chapters, &chapter)
+		where += " AND " + keysetCondition("chapter_number", "id", "text", true, 3)
+		args = append(args, c.Key, c.ID)
+		params.Offset = 0
 	}
-	return chapters, nil
+
+	query := `SELECT ` + chapterColumns + ` FROM chapters ` + where + fmt.Sprintf(`
+        ORDER BY chapter_number DESC, id DESC
+        LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, params.Limit+1, params.Offset) // One more to see if there's a next page
+
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chapters: %w", err)
+	}
+	chapters, err := scanChapters(rows)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(chapters, params.Limit, total, func(ch *domain.Chapter) cursor {
+		return cursor{Sort: sortName, Key: ch.ChapterNumber, ID: ch.ID}
+	}), nil
 }
 
-func (r *PostgresChapterRepository) Update(ctx context.Context, chapter *domain.Chapter) error {
+// ListByVolume returns every published chapter of a manga's volume. Chapter numbers are strings,
+// so callers are responsible for ordering them naturally.
+func (r *PostgresChapterRepository) ListByVolume(ctx context.Context, mangaID uuid.UUID, volume string) ([]*domain.Chapter, error) {
+	query := `
+        SELECT ` + chapterColumns + `
+        FROM chapters
+        WHERE manga_id = $1 AND volume = $2 AND publication_state = 'published' AND deleted_at IS NULL`
+
+	rows, err := r.DB.Query(ctx, query, mangaID, volume)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volume chapters: %w", err)
+	}
+	return scanChapters(rows)
+}
+
+func (r *PostgresChapterRepository) Update(ctx context.Context, chapter *domain.Chapter, revise repository.Revise[*domain.Chapter]) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
         UPDATE chapters
-        SET chapter_number = $1, title = $2, pages = $3, updated_at = now()
-        WHERE id = $4
-        RETURNING updated_at`
+        SET chapter_number = $1, title = $2, volume = $3, pages = $4, publication_state = $5, publish_at = $6,
+            version = version + 1, updated_at = now()
+        WHERE id = $7 AND deleted_at IS NULL AND ` + versionCondition(8) + `
+        RETURNING manga_id, version, created_at, updated_at`
 
-	err := r.DB.QueryRow(ctx, query, chapter.ChapterNumber, chapter.Title, chapter.Pages, chapter.ID).Scan(&chapter.UpdatedAt)
+	err = tx.QueryRow(ctx, query,
+		chapter.ChapterNumber, chapter.Title, chapter.Volume, chapter.Pages, chapter.PublicationState, chapter.PublishAt, chapter.ID, chapter.Version,
+	).Scan(&chapter.MangaID, &chapter.Version, &chapter.CreatedAt, &chapter.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return repository.ErrChapterNotFound
+			return missingOrConflict(ctx, r.DB, "chapters", chapter.ID, chapter.Version, repository.ErrChapterNotFound)
 		}
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
@@ -104,22 +206,38 @@ func (r *PostgresChapterRepository) Update(ctx context.Context, chapter *domain.
 		}
 		return fmt.Errorf("failed to update chapter: %w", err)
 	}
-	return nil
+	if err := reviseInTx(ctx, tx, revise, chapter); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
-func (r *PostgresChapterRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	cmdTag, err := r.DB.Exec(ctx, "DELETE FROM chapters WHERE id = $1", id)
+// Delete moves the chapter to the trash.
+func (r *PostgresChapterRepository) Delete(ctx context.Context, id uuid.UUID, version int, revise repository.Revise[uuid.UUID]) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        UPDATE chapters SET deleted_at = now(), version = version + 1
+        WHERE id = $1 AND deleted_at IS NULL AND ` + versionCondition(2)
+	cmdTag, err := tx.Exec(ctx, query, id, version)
 	if err != nil {
 		return fmt.Errorf("failed to delete chapter: %w", err)
 	}
 	if cmdTag.RowsAffected() == 0 {
-		return repository.ErrChapterNotFound
+		return missingOrConflict(ctx, r.DB, "chapters", id, version, repository.ErrChapterNotFound)
 	}
-	return nil
+	if err := reviseInTx(ctx, tx, revise, id); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 func (r *PostgresChapterRepository) UpdatePages(ctx context.Context, id uuid.UUID, pages []string) error {
-	query := `UPDATE chapters SET pages = $1, updated_at = now() WHERE id = $2`
+	query := `UPDATE chapters SET pages = $1, version = version + 1, updated_at = now() WHERE id = $2 AND deleted_at IS NULL`
 	cmdTag, err := r.DB.Exec(ctx, query, pages, id)
 	if err != nil {
 		return fmt.Errorf("failed to update chapter pages: %w", err)
@@ -129,3 +247,37 @@ func (r *PostgresChapterRepository) UpdatePages(ctx context.Context, id uuid.UUI
 	}
 	return nil
 }
+
+// PublishDue publishes every scheduled chapter whose publish_at has passed and returns them.
+// The single UPDATE makes it safe to run from several workers at once.
+func (r *PostgresChapterRepository) PublishDue(ctx context.Context, now time.Time, revise repository.Revise[*domain.Chapter]) ([]*domain.Chapter, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        UPDATE chapters
+        SET publication_state = 'published', version = version + 1, updated_at = now()
+        WHERE publication_state = 'scheduled' AND publish_at <= $1 AND deleted_at IS NULL
+        RETURNING ` + chapterColumns
+
+	rows, err := tx.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish due chapters: %w", err)
+	}
+	chapters, err := scanChapters(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, chapter := range chapters {
+		if err := reviseInTx(ctx, tx, revise, chapter); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return chapters, nil
+}
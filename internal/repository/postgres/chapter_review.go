@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// submissionColumns lists the columns scanned by scanSubmission, in order, for queries on the
+// chapters table (not aliased, so that UPDATE ... RETURNING can use them too).
+const submissionColumns = chapterColumns + `,
+            (SELECT m.title FROM manga m WHERE m.id = chapters.manga_id),
+            (SELECT u.username FROM users u WHERE u.id = chapters.uploaded_by)`
+
+func scanSubmission(row pgx.Row) (*domain.ChapterSubmission, error) {
+	var submission domain.ChapterSubmission
+	chapter, err := scanChapter(row, &submission.MangaTitle, &submission.UploaderUsername)
+	if err != nil {
+		return nil, err
+	}
+	submission.Chapter = *chapter
+	return &submission, nil
+}
+
+// ListSubmissions lists submitted chapters, newest first unless params.OldestFirst is set.
+func (r *PostgresChapterRepository) ListSubmissions(ctx context.Context, params repository.ListSubmissionsParams) (*repository.Page[*domain.ChapterSubmission], error) {
+	where := `WHERE uploaded_by IS NOT NULL`
+	var args []interface{}
+	if params.UploadedBy != uuid.Nil {
+		args = append(args, params.UploadedBy)
+		where += fmt.Sprintf(" AND uploaded_by = $%d", len(args))
+	} else {
+		where += " AND deleted_at IS NULL"
+	}
+	if params.Status != "" {
+		args = append(args, params.Status)
+		where += fmt.Sprintf(" AND review_status = $%d", len(args))
+	}
+
+	var total int
+	if err := r.DB.QueryRow(ctx, `SELECT count(*) FROM chapters `+where, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count submitted chapters: %w", err)
+	}
+
+	sortName, order := "created_at:desc", "DESC"
+	if params.OldestFirst {
+		sortName, order = "created_at:asc", "ASC"
+	}
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor, sortName, "timestamptz")
+		if err != nil {
+			return nil, err
+		}
+		where += " AND " + keysetCondition("created_at", "id", "timestamptz", !params.OldestFirst, len(args)+1)
+		args = append(args, c.Key, c.ID)
+		params.Offset = 0
+	}
+
+	query := `SELECT ` + submissionColumns + ` FROM chapters ` + where + fmt.Sprintf(`
+        ORDER BY created_at %s, id %s
+        LIMIT $%d OFFSET $%d`, order, order, len(args)+1, len(args)+2)
+	args = append(args, params.Limit+1, params.Offset) // One more to see if there's a next page
+
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list submitted chapters: %w", err)
+	}
+	defer rows.Close()
+
+	var submissions []*domain.ChapterSubmission
+	for rows.Next() {
+		submission, err := scanSubmission(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan chapter row: %w", err)
+		}
+		submissions = append(submissions, submission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return newPage(submissions, params.Limit, total, func(s *domain.ChapterSubmission) cursor {
+		return cursor{Sort: sortName, Key: timeKey(s.CreatedAt), ID: s.ID}
+	}), nil
+}
+
+func (r *PostgresChapterRepository) Approve(ctx context.Context, id, reviewerID uuid.UUID, state domain.PublicationState, publishAt *time.Time) (*domain.ChapterSubmission, error) {
+	query := `
+        UPDATE chapters
+        SET review_status = 'approved', reviewed_by = $2, reviewed_at = now(),
+            publication_state = $3, publish_at = $4, version = version + 1, updated_at = now()
+        WHERE id = $1 AND review_status = 'pending' AND deleted_at IS NULL
+        RETURNING ` + submissionColumns
+
+	submission, err := scanSubmission(r.DB.QueryRow(ctx, query, id, reviewerID, state, publishAt))
+	if err != nil {
+		return nil, r.reviewFailed(ctx, id, err)
+	}
+	return submission, nil
+}
+
+// Reject moves the chapter to the trash, which frees its number for another upload.
+func (r *PostgresChapterRepository) Reject(ctx context.Context, id, reviewerID uuid.UUID, reason string) (*domain.ChapterSubmission, error) {
+	query := `
+        UPDATE chapters
+        SET review_status = 'rejected', reviewed_by = $2, reviewed_at = now(), rejection_reason = $3,
+            deleted_at = now(), version = version + 1, updated_at = now()
+        WHERE id = $1 AND review_status = 'pending' AND deleted_at IS NULL
+        RETURNING ` + submissionColumns
+
+	submission, err := scanSubmission(r.DB.QueryRow(ctx, query, id, reviewerID, reason))
+	if err != nil {
+		return nil, r.reviewFailed(ctx, id, err)
+	}
+	return submission, nil
+}
+
+// reviewFailed tells why a review updated no chapter: there is none, or it isn't pending.
+func (r *PostgresChapterRepository) reviewFailed(ctx context.Context, id uuid.UUID, err error) error {
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to review chapter: %w", err)
+	}
+	var exists bool
+	err = r.DB.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM chapters WHERE id = $1 AND deleted_at IS NULL)`, id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to review chapter: %w", err)
+	}
+	if !exists {
+		return repository.ErrChapterNotFound
+	}
+	return repository.ErrChapterReviewed
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// trashedAlone selects the chapters trashed on their own, while their manga is still live.
+// Those trashed along with their manga are restored and purged with it.
+const trashedAlone = `deleted_at IS NOT NULL AND manga_id IN (SELECT id FROM manga WHERE deleted_at IS NULL)`
+
+// ListDeleted lists the chapters in the trash, most recently deleted first.
+func (r *PostgresChapterRepository) ListDeleted(ctx context.Context, params repository.ListTrashParams) (*repository.Page[*domain.Chapter], error) {
+	where := `WHERE ` + trashedAlone
+	var args []interface{}
+
+	var total int
+	if err := r.DB.QueryRow(ctx, `SELECT count(*) FROM chapters `+where).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count deleted chapters: %w", err)
+	}
+
+	const sortName = "deleted_at:desc"
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor, sortName, "timestamptz")
+		if err != nil {
+			return nil, err
+		}
+		where += " AND " + keysetCondition("deleted_at", "id", "timestamptz", true, 1)
+		args = append(args, c.Key, c.ID)
+		params.Offset = 0
+	}
+
+	query := `SELECT ` + chapterColumns + ` FROM chapters ` + where + fmt.Sprintf(`
+        ORDER BY deleted_at DESC, id DESC
+        LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, params.Limit+1, params.Offset) // One more to see if there's a next page
+
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted chapters: %w", err)
+	}
+	chapters, err := scanChapters(rows)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(chapters, params.Limit, total, func(ch *domain.Chapter) cursor {
+		return cursor{Sort: sortName, Key: timeKey(*ch.DeletedAt), ID: ch.ID}
+	}), nil
+}
+
+// Restore takes the chapter out of the trash. It fails with ErrChapterAlreadyExists if its
+// number has been taken since.
+func (r *PostgresChapterRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `
+        UPDATE chapters SET deleted_at = NULL, version = version + 1, updated_at = now()
+        WHERE id = $1 AND ` + trashedAlone
+
+	cmdTag, err := r.DB.Exec(ctx, query, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return repository.ErrChapterAlreadyExists
+		}
+		return fmt.Errorf("failed to restore chapter: %w", err)
+	}
+	if cmdTag.RowsAffected() > 0 {
+		return nil
+	}
+
+	// Either there is no such chapter in the trash, or its manga is there too
+	var trashed bool
+	err = r.DB.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM chapters WHERE id = $1 AND deleted_at IS NOT NULL)`, id).Scan(&trashed)
+	if err != nil {
+		return fmt.Errorf("failed to find deleted chapter: %w", err)
+	}
+	if trashed {
+		return repository.ErrMangaNotFound
+	}
+	return repository.ErrChapterNotFound
+}
+
+// ListDeletedBefore lists the IDs of the chapters trashed on their own before the time.
+func (r *PostgresChapterRepository) ListDeletedBefore(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	query := `SELECT id FROM chapters WHERE ` + trashedAlone + ` AND deleted_at < $1 ORDER BY deleted_at`
+	rows, err := r.DB.Query(ctx, query, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted chapters: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+}
+
+func (r *PostgresChapterRepository) ListIDsByMangaID(ctx context.Context, mangaID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.DB.Query(ctx, `SELECT id FROM chapters WHERE manga_id = $1`, mangaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chapter ids: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+}
+
+// Purge deletes a trashed chapter for good, with its comments and reading progress.
+func (r *PostgresChapterRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	cmdTag, err := r.DB.Exec(ctx, `DELETE FROM chapters WHERE id = $1 AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to purge chapter: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrChapterNotFound
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// coverColumns lists the columns scanned by scanCover, in order.
+const coverColumns = `id, manga_id, url, thumbnail_url, volume, language, is_primary, created_at`
+
+type PostgresCoverRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresCoverRepository(db *pgxpool.Pool) *PostgresCoverRepository {
+	return &PostgresCoverRepository{DB: db}
+}
+
+func scanCover(row pgx.Row) (*domain.Cover, error) {
+	var cover domain.Cover
+	err := row.Scan(
+		&cover.ID, &cover.MangaID, &cover.URL, &cover.ThumbnailURL, &cover.Volume, &cover.Language,
+		&cover.IsPrimary, &cover.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &cover, nil
+}
+
+func (r *PostgresCoverRepository) Create(ctx context.Context, cover *domain.Cover) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the manga row so concurrent uploads agree on whether the manga already has a cover.
+	var first bool
+	err = tx.QueryRow(ctx, `SELECT NOT EXISTS (SELECT 1 FROM manga_covers WHERE manga_id = $1) FROM manga WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, cover.MangaID).
+		Scan(&first)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrMangaNotFound
+		}
+		return fmt.Errorf("failed to check existing covers: %w", err)
+	}
+
+	query := `
+        INSERT INTO manga_covers (manga_id, url, thumbnail_url, volume, language)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`
+	err = tx.QueryRow(ctx, query, cover.MangaID, cover.URL, cover.ThumbnailURL, cover.Volume, cover.Language).
+		Scan(&cover.ID, &cover.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create cover: %w", err)
+	}
+
+	if cover.IsPrimary || first {
+		if err := setPrimaryCover(ctx, tx, cover.MangaID, cover.ID); err != nil {
+			return err
+		}
+		cover.IsPrimary = true
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresCoverRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Cover, error) {
+	query := `SELECT ` + coverColumns + ` FROM manga_covers WHERE id = $1 AND manga_id IN (SELECT id FROM manga WHERE deleted_at IS NULL)`
+
+	cover, err := scanCover(r.DB.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrCoverNotFound
+		}
+		return nil, fmt.Errorf("failed to find cover by id: %w", err)
+	}
+	return cover, nil
+}
+
+// ListByMangaID returns the manga's covers, primary first, then by volume and newest.
+func (r *PostgresCoverRepository) ListByMangaID(ctx context.Context, mangaID uuid.UUID) ([]*domain.Cover, error) {
+	query := `
+        SELECT ` + coverColumns + `
+        FROM manga_covers
+        WHERE manga_id = $1 AND manga_id IN (SELECT id FROM manga WHERE deleted_at IS NULL)
+        ORDER BY is_primary DESC, volume NULLS FIRST, created_at DESC`
+
+	rows, err := r.DB.Query(ctx, query, mangaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list covers: %w", err)
+	}
+	defer rows.Close()
+
+	var covers []*domain.Cover
+	for rows.Next() {
+		cover, err := scanCover(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cover row: %w", err)
+		}
+		covers = append(covers, cover)
+	}
+	return covers, rows.Err()
+}
+
+func (r *PostgresCoverRepository) SetPrimary(ctx context.Context, mangaID, coverID uuid.UUID) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := setPrimaryCover(ctx, tx, mangaID, coverID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresCoverRepository) Delete(ctx context.Context, mangaID, coverID uuid.UUID) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var wasPrimary bool
+	err = tx.QueryRow(ctx, `DELETE FROM manga_covers WHERE id = $1 AND manga_id = $2 RETURNING is_primary`, coverID, mangaID).
+		Scan(&wasPrimary)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrCoverNotFound
+		}
+		return fmt.Errorf("failed to delete cover: %w", err)
+	}
+
+	if wasPrimary {
+		var nextID uuid.UUID
+		err = tx.QueryRow(ctx, `SELECT id FROM manga_covers WHERE manga_id = $1 ORDER BY created_at DESC LIMIT 1`, mangaID).Scan(&nextID)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			// That was the last cover.
+			if _, err := tx.Exec(ctx, `UPDATE manga SET cover_image_url = NULL, version = version + 1, updated_at = now() WHERE id = $1`, mangaID); err != nil {
+				return fmt.Errorf("failed to clear manga cover: %w", err)
+			}
+		case err != nil:
+			return fmt.Errorf("failed to find replacement cover: %w", err)
+		default:
+			if err := setPrimaryCover(ctx, tx, mangaID, nextID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// setPrimaryCover makes the cover the manga's only primary cover and mirrors its URL into manga.cover_image_url.
+func setPrimaryCover(ctx context.Context, tx pgx.Tx, mangaID, coverID uuid.UUID) error {
+	// Clear the old primary first, the unique index allows only one per manga.
+	_, err := tx.Exec(ctx, `UPDATE manga_covers SET is_primary = false WHERE manga_id = $1 AND is_primary AND id <> $2`, mangaID, coverID)
+	if err != nil {
+		return fmt.Errorf("failed to clear primary cover: %w", err)
+	}
+
+	var url string
+	err = tx.QueryRow(ctx, `UPDATE manga_covers SET is_primary = true WHERE id = $1 AND manga_id = $2 RETURNING url`, coverID, mangaID).Scan(&url)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrCoverNotFound
+		}
+		return fmt.Errorf("failed to set primary cover: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE manga SET cover_image_url = $1, version = version + 1, updated_at = now() WHERE id = $2`, url, mangaID); err != nil {
+		return fmt.Errorf("failed to update manga cover: %w", err)
+	}
+	return nil
+}
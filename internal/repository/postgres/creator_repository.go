@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresCreatorRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresCreatorRepository(db *pgxpool.Pool) *PostgresCreatorRepository {
+	return &PostgresCreatorRepository{DB: db}
+}
+
+func (r *PostgresCreatorRepository) Create(ctx context.Context, creator *domain.Creator) error {
+	query := `INSERT INTO creators (name) VALUES ($1) RETURNING id, created_at`
+	err := r.DB.QueryRow(ctx, query, creator.Name).Scan(&creator.ID, &creator.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return repository.ErrCreatorAlreadyExists
+		}
+		return fmt.Errorf("failed to create creator: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresCreatorRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Creator, error) {
+	query := `SELECT id, name, created_at FROM creators WHERE id = $1`
+
+	var creator domain.Creator
+	err := r.DB.QueryRow(ctx, query, id).Scan(&creator.ID, &creator.Name, &creator.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrCreatorNotFound
+		}
+		return nil, fmt.Errorf("failed to find creator by id: %w", err)
+	}
+	return &creator, nil
+}
+
+func (r *PostgresCreatorRepository) List(ctx context.Context, params repository.ListCreatorsParams) ([]*domain.Creator, error) {
+	query := `
+        SELECT id, name, created_at
+        FROM creators
+        WHERE $1 = '' OR name ILIKE '%' || $1 || '%'
+        ORDER BY name
+        LIMIT $2 OFFSET $3`
+
+	rows, err := r.DB.Query(ctx, query, params.Query, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list creators: %w", err)
+	}
+	defer rows.Close()
+
+	var creators []*domain.Creator
+	for rows.Next() {
+		var creator domain.Creator
+		if err := rows.Scan(&creator.ID, &creator.Name, &creator.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan creator row: %w", err)
+		}
+		creators = append(creators, &creator)
+	}
+	return creators, rows.Err()
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+)
+
+// A cursor marks the last item of a page by its sort key and ID. The next page starts right
+// after it (keyset pagination), which unlike OFFSET stays fast on deep pages. Clients get it
+// as an opaque string.
+type cursor struct {
+	Sort string    `json:"s"` // The order the cursor was made for, e.g. "title:asc"
+	Key  string    `json:"k"` // Sort key of the item, as text
+	ID   uuid.UUID `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c) // Can't fail for these field types
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor reads a cursor made for the given order, whose key is of the given SQL type.
+func decodeCursor(s, sort, keyType string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, repository.ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != sort || !validCursorKey(c.Key, keyType) {
+		return nil, repository.ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// validCursorKey checks a key before it is cast to its SQL type, so a tampered cursor is
+// reported as invalid rather than failing the query.
+func validCursorKey(key, keyType string) bool {
+	switch keyType {
+	case "timestamptz":
+		_, err := time.Parse(time.RFC3339Nano, key)
+		return err == nil
+	case "smallint":
+		_, err := strconv.ParseInt(key, 10, 16)
+		return err == nil
+	case "real":
+		_, err := strconv.ParseFloat(key, 32)
+		return err == nil
+	default:
+		return true
+	}
+}
+
+// timeKey formats a timestamp as a cursor key. Postgres keeps microseconds, which RFC 3339
+// preserves exactly.
+func timeKey(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// keysetCondition selects the rows after the cursor in an order by (keyExpr, idExpr), both
+// ascending or both descending. The key and ID are the arguments argID and argID+1.
+func keysetCondition(keyExpr, idExpr, keyType string, desc bool, argID int) string {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	return fmt.Sprintf("(%s, %s) %s ($%d::%s, $%d)", keyExpr, idExpr, op, argID, keyType, argID+1)
+}
+
+// newPage makes a page from items fetched with a limit one higher than the page size, the
+// extra item telling whether there is a next page.
+func newPage[T any](items []T, limit, total int, cursorFor func(T) cursor) *repository.Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	page := &repository.Page[T]{Items: items, Total: total}
+	if limit > 0 && len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = encodeCursor(cursorFor(page.Items[limit-1]))
+	}
+	return page
+}
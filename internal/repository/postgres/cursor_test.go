@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	id := uuid.New()
+	created := encodeCursor(cursor{Sort: "created_at:desc", Key: timeKey(time.Date(2024, 5, 1, 12, 0, 0, 123456000, time.UTC)), ID: id})
+
+	c, err := decodeCursor(created, "created_at:desc", "timestamptz")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-05-01T12:00:00.123456Z", c.Key)
+	assert.Equal(t, id, c.ID)
+
+	tests := []struct {
+		name    string
+		cursor  string
+		sort    string
+		keyType string
+	}{
+		{"another sort", created, "created_at:asc", "timestamptz"},
+		{"not base64", "not a cursor!", "created_at:desc", "timestamptz"},
+		{"not JSON", "bm90IGpzb24", "created_at:desc", "timestamptz"},
+		{"bad key", encodeCursor(cursor{Sort: "year:asc", Key: "1999; DROP TABLE manga", ID: id}), "year:asc", "smallint"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeCursor(tt.cursor, tt.sort, tt.keyType)
+			assert.ErrorIs(t, err, repository.ErrInvalidCursor)
+		})
+	}
+}
+
+func TestValidCursorKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		keyType string
+		want    bool
+	}{
+		{"2024-05-01T12:00:00.123456Z", "timestamptz", true},
+		{"2024-05-01", "timestamptz", false},
+		{"1999", "smallint", true},
+		{"40000", "smallint", false},
+		{"1999.5", "smallint", false},
+		{"0.75", "real", true},
+		{"NaN-ish", "real", false},
+		{"Berserk", "text", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, validCursorKey(tt.key, tt.keyType), "%s as %s", tt.key, tt.keyType)
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	tests := []struct {
+		desc bool
+		want string
+	}{
+		{false, "(m.title, m.id) > ($3::text, $4)"},
+		{true, "(m.title, m.id) < ($3::text, $4)"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, keysetCondition("m.title", "m.id", "text", tt.desc, 3))
+	}
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresJobRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresJobRepository(db *pgxpool.Pool) *PostgresJobRepository {
+	return &PostgresJobRepository{DB: db}
+}
+
+func (r *PostgresJobRepository) Create(ctx context.Context, job *domain.Job) error {
+	query := `
+        INSERT INTO jobs (type, status, payload, created_by)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at, updated_at`
+
+	err := r.DB.QueryRow(ctx, query, job.Type, job.Status, job.Payload, job.CreatedBy).Scan(
+		&job.ID,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	var job domain.Job
+	query := `SELECT id, type, status, payload, result, error, created_by, created_at, updated_at FROM jobs WHERE id = $1`
+
+	err := r.DB.QueryRow(ctx, query, id).Scan(
+		&job.ID, &job.Type, &job.Status, &job.Payload, &job.Result, &job.Error, &job.CreatedBy, &job.CreatedAt, &job.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to find job by id: %w", err)
+	}
+	return &job, nil
+}
+
+func (r *PostgresJobRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.JobStatus, result json.RawMessage, errMsg *string) error {
+	query := `UPDATE jobs SET status = $1, result = $2, error = $3, updated_at = now() WHERE id = $4`
+	cmdTag, err := r.DB.Exec(ctx, query, status, result, errMsg, id)
+	if err != nil {
+		return fmt.Errorf("failed to update job status: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrJobNotFound
+	}
+	return nil
+}
+
+func (r *PostgresJobRepository) Start(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `UPDATE jobs SET status = $1, updated_at = now() WHERE id = $2 AND status = $3`
+	cmdTag, err := r.DB.Exec(ctx, query, domain.JobStatusRunning, id, domain.JobStatusPending)
+	if err != nil {
+		return false, fmt.Errorf("failed to start job: %w", err)
+	}
+	return cmdTag.RowsAffected() == 1, nil
+}
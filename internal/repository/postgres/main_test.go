@@ -13,17 +13,21 @@ import (
 	"github.com/spf13/viper"
 )
 
+// testPool is nil when there is no test database, see requireTestDB.
 var testPool *pgxpool.Pool
 
 func TestMain(m *testing.M) {
-	// Load config from .env to get TEST_DB_URL
+	// Load config from .env, or the environment, to get TEST_DB_URL
 	viper.SetConfigFile("../../../.env")
+	viper.AutomaticEnv()
 	if err := viper.ReadInConfig(); err != nil {
-		log.Fatalf("Error reading .env file for tests: %s", err)
+		log.Printf("Error reading .env file for tests: %s", err)
 	}
 	testDbUrl := viper.GetString("TEST_DB_URL")
 	if testDbUrl == "" {
-		log.Fatal("TEST_DB_URL not set in .env file")
+		// The unit tests of the package don't need a database, so they still run
+		log.Print("TEST_DB_URL not set, skipping integration tests")
+		os.Exit(m.Run())
 	}
 
 	// Connect to the test database
@@ -53,3 +57,11 @@ func TestMain(m *testing.M) {
 
 	os.Exit(code)
 }
+
+// requireTestDB skips an integration test when there is no test database.
+func requireTestDB(t *testing.T) {
+	t.Helper()
+	if testPool == nil {
+		t.Skip("TEST_DB_URL not set")
+	}
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+)
+
+// Facets of manga searches. A filter on one of them is left out when counting that facet.
+const (
+	facetGenre         = "genre"
+	facetStatus        = "status"
+	facetContentRating = "content_rating"
+	facetYear          = "year"
+)
+
+// mangaFilters are the conditions of a manga search, each with the facet it filters on, if any.
+type mangaFilters struct {
+	conditions []string
+	facets     []string
+}
+
+func (f *mangaFilters) add(facet, condition string) {
+	f.conditions = append(f.conditions, condition)
+	f.facets = append(f.facets, facet)
+}
+
+// where joins the conditions into a WHERE clause, leaving out those on the skipped facet.
+func (f mangaFilters) where(skip string, extra ...string) string {
+	var conditions []string
+	for i, condition := range f.conditions {
+		if skip == "" || f.facets[i] != skip {
+			conditions = append(conditions, condition)
+		}
+	}
+	conditions = append(conditions, extra...)
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// countFacets counts the manga matching the filters by each facet in one query. Every argument
+// is used by at least three of the four parts, so all their types are known.
+func (r *PostgresMangaRepository) countFacets(ctx context.Context, filters mangaFilters, args []interface{}) (*domain.MangaFacets, error) {
+	query := `
+        SELECT '` + facetGenre + `', g.name, count(*)
+        FROM manga m
+        JOIN manga_genres mg ON mg.manga_id = m.id
+        JOIN genres g ON g.id = mg.genre_id` + filters.where(facetGenre) + `
+        GROUP BY g.name
+        UNION ALL
+        SELECT '` + facetStatus + `', m.status::text, count(*) FROM manga m` + filters.where(facetStatus) + `
+        GROUP BY m.status
+        UNION ALL
+        SELECT '` + facetContentRating + `', m.content_rating::text, count(*) FROM manga m` + filters.where(facetContentRating) + `
+        GROUP BY m.content_rating
+        UNION ALL
+        SELECT '` + facetYear + `', m.year::text, count(*) FROM manga m` + filters.where(facetYear, "m.year IS NOT NULL") + `
+        GROUP BY m.year`
+
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count manga facets: %w", err)
+	}
+	defer rows.Close()
+
+	facets := newMangaFacets()
+	for rows.Next() {
+		var facet, value string
+		var count int
+		if err := rows.Scan(&facet, &value, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan manga facet row: %w", err)
+		}
+		switch facet {
+		case facetGenre:
+			facets.Genres[value] = count
+		case facetStatus:
+			facets.Statuses[domain.MangaStatus(value)] = count
+		case facetContentRating:
+			facets.ContentRatings[domain.ContentRating(value)] = count
+		case facetYear:
+			year, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse manga facet year %q: %w", value, err)
+			}
+			facets.Years[year] = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return facets, nil
+}
+
+func newMangaFacets() *domain.MangaFacets {
+	return &domain.MangaFacets{
+		Genres:         map[string]int{},
+		Statuses:       map[domain.MangaStatus]int{},
+		ContentRatings: map[domain.ContentRating]int{},
+		Years:          map[int]int{},
+	}
+}
+
+// emptyMangaPage is the result of a search that can't match anything.
+func emptyMangaPage(params repository.ListMangaParams) *repository.MangaPage {
+	page := &repository.MangaPage{Page: *newPage[*domain.Manga](nil, params.Limit, 0, nil)}
+	if params.Facets {
+		page.Facets = newMangaFacets()
+	}
+	return page
+}
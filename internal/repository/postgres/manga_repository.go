@@ -4,15 +4,38 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/0xpanadol/manga/internal/domain"
 	"github.com/0xpanadol/manga/internal/repository"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// mangaColumns lists the columns scanned by scanManga, in order. It expects the manga table aliased as m.
+const mangaColumns = `
+            m.id, m.title, m.description, m.author, m.status, m.content_rating, m.cover_image_url,
+            m.demographic, m.year, m.original_language, m.last_volume, m.last_chapter,
+            m.official_url, m.mal_id, m.anilist_id, m.mangaupdates_id, m.search_language, m.external_id,
+            m.version, m.deleted_at, m.created_at, m.updated_at,
+            ARRAY(
+                SELECT g.name FROM manga_genres mg JOIN genres g ON mg.genre_id = g.id
+                WHERE mg.manga_id = m.id ORDER BY g.name
+            ) AS genres,
+            COALESCE((
+                SELECT json_agg(json_build_object('title', t.title, 'language', t.language) ORDER BY t.language, t.title)
+                FROM manga_titles t WHERE t.manga_id = m.id
+            ), '[]') AS alt_titles,
+            COALESCE((
+                SELECT json_agg(json_build_object('CreatorID', c.id, 'Name', c.name, 'Role', mc.role) ORDER BY mc.role, c.name)
+                FROM manga_creators mc JOIN creators c ON mc.creator_id = c.id WHERE mc.manga_id = m.id
+            ), '[]') AS creators`
+
 type PostgresMangaRepository struct {
 	DB *pgxpool.Pool
 }
@@ -21,8 +44,136 @@ func NewPostgresMangaRepository(db *pgxpool.Pool) *PostgresMangaRepository {
 	return &PostgresMangaRepository{DB: db}
 }
 
+// scanManga scans a row of mangaColumns, followed by any extra columns into extra.
+func scanManga(row pgx.Row, extra ...any) (*domain.Manga, error) {
+	var manga domain.Manga
+	dest := []any{
+		&manga.ID, &manga.Title, &manga.Description, &manga.Author, &manga.Status, &manga.ContentRating, &manga.CoverImageURL,
+		&manga.Demographic, &manga.Year, &manga.OriginalLanguage, &manga.LastVolume, &manga.LastChapter,
+		&manga.Links.OfficialURL, &manga.Links.MyAnimeListID, &manga.Links.AniListID, &manga.Links.MangaUpdatesID, &manga.SearchLanguage, &manga.ExternalID,
+		&manga.Version, &manga.DeletedAt, &manga.CreatedAt, &manga.UpdatedAt, &manga.Genres, &manga.AltTitles, &manga.Creators,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	manga.DisplayTitle = manga.Title
+	return &manga, nil
+}
+
+func scanMangas(rows pgx.Rows) ([]*domain.Manga, error) {
+	defer rows.Close()
+
+	var mangas []*domain.Manga
+	for rows.Next() {
+		manga, err := scanManga(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan manga row: %w", err)
+		}
+		mangas = append(mangas, manga)
+	}
+	return mangas, rows.Err()
+}
+
+// associateTags links the manga to the tags in manga.Genres, given by name or slug, and replaces
+// manga.Genres with their names. Tags that don't exist are reported with ErrUnknownTags.
+func associateTags(ctx context.Context, tx pgx.Tx, manga *domain.Manga) error {
+	if len(manga.Genres) == 0 {
+		return nil
+	}
+
+	rows, err := tx.Query(ctx, `SELECT id, name, slug FROM genres WHERE name = ANY($1) OR slug = ANY($1)`, manga.Genres)
+	if err != nil {
+		return fmt.Errorf("failed to look up genres: %w", err)
+	}
+	defer rows.Close()
+
+	found := make(map[string]bool)
+	var ids []uuid.UUID
+	var names []string
+	for rows.Next() {
+		var id uuid.UUID
+		var name, slug string
+		if err := rows.Scan(&id, &name, &slug); err != nil {
+			return fmt.Errorf("failed to scan genre row: %w", err)
+		}
+		found[name], found[slug] = true, true
+		ids = append(ids, id)
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to look up genres: %w", err)
+	}
+
+	var unknown []string
+	for _, genre := range manga.Genres {
+		if !found[genre] {
+			unknown = append(unknown, genre)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", repository.ErrUnknownTags, strings.Join(unknown, ", "))
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO manga_genres (manga_id, genre_id) SELECT $1, unnest($2::uuid[])`, manga.ID, ids)
+	if err != nil {
+		return fmt.Errorf("failed to associate genres: %w", err)
+	}
+	sort.Strings(names)
+	manga.Genres = names
+	return nil
+}
+
+// replaceCreators swaps the manga's credits for manga.Creators. Creators given only by name
+// are matched case-insensitively or created, and their IDs filled in.
+func replaceCreators(ctx context.Context, tx pgx.Tx, manga *domain.Manga) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM manga_creators WHERE manga_id = $1", manga.ID); err != nil {
+		return fmt.Errorf("failed to clear creators: %w", err)
+	}
+	for i, credit := range manga.Creators {
+		if credit.CreatorID == uuid.Nil {
+			// The no-op update makes RETURNING yield the existing row on conflict.
+			err := tx.QueryRow(ctx, `
+                INSERT INTO creators (name) VALUES ($1)
+                ON CONFLICT (lower(name)) DO UPDATE SET name = creators.name
+                RETURNING id, name`, credit.Name).Scan(&manga.Creators[i].CreatorID, &manga.Creators[i].Name)
+			if err != nil {
+				return fmt.Errorf("failed to find or create creator: %w", err)
+			}
+		}
+
+		_, err := tx.Exec(ctx, `
+            INSERT INTO manga_creators (manga_id, creator_id, role) VALUES ($1, $2, $3)
+            ON CONFLICT DO NOTHING`, manga.ID, manga.Creators[i].CreatorID, credit.Role)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+				return repository.ErrCreatorNotFound
+			}
+			return fmt.Errorf("failed to credit creator: %w", err)
+		}
+	}
+	return nil
+}
+
+// replaceAltTitles swaps the manga's alternative titles for manga.AltTitles.
+func replaceAltTitles(ctx context.Context, tx pgx.Tx, manga *domain.Manga) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM manga_titles WHERE manga_id = $1", manga.ID); err != nil {
+		return fmt.Errorf("failed to clear alternative titles: %w", err)
+	}
+	for _, title := range manga.AltTitles {
+		_, err := tx.Exec(ctx, `
+            INSERT INTO manga_titles (manga_id, title, language) VALUES ($1, $2, $3)
+            ON CONFLICT DO NOTHING`, manga.ID, title.Title, title.Language)
+		if err != nil {
+			return fmt.Errorf("failed to insert alternative title: %w", err)
+		}
+	}
+	return nil
+}
+
 // Create inserts a new manga and its genre associations into the database.
-func (r *PostgresMangaRepository) Create(ctx context.Context, manga *domain.Manga) error {
+func (r *PostgresMangaRepository) Create(ctx context.Context, manga *domain.Manga, revise repository.Revise[*domain.Manga]) error {
 	tx, err := r.DB.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -31,51 +182,55 @@ func (r *PostgresMangaRepository) Create(ctx context.Context, manga *domain.Mang
 
 	// 1. Insert into manga table
 	mangaQuery := `
-        INSERT INTO manga (title, description, author, status, cover_image_url)
-        VALUES ($1, $2, $3, $4, $5)
-        RETURNING id, created_at, updated_at`
-	err = tx.QueryRow(ctx, mangaQuery, manga.Title, manga.Description, manga.Author, manga.Status, manga.CoverImageURL).Scan(
+        INSERT INTO manga (
+            title, description, author, status, content_rating, cover_image_url,
+            demographic, year, original_language, last_volume, last_chapter,
+            official_url, mal_id, anilist_id, mangaupdates_id, search_language, external_id
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+        RETURNING id, version, created_at, updated_at`
+	err = tx.QueryRow(ctx, mangaQuery,
+		manga.Title, manga.Description, manga.Author, manga.Status, manga.ContentRating, manga.CoverImageURL,
+		manga.Demographic, manga.Year, manga.OriginalLanguage, manga.LastVolume, manga.LastChapter,
+		manga.Links.OfficialURL, manga.Links.MyAnimeListID, manga.Links.AniListID, manga.Links.MangaUpdatesID, manga.SearchLanguage, manga.ExternalID,
+	).Scan(
 		&manga.ID,
+		&manga.Version,
 		&manga.CreatedAt,
 		&manga.UpdatedAt,
 	)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation on an external ID
+			return repository.ErrExternalIDConflict
+		}
 		return fmt.Errorf("failed to insert manga: %w", err)
 	}
 
 	// 2. Associate genres
-	if len(manga.Genres) > 0 {
-		genreQuery := `
-            INSERT INTO manga_genres (manga_id, genre_id)
-            SELECT $1, id FROM genres WHERE name = ANY($2)`
-		_, err = tx.Exec(ctx, genreQuery, manga.ID, manga.Genres)
-		if err != nil {
-			return fmt.Errorf("failed to associate genres: %w", err)
-		}
+	if err := associateTags(ctx, tx, manga); err != nil {
+		return err
+	}
+
+	// 3. Alternative titles and credits
+	if err := replaceAltTitles(ctx, tx, manga); err != nil {
+		return err
+	}
+	if err := replaceCreators(ctx, tx, manga); err != nil {
+		return err
+	}
+	if err := reviseInTx(ctx, tx, revise, manga); err != nil {
+		return err
 	}
 
 	return tx.Commit(ctx)
 }
 
-// FindByID retrieves a manga and its genres by ID.
+// FindByID retrieves a manga with its genres, alternative titles and related manga by ID.
 func (r *PostgresMangaRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Manga, error) {
-	query := `
-        SELECT
-            m.id, m.title, m.description, m.author, m.status, m.cover_image_url,
-            m.created_at, m.updated_at,
-            COALESCE(array_agg(g.name) FILTER (WHERE g.name IS NOT NULL), '{}') AS genres
-        FROM manga m
-        LEFT JOIN manga_genres mg ON m.id = mg.manga_id
-        LEFT JOIN genres g ON mg.genre_id = g.id
-        WHERE m.id = $1
-        GROUP BY m.id`
-
-	var manga domain.Manga
-	err := r.DB.QueryRow(ctx, query, id).Scan(
-		&manga.ID, &manga.Title, &manga.Description, &manga.Author, &manga.Status, &manga.CoverImageURL,
-		&manga.CreatedAt, &manga.UpdatedAt, &manga.Genres,
-	)
+	query := `SELECT ` + mangaColumns + ` FROM manga m WHERE m.id = $1 AND m.deleted_at IS NULL`
 
+	manga, err := scanManga(r.DB.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, repository.ErrMangaNotFound
@@ -83,78 +238,291 @@ func (r *PostgresMangaRepository) FindByID(ctx context.Context, id uuid.UUID) (*
 		return nil, fmt.Errorf("failed to find manga by id: %w", err)
 	}
 
-	return &manga, nil
+	manga.Relations, err = r.listRelations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return manga, nil
 }
 
-// List retrieves a paginated and filtered list of manga.
-func (r *PostgresMangaRepository) List(ctx context.Context, params repository.ListMangaParams) ([]*domain.Manga, error) {
-	// Base query
+// FindByExternalID retrieves a manga by its external ID, without its related manga.
+func (r *PostgresMangaRepository) FindByExternalID(ctx context.Context, externalID string) (*domain.Manga, error) {
+	query := `SELECT ` + mangaColumns + ` FROM manga m WHERE m.external_id = $1 AND m.deleted_at IS NULL`
+
+	manga, err := scanManga(r.DB.QueryRow(ctx, query, externalID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrMangaNotFound
+		}
+		return nil, fmt.Errorf("failed to find manga by external id: %w", err)
+	}
+	return manga, nil
+}
+
+func (r *PostgresMangaRepository) listRelations(ctx context.Context, id uuid.UUID) ([]domain.MangaRelation, error) {
 	query := `
-        SELECT
-            m.id, m.title, m.description, m.author, m.status, m.cover_image_url,
-            m.created_at, m.updated_at,
-            COALESCE(array_agg(g.name) FILTER (WHERE g.name IS NOT NULL), '{}') AS genres
-        FROM manga m
-        LEFT JOIN manga_genres mg ON m.id = mg.manga_id
-        LEFT JOIN genres g ON mg.genre_id = g.id
-    `
-	var conditions []string
+        SELECT r.related_manga_id, rm.title, rm.content_rating, r.type
+        FROM manga_relations r
+        JOIN manga rm ON r.related_manga_id = rm.id
+        WHERE r.manga_id = $1 AND rm.deleted_at IS NULL
+        ORDER BY r.type, rm.title`
+
+	rows, err := r.DB.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list relations: %w", err)
+	}
+	defer rows.Close()
+
+	relations := []domain.MangaRelation{}
+	for rows.Next() {
+		var rel domain.MangaRelation
+		if err := rows.Scan(&rel.MangaID, &rel.Title, &rel.ContentRating, &rel.Type); err != nil {
+			return nil, fmt.Errorf("failed to scan relation row: %w", err)
+		}
+		relations = append(relations, rel)
+	}
+	return relations, rows.Err()
+}
+
+// SetRelation relates the two manga, replacing any relation between them. Both directions are
+// written by a single statement, so they can't get out of step.
+func (r *PostgresMangaRepository) SetRelation(ctx context.Context, mangaID, relatedID uuid.UUID, relationType domain.RelationType) error {
+	// Nothing is written unless both manga exist and aren't in the trash
+	query := `
+        WITH rel AS (
+            INSERT INTO manga_relations (manga_id, related_manga_id, type)
+            SELECT v.manga_id, v.related_manga_id, v.type
+            FROM (VALUES ($1::uuid, $2::uuid, $3::manga_relation_type), ($2, $1, $4)) v (manga_id, related_manga_id, type)
+            WHERE (SELECT count(*) FROM manga WHERE id IN ($1, $2) AND deleted_at IS NULL) = 2
+            ON CONFLICT (manga_id, related_manga_id) DO UPDATE SET type = EXCLUDED.type, created_at = now()
+            RETURNING manga_id
+        )
+        UPDATE manga SET version = version + 1, updated_at = now() WHERE id IN (SELECT manga_id FROM rel)`
+
+	cmdTag, err := r.DB.Exec(ctx, query, mangaID, relatedID, relationType, relationType.Inverse())
+	if err != nil {
+		return fmt.Errorf("failed to set relation: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrMangaNotFound
+	}
+	return nil
+}
+
+// DeleteRelation removes the relation between the two manga, in both directions.
+func (r *PostgresMangaRepository) DeleteRelation(ctx context.Context, mangaID, relatedID uuid.UUID) error {
+	query := `
+        WITH rel AS (
+            DELETE FROM manga_relations
+            WHERE (manga_id = $1 AND related_manga_id = $2) OR (manga_id = $2 AND related_manga_id = $1)
+            RETURNING manga_id
+        )
+        UPDATE manga SET version = version + 1, updated_at = now() WHERE id IN (SELECT manga_id FROM rel)`
+
+	cmdTag, err := r.DB.Exec(ctx, query, mangaID, relatedID)
+	if err != nil {
+		return fmt.Errorf("failed to delete relation: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrRelationNotFound
+	}
+	return nil
+}
+
+// List retrieves a paginated and filtered list of manga.
+func (r *PostgresMangaRepository) List(ctx context.Context, params repository.ListMangaParams) (*repository.MangaPage, error) {
+	var filters mangaFilters
 	var args []interface{}
 	argID := 1
 
-	// Filtering by Title (simple ILIKE search)
-	if params.SearchQuery != "" {
-		// We use plainto_tsquery because it's safer for user-provided input.
-		// It automatically handles spaces and basic formatting.
-		conditions = append(conditions, fmt.Sprintf("m.search_tsv @@ plainto_tsquery('english', $%d)", argID))
-		args = append(args, params.SearchQuery)
+	filters.add("", "m.deleted_at IS NULL")
+	if params.IDs != nil {
+		filters.add("", fmt.Sprintf("m.id = ANY($%d)", argID))
+		args = append(args, params.IDs)
 		argID++
 	}
 
+	// Searching titles and descriptions
+	relevance := ""
+	if params.SearchQuery != "" {
+		condition, expr, searchArgs := searchCondition(params.SearchQuery, params.SearchLanguage, argID)
+		filters.add("", condition)
+		relevance = expr
+		args = append(args, searchArgs...)
+		argID += len(searchArgs)
+	}
+
 	// Filtering by Status
 	if params.Status != "" {
-		conditions = append(conditions, fmt.Sprintf("m.status = $%d", argID))
+		filters.add(facetStatus, fmt.Sprintf("m.status = $%d", argID))
 		args = append(args, params.Status)
 		argID++
 	}
 
-	// Filtering by Genres (manga must have ALL specified genres)
-	if len(params.Genres) > 0 {
-		conditions = append(conditions, fmt.Sprintf(`(
-            SELECT count(g.id) FROM genres g
-            JOIN manga_genres mg_sub ON g.id = mg_sub.genre_id
-            WHERE mg_sub.manga_id = m.id AND g.name = ANY($%d)
-        ) = %d`, argID, len(params.Genres)))
-		args = append(args, params.Genres)
+	// Filtering by Content Rating
+	if params.ContentRatings != nil {
+		filters.add("", fmt.Sprintf("m.content_rating = ANY($%d::text[]::content_rating[])", argID))
+		args = append(args, params.ContentRatings)
+		argID++
+	}
+	if params.ContentRatingFilter != nil {
+		filters.add(facetContentRating, fmt.Sprintf("m.content_rating = ANY($%d::text[]::content_rating[])", argID))
+		args = append(args, params.ContentRatingFilter)
 		argID++
 	}
 
-	// Construct WHERE clause
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+	// Filtering by publication details
+	if params.Demographics != nil {
+		filters.add("", fmt.Sprintf("m.demographic = ANY($%d::text[]::manga_demographic[])", argID))
+		args = append(args, params.Demographics)
+		argID++
+	}
+	if params.YearFrom != 0 {
+		filters.add(facetYear, fmt.Sprintf("m.year >= $%d", argID))
+		args = append(args, params.YearFrom)
+		argID++
+	}
+	if params.YearTo != 0 {
+		filters.add(facetYear, fmt.Sprintf("m.year <= $%d", argID))
+		args = append(args, params.YearTo)
+		argID++
+	}
+	if params.OriginalLanguage != "" {
+		filters.add("", fmt.Sprintf("m.original_language = $%d", argID))
+		args = append(args, params.OriginalLanguage)
+		argID++
 	}
 
-	// Grouping is always needed for the genre aggregation
-	query += " GROUP BY m.id"
+	// Looking up by external IDs
+	if params.MyAnimeListID != 0 {
+		filters.add("", fmt.Sprintf("m.mal_id = $%d", argID))
+		args = append(args, params.MyAnimeListID)
+		argID++
+	}
+	if params.AniListID != 0 {
+		filters.add("", fmt.Sprintf("m.anilist_id = $%d", argID))
+		args = append(args, params.AniListID)
+		argID++
+	}
+	if params.MangaUpdatesID != "" {
+		filters.add("", fmt.Sprintf("m.mangaupdates_id = $%d", argID))
+		args = append(args, params.MangaUpdatesID)
+		argID++
+	}
 
-	// Sorting
-	if params.SortBy != "" {
-		// Whitelist sortable columns to prevent SQL injection
-		validSortBy := map[string]bool{"title": true, "created_at": true, "updated_at": true}
-		if validSortBy[params.SortBy] {
-			order := "ASC"
-			if strings.ToLower(params.SortOrder) == "desc" {
-				order = "DESC"
+	// Filtering by Creator
+	if params.CreatorID != uuid.Nil {
+		filters.add("", fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM manga_creators mc_sub WHERE mc_sub.manga_id = m.id AND mc_sub.creator_id = $%d)", argID))
+		args = append(args, params.CreatorID)
+		argID++
+	}
+
+	// Filtering by Genres. Tags are resolved to IDs first so the filters below are
+	// plain set operations on manga_genres, served by its two indexes.
+	if len(params.Genres) > 0 {
+		ids, unknown, err := r.resolveTagIDs(ctx, params.Genres)
+		if err != nil {
+			return nil, err
+		}
+		if params.GenreMode == repository.TagModeAny {
+			if len(ids) == 0 {
+				return emptyMangaPage(params), nil // No manga has any of these tags
+			}
+			// Picking another genre widens the results, so they are counted without this filter
+			filters.add(facetGenre, fmt.Sprintf(
+				"m.id IN (SELECT manga_id FROM manga_genres WHERE genre_id = ANY($%d))", argID))
+		} else {
+			if unknown {
+				return emptyMangaPage(params), nil // No manga has a tag that does not exist
 			}
-			query += fmt.Sprintf(" ORDER BY m.%s %s", params.SortBy, order)
+			filters.add("", fmt.Sprintf(
+				"m.id IN (SELECT manga_id FROM manga_genres WHERE genre_id = ANY($%d) GROUP BY manga_id HAVING count(*) = %d)",
+				argID, len(ids)))
+		}
+		args = append(args, ids)
+		argID++
+	}
+
+	// Excluding Genres. Unknown tags exclude nothing.
+	if len(params.ExcludedGenres) > 0 {
+		ids, unknown, err := r.resolveTagIDs(ctx, params.ExcludedGenres)
+		if err != nil {
+			return nil, err
+		}
+		if params.ExcludedGenreMode == repository.TagModeAll {
+			if !unknown {
+				filters.add("", fmt.Sprintf(
+					"m.id NOT IN (SELECT manga_id FROM manga_genres WHERE genre_id = ANY($%d) GROUP BY manga_id HAVING count(*) = %d)",
+					argID, len(ids)))
+				args = append(args, ids)
+				argID++
+			}
+		} else if len(ids) > 0 {
+			filters.add("", fmt.Sprintf(
+				"NOT EXISTS (SELECT 1 FROM manga_genres mg_sub WHERE mg_sub.manga_id = m.id AND mg_sub.genre_id = ANY($%d))", argID))
+			args = append(args, ids)
+			argID++
+		}
+	}
+
+	// Count the matches on all pages
+	where := filters.where("")
+	var total int
+	if err := r.DB.QueryRow(ctx, `SELECT count(*) FROM manga m`+where, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count manga: %w", err)
+	}
+
+	var facets *domain.MangaFacets
+	if params.Facets {
+		var err error
+		if facets, err = r.countFacets(ctx, filters, args); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sorting, with the ID breaking ties so the order is stable across pages
+	desc := strings.ToLower(params.SortOrder) == "desc"
+	sortBy := params.SortBy
+	key, ok := mangaSortKeys[sortBy]
+	if sortBy == repository.SortByRelevance || (sortBy == "" && relevance != "") {
+		// Searches default to the best matches first. The relevance is computed by the query,
+		// so it is selected for the cursor.
+		sortBy, desc = repository.SortByRelevance, true
+		key, ok = mangaSortKey{expr: func(bool) string { return relevance }, keyType: "real"}, relevance != ""
+	}
+	if !ok {
+		sortBy, desc = "created_at", true // Default sort
+		key = mangaSortKeys[sortBy]
+	}
+	keyExpr := key.expr(desc)
+	order := "ASC"
+	if desc {
+		order = "DESC"
+	}
+	sortName := sortBy + ":" + strings.ToLower(order)
+
+	// Pagination: after the cursor if there is one, otherwise by offset
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor, sortName, key.keyType)
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		query += " ORDER BY m.created_at DESC" // Default sort
+		filters.add("", keysetCondition(keyExpr, "m.id", key.keyType, desc, argID))
+		where = filters.where("")
+		args = append(args, c.Key, c.ID)
+		argID += 2
+		params.Offset = 0
 	}
 
-	// Pagination
+	selectKey := ""
+	if key.value == nil {
+		selectKey = ", (" + keyExpr + ")::text"
+	}
+	query := `SELECT ` + mangaColumns + selectKey + ` FROM manga m` + where
+	query += fmt.Sprintf(" ORDER BY %s %s, m.id %s", keyExpr, order, order)
 	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argID, argID+1)
-	args = append(args, params.Limit, params.Offset)
+	args = append(args, params.Limit+1, params.Offset) // One more to see if there's a next page
 
 	rows, err := r.DB.Query(ctx, query, args...)
 	if err != nil {
@@ -163,38 +531,150 @@ func (r *PostgresMangaRepository) List(ctx context.Context, params repository.Li
 	defer rows.Close()
 
 	var mangas []*domain.Manga
+	sortKeys := make(map[uuid.UUID]string)
 	for rows.Next() {
-		var manga domain.Manga
-		if err := rows.Scan(
-			&manga.ID, &manga.Title, &manga.Description, &manga.Author, &manga.Status, &manga.CoverImageURL,
-			&manga.CreatedAt, &manga.UpdatedAt, &manga.Genres,
-		); err != nil {
+		var sortKey string
+		var extra []any
+		if key.value == nil {
+			extra = append(extra, &sortKey)
+		}
+		manga, err := scanManga(rows, extra...)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan manga row: %w", err)
 		}
-		mangas = append(mangas, &manga)
+		mangas = append(mangas, manga)
+		sortKeys[manga.ID] = sortKey
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	page := newPage(mangas, params.Limit, total, func(m *domain.Manga) cursor {
+		if key.value == nil {
+			return cursor{Sort: sortName, Key: sortKeys[m.ID], ID: m.ID}
+		}
+		return cursor{Sort: sortName, Key: key.value(m, desc), ID: m.ID}
+	})
+	return &repository.MangaPage{Page: *page, Facets: facets}, nil
+}
+
+// mangaSortKey is an order manga can be listed in.
+type mangaSortKey struct {
+	expr    func(desc bool) string                  // SQL expression sorted on
+	keyType string                                  // Its SQL type
+	value   func(m *domain.Manga, desc bool) string // Cursor key of a manga; nil to use the expression as selected
+}
+
+// missingYear is the key that sorts manga without a year last in either direction.
+func missingYear(desc bool) string {
+	if desc {
+		return "-1"
 	}
+	return "32767" // smallint max
+}
+
+// mangaSortKeys whitelists the sortable columns, which keeps SortBy out of the SQL.
+var mangaSortKeys = map[string]mangaSortKey{
+	"title": {
+		expr:    func(bool) string { return "m.title" },
+		keyType: "text",
+		value:   func(m *domain.Manga, _ bool) string { return m.Title },
+	},
+	"year": {
+		expr:    func(desc bool) string { return "COALESCE(m.year, " + missingYear(desc) + ")" },
+		keyType: "smallint",
+		value: func(m *domain.Manga, desc bool) string {
+			if m.Year == nil {
+				return missingYear(desc)
+			}
+			return strconv.Itoa(*m.Year)
+		},
+	},
+	"created_at": {
+		expr:    func(bool) string { return "m.created_at" },
+		keyType: "timestamptz",
+		value:   func(m *domain.Manga, _ bool) string { return timeKey(m.CreatedAt) },
+	},
+	"updated_at": {
+		expr:    func(bool) string { return "m.updated_at" },
+		keyType: "timestamptz",
+		value:   func(m *domain.Manga, _ bool) string { return timeKey(m.UpdatedAt) },
+	},
+}
 
-	return mangas, nil
+// resolveTagIDs returns the distinct IDs of the tags with the given names or slugs,
+// and whether any of them matched no tag.
+func (r *PostgresMangaRepository) resolveTagIDs(ctx context.Context, tags []string) ([]uuid.UUID, bool, error) {
+	rows, err := r.DB.Query(ctx, `
+        SELECT t.tag, g.id
+        FROM unnest($1::text[]) AS t(tag)
+        LEFT JOIN genres g ON g.name = t.tag OR g.slug = t.tag`, tags)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve tags: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	unknown := false
+	for rows.Next() {
+		var tag string
+		var id *uuid.UUID
+		if err := rows.Scan(&tag, &id); err != nil {
+			return nil, false, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		if id == nil {
+			unknown = true
+			continue
+		}
+		if !seen[*id] {
+			seen[*id] = true
+			ids = append(ids, *id)
+		}
+	}
+	return ids, unknown, rows.Err()
 }
 
 // Update modifies an existing manga's details and genre associations.
-func (r *PostgresMangaRepository) Update(ctx context.Context, manga *domain.Manga) error {
+func (r *PostgresMangaRepository) Update(ctx context.Context, manga *domain.Manga, revise repository.Revise[*domain.Manga]) error {
 	tx, err := r.DB.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	// 1. Update the manga table
+	// 1. Update the manga table. The cover image is managed through the manga's covers.
+	// An empty content rating or search language keeps the current one.
 	mangaQuery := `
         UPDATE manga
-        SET title = $1, description = $2, author = $3, status = $4, cover_image_url = $5, updated_at = now()
-        WHERE id = $6
-        RETURNING updated_at`
-	err = tx.QueryRow(ctx, mangaQuery, manga.Title, manga.Description, manga.Author, manga.Status, manga.CoverImageURL, manga.ID).Scan(&manga.UpdatedAt)
+        SET title = $1, description = $2, author = $3, status = $4,
+            content_rating = COALESCE(NULLIF($5, '')::content_rating, content_rating),
+            demographic = $6, year = $7, original_language = $8, last_volume = $9, last_chapter = $10,
+            official_url = $11, mal_id = $12, anilist_id = $13, mangaupdates_id = $14,
+            search_language = COALESCE(NULLIF($15, ''), search_language),
+            version = version + 1, updated_at = now()
+        WHERE id = $16 AND deleted_at IS NULL AND ` + versionCondition(17) + `
+        RETURNING content_rating, search_language, cover_image_url, version, created_at, updated_at`
+	err = tx.QueryRow(ctx, mangaQuery,
+		manga.Title, manga.Description, manga.Author, manga.Status, string(manga.ContentRating),
+		manga.Demographic, manga.Year, manga.OriginalLanguage, manga.LastVolume, manga.LastChapter,
+		manga.Links.OfficialURL, manga.Links.MyAnimeListID, manga.Links.AniListID, manga.Links.MangaUpdatesID,
+		manga.SearchLanguage, manga.ID, manga.Version,
+	).Scan(
+		&manga.ContentRating,
+		&manga.SearchLanguage,
+		&manga.CoverImageURL,
+		&manga.Version,
+		&manga.CreatedAt,
+		&manga.UpdatedAt,
+	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return repository.ErrMangaNotFound
+			return missingOrConflict(ctx, r.DB, "manga", manga.ID, manga.Version, repository.ErrMangaNotFound)
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return repository.ErrExternalIDConflict
 		}
 		return fmt.Errorf("failed to update manga: %w", err)
 	}
@@ -206,28 +686,53 @@ func (r *PostgresMangaRepository) Update(ctx context.Context, manga *domain.Mang
 	}
 
 	// 3. Add the new genre associations
-	if len(manga.Genres) > 0 {
-		genreQuery := `
-            INSERT INTO manga_genres (manga_id, genre_id)
-            SELECT $1, id FROM genres WHERE name = ANY($2)`
-		_, err = tx.Exec(ctx, genreQuery, manga.ID, manga.Genres)
-		if err != nil {
-			return fmt.Errorf("failed to associate new genres: %w", err)
-		}
+	if err := associateTags(ctx, tx, manga); err != nil {
+		return err
+	}
+
+	// 4. Replace the alternative titles and credits
+	if err := replaceAltTitles(ctx, tx, manga); err != nil {
+		return err
+	}
+	if err := replaceCreators(ctx, tx, manga); err != nil {
+		return err
+	}
+	if err := reviseInTx(ctx, tx, revise, manga); err != nil {
+		return err
 	}
 
 	return tx.Commit(ctx)
 }
 
-// Delete removes a manga from the database.
-func (r *PostgresMangaRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := "DELETE FROM manga WHERE id = $1"
-	cmdTag, err := r.DB.Exec(ctx, query, id)
+// Delete moves the manga to the trash, along with its chapters that aren't there yet.
+func (r *PostgresMangaRepository) Delete(ctx context.Context, id uuid.UUID, version int, revise repository.Revise[uuid.UUID]) error {
+	tx, err := r.DB.Begin(ctx)
 	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+        UPDATE manga SET deleted_at = now(), version = version + 1
+        WHERE id = $1 AND deleted_at IS NULL AND ` + versionCondition(2) + `
+        RETURNING deleted_at`
+	var deletedAt time.Time
+	if err := tx.QueryRow(ctx, query, id, version).Scan(&deletedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return missingOrConflict(ctx, r.DB, "manga", id, version, repository.ErrMangaNotFound)
+		}
 		return fmt.Errorf("failed to delete manga: %w", err)
 	}
-	if cmdTag.RowsAffected() == 0 {
-		return repository.ErrMangaNotFound
+
+	// The chapters share the manga's deleted_at, which tells them apart from those trashed before
+	_, err = tx.Exec(ctx, `
+        UPDATE chapters SET deleted_at = $2, version = version + 1
+        WHERE manga_id = $1 AND deleted_at IS NULL`, id, deletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete chapters of manga: %w", err)
 	}
-	return nil
+	if err := reviseInTx(ctx, tx, revise, id); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
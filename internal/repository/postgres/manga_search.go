@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/0xpanadol/manga/internal/domain"
+)
+
+// prefixTSQuery turns a search into a to_tsquery expression matching every word as a prefix:
+// "one pi" -> 'one':* & 'pi':*. Everything but letters and digits separates words, so the input
+// can't inject tsquery operators.
+func prefixTSQuery(search string) string {
+	words := strings.FieldsFunc(search, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = "'" + word + "':*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// searchCondition matches manga by full-text search on words and word prefixes, falling back
+// to trigram similarity of the main and alternative titles for typos. It also returns the
+// relevance expression, the text rank plus the best title similarity, and the arguments of
+// both, numbered from argID.
+//
+// Words are normalized for the search language, and also taken as they are to match manga
+// indexed without a language-specific configuration (see manga_search_config).
+func searchCondition(search, language string, argID int) (condition, relevance string, args []interface{}) {
+	similarity := fmt.Sprintf(`GREATEST(word_similarity($%[1]d, m.title),
+        COALESCE((SELECT max(word_similarity($%[1]d, t.title)) FROM manga_titles t WHERE t.manga_id = m.id), 0))`, argID)
+	condition = fmt.Sprintf(`($%[1]d <%% m.title OR EXISTS (SELECT 1 FROM manga_titles t WHERE t.manga_id = m.id AND $%[1]d <%% t.title))`, argID)
+	relevance = similarity
+	args = []interface{}{search}
+
+	// A search without words, e.g. only punctuation, is left to similarity
+	if tsquery := prefixTSQuery(search); tsquery != "" {
+		if language == "" {
+			language = domain.DefaultSearchLanguage
+		}
+		fullText := fmt.Sprintf("(to_tsquery(manga_search_config($%[1]d), $%[2]d) || to_tsquery('simple', $%[2]d))", argID+1, argID+2)
+		condition = fmt.Sprintf("(m.search_tsv @@ %s OR %s)", fullText, condition)
+		relevance = fmt.Sprintf("(ts_rank(m.search_tsv, %s) + %s)", fullText, similarity)
+		args = append(args, language, tsquery)
+	}
+	return condition, relevance, args
+}
+
+// Autocomplete suggests manga whose main or alternative title starts with, or resembles, the
+// search. Each manga is suggested once, by its best matching title; prefix matches come first.
+func (r *PostgresMangaRepository) Autocomplete(ctx context.Context, search string, ratings []domain.ContentRating, limit int) ([]*domain.MangaSuggestion, error) {
+	query := `
+        SELECT manga_id, title FROM (
+            SELECT DISTINCT ON (m.id) m.id AS manga_id, s.title,
+                s.title ILIKE $2 AS prefix, word_similarity($1, s.title) AS score
+            FROM manga m
+            JOIN (
+                SELECT id AS manga_id, title FROM manga
+                UNION ALL
+                SELECT manga_id, title FROM manga_titles
+            ) s ON s.manga_id = m.id
+            WHERE (s.title ILIKE $2 OR $1 <% s.title) AND m.content_rating = ANY($3::text[]::content_rating[])
+                AND m.deleted_at IS NULL
+            ORDER BY m.id, prefix DESC, score DESC
+        ) best
+        ORDER BY prefix DESC, score DESC, title
+        LIMIT $4`
+
+	rows, err := r.DB.Query(ctx, query, search, escapeLike(search)+"%", ratings, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to autocomplete manga: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := []*domain.MangaSuggestion{}
+	for rows.Next() {
+		var suggestion domain.MangaSuggestion
+		if err := rows.Scan(&suggestion.MangaID, &suggestion.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan manga suggestion row: %w", err)
+		}
+		suggestions = append(suggestions, &suggestion)
+	}
+	return suggestions, rows.Err()
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixTSQuery(t *testing.T) {
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{"one pi", "'one':* & 'pi':*"},
+		{"  Berserk  ", "'Berserk':*"},
+		{"Re:Zero 2", "'Re':* & 'Zero':* & '2':*"},
+		{"ベルセルク", "'ベルセルク':*"},
+		{"a' | !b & c:*", "'a':* & 'b':* & 'c':*"}, // tsquery operators and quotes only separate words
+		{"!?-", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, prefixTSQuery(tt.search), tt.search)
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"Berserk", "Berserk"},
+		{"100%", `100\%`},
+		{"a_b", `a\_b`},
+		{`C:\manga`, `C:\\manga`},
+		{`%_\`, `\%\_\\`},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, escapeLike(tt.s), tt.s)
+	}
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ListDeleted lists the manga in the trash, most recently deleted first.
+func (r *PostgresMangaRepository) ListDeleted(ctx context.Context, params repository.ListTrashParams) (*repository.Page[*domain.Manga], error) {
+	where := " WHERE m.deleted_at IS NOT NULL"
+	var args []interface{}
+
+	var total int
+	if err := r.DB.QueryRow(ctx, `SELECT count(*) FROM manga m`+where).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count deleted manga: %w", err)
+	}
+
+	const sortName = "deleted_at:desc"
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor, sortName, "timestamptz")
+		if err != nil {
+			return nil, err
+		}
+		where += " AND " + keysetCondition("m.deleted_at", "m.id", "timestamptz", true, 1)
+		args = append(args, c.Key, c.ID)
+		params.Offset = 0
+	}
+
+	query := `SELECT ` + mangaColumns + ` FROM manga m` + where + fmt.Sprintf(`
+        ORDER BY m.deleted_at DESC, m.id DESC
+        LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, params.Limit+1, params.Offset) // One more to see if there's a next page
+
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted manga: %w", err)
+	}
+	mangas, err := scanMangas(rows)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(mangas, params.Limit, total, func(m *domain.Manga) cursor {
+		return cursor{Sort: sortName, Key: timeKey(*m.DeletedAt), ID: m.ID}
+	}), nil
+}
+
+// Restore takes the manga out of the trash, with the chapters that were trashed along with it.
+func (r *PostgresMangaRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var deletedAt time.Time
+	err = tx.QueryRow(ctx, `SELECT deleted_at FROM manga WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE`, id).Scan(&deletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrMangaNotFound
+		}
+		return fmt.Errorf("failed to find deleted manga: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE manga SET deleted_at = NULL, version = version + 1, updated_at = now() WHERE id = $1`, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return repository.ErrExternalIDConflict // Taken by another manga in the meantime
+		}
+		return fmt.Errorf("failed to restore manga: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+        UPDATE chapters SET deleted_at = NULL, version = version + 1
+        WHERE manga_id = $1 AND deleted_at = $2`, id, deletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to restore chapters of manga: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// ListDeletedBefore lists the IDs of the manga trashed before the time.
+func (r *PostgresMangaRepository) ListDeletedBefore(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	rows, err := r.DB.Query(ctx, `SELECT id FROM manga WHERE deleted_at < $1 ORDER BY deleted_at`, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted manga: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+}
+
+// Purge deletes a trashed manga for good. Its chapters, covers, comments, favorites and reading
+// progress go with it through the foreign keys.
+func (r *PostgresMangaRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	cmdTag, err := r.DB.Exec(ctx, `DELETE FROM manga WHERE id = $1 AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to purge manga: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrMangaNotFound
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// revisionColumns lists the columns scanned by scanRevision, in order. Queries join users as u.
+const revisionColumns = `r.id, r.manga_id, r.chapter_id, r.action, r.actor_id, u.username, r.changes, r.snapshot, r.reverted_from, r.created_at`
+
+type PostgresRevisionRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresRevisionRepository(db *pgxpool.Pool) *PostgresRevisionRepository {
+	return &PostgresRevisionRepository{DB: db}
+}
+
+func scanRevision(row pgx.Row) (*domain.Revision, error) {
+	var revision domain.Revision
+	err := row.Scan(
+		&revision.ID, &revision.MangaID, &revision.ChapterID, &revision.Action, &revision.ActorID, &revision.ActorUsername,
+		&revision.Changes, &revision.Snapshot, &revision.RevertedFrom, &revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *PostgresRevisionRepository) Create(ctx context.Context, revision *domain.Revision) error {
+	return insertRevision(ctx, r.DB, revision)
+}
+
+// queryRower is a pool or a transaction.
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func insertRevision(ctx context.Context, db queryRower, revision *domain.Revision) error {
+	query := `
+        INSERT INTO revisions (manga_id, chapter_id, action, actor_id, changes, snapshot, reverted_from)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at`
+
+	var snapshot any // Stored as NULL for deletes
+	if revision.Snapshot != nil {
+		snapshot = revision.Snapshot
+	}
+	err := db.QueryRow(ctx, query,
+		revision.MangaID, revision.ChapterID, revision.Action, revision.ActorID, revision.Changes, snapshot, revision.RevertedFrom,
+	).Scan(&revision.ID, &revision.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create revision: %w", err)
+	}
+	return nil
+}
+
+// reviseInTx records the revision of a change saved in the transaction, if there is one.
+func reviseInTx[T any](ctx context.Context, tx pgx.Tx, revise repository.Revise[T], saved T) error {
+	if revise == nil {
+		return nil
+	}
+	revision, err := revise(saved)
+	if err != nil || revision == nil {
+		return err
+	}
+	return insertRevision(ctx, tx, revision)
+}
+
+func (r *PostgresRevisionRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Revision, error) {
+	query := `SELECT ` + revisionColumns + ` FROM revisions r LEFT JOIN users u ON r.actor_id = u.id WHERE r.id = $1`
+
+	revision, err := scanRevision(r.DB.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to find revision by id: %w", err)
+	}
+	return revision, nil
+}
+
+// ListByMangaID lists the revisions of a manga and its chapters, newest first.
+func (r *PostgresRevisionRepository) ListByMangaID(ctx context.Context, params repository.ListRevisionsParams) (*repository.Page[*domain.Revision], error) {
+	where := ` WHERE r.manga_id = $1`
+	args := []interface{}{params.MangaID}
+
+	var total int
+	if err := r.DB.QueryRow(ctx, `SELECT count(*) FROM revisions r`+where, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count revisions: %w", err)
+	}
+
+	const sortName = "created_at:desc"
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor, sortName, "timestamptz")
+		if err != nil {
+			return nil, err
+		}
+		where += " AND " + keysetCondition("r.created_at", "r.id", "timestamptz", true, 2)
+		args = append(args, c.Key, c.ID)
+		params.Offset = 0
+	}
+
+	query := `SELECT ` + revisionColumns + ` FROM revisions r LEFT JOIN users u ON r.actor_id = u.id` + where + fmt.Sprintf(`
+        ORDER BY r.created_at DESC, r.id DESC
+        LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, params.Limit+1, params.Offset) // One more to see if there's a next page
+
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*domain.Revision
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan revision row: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return newPage(revisions, params.Limit, total, func(rev *domain.Revision) cursor {
+		return cursor{Sort: sortName, Key: timeKey(rev.CreatedAt), ID: rev.ID}
+	}), nil
+}
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/0xpanadol/manga/internal/domain"
 	"github.com/0xpanadol/manga/internal/repository"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool" // Use pgxpool
 )
 
@@ -38,34 +40,57 @@ func (r *PostgresSocialRepository) ToggleFavorite(ctx context.Context, userID, m
 		return &repository.ToggleFavoriteResult{IsFavorited: false}, nil
 	}
 
-	// If no rows were deleted, it means it wasn't a favorite. So, we insert.
-	insertQuery := "INSERT INTO user_favorites (user_id, manga_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
-	_, err = r.DB.Exec(ctx, insertQuery, userID, mangaID)
+	// If no rows were deleted, it means it wasn't a favorite. So, we insert, unless the manga
+	// is missing or in the trash.
+	insertQuery := `
+        INSERT INTO user_favorites (user_id, manga_id)
+        SELECT $1::uuid, id FROM manga WHERE id = $2 AND deleted_at IS NULL
+        ON CONFLICT DO NOTHING`
+	cmdTag, err = r.DB.Exec(ctx, insertQuery, userID, mangaID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert into favorites: %w", err)
 	}
+	if cmdTag.RowsAffected() == 0 {
+		return nil, repository.ErrMangaNotFound
+	}
 
 	return &repository.ToggleFavoriteResult{IsFavorited: true}, nil
 }
 
-func (r *PostgresSocialRepository) ListFavorites(ctx context.Context, userID uuid.UUID, params repository.ListMangaParams) ([]*domain.Manga, error) {
-	query := `
-        SELECT
-            m.id, m.title, m.description, m.author, m.status, m.cover_image_url,
-            m.created_at, m.updated_at,
-            COALESCE(array_agg(g.name) FILTER (WHERE g.name IS NOT NULL), '{}') AS genres
-        FROM manga m
-        JOIN user_favorites uf ON m.id = uf.manga_id
-        LEFT JOIN manga_genres mg ON m.id = mg.manga_id
-        LEFT JOIN genres g ON mg.genre_id = g.id
-        WHERE uf.user_id = $1
-    `
+// ListFavorites lists the user's favorite manga, most recently favorited first.
+func (r *PostgresSocialRepository) ListFavorites(ctx context.Context, userID uuid.UUID, params repository.ListMangaParams) (*repository.Page[*domain.Manga], error) {
+	where := " WHERE uf.user_id = $1 AND m.deleted_at IS NULL"
 	args := []interface{}{userID}
-	argID := 2
 
-	query += " GROUP BY m.id, uf.created_at ORDER BY uf.created_at DESC"
-	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argID, argID+1)
-	args = append(args, params.Limit, params.Offset)
+	if params.ContentRatings != nil {
+		where += " AND m.content_rating = ANY($2::text[]::content_rating[])"
+		args = append(args, params.ContentRatings)
+	}
+
+	var total int
+	countQuery := "SELECT count(*) FROM manga m JOIN user_favorites uf ON m.id = uf.manga_id" + where
+	if err := r.DB.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count favorite manga: %w", err)
+	}
+
+	const sortName = "favorited_at:desc"
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor, sortName, "timestamptz")
+		if err != nil {
+			return nil, err
+		}
+		where += " AND " + keysetCondition("uf.created_at", "m.id", "timestamptz", true, len(args)+1)
+		args = append(args, c.Key, c.ID)
+		params.Offset = 0
+	}
+
+	query := `
+        SELECT ` + mangaColumns + `, uf.created_at
+        FROM manga m
+        JOIN user_favorites uf ON m.id = uf.manga_id` + where + fmt.Sprintf(`
+        ORDER BY uf.created_at DESC, m.id DESC
+        LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, params.Limit+1, params.Offset) // One more to see if there's a next page
 
 	rows, err := r.DB.Query(ctx, query, args...)
 	if err != nil {
@@ -74,37 +99,59 @@ func (r *PostgresSocialRepository) ListFavorites(ctx context.Context, userID uui
 	defer rows.Close()
 
 	var mangas []*domain.Manga
+	favoritedAt := make(map[uuid.UUID]time.Time)
 	for rows.Next() {
-		var manga domain.Manga
-		if err := rows.Scan(
-			&manga.ID, &manga.Title, &manga.Description, &manga.Author, &manga.Status, &manga.CoverImageURL,
-			&manga.CreatedAt, &manga.UpdatedAt, &manga.Genres,
-		); err != nil {
+		var at time.Time
+		manga, err := scanManga(rows, &at)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan favorite manga row: %w", err)
 		}
-		mangas = append(mangas, &manga)
+		mangas = append(mangas, manga)
+		favoritedAt[manga.ID] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return mangas, nil
+
+	return newPage(mangas, params.Limit, total, func(m *domain.Manga) cursor {
+		return cursor{Sort: sortName, Key: timeKey(favoritedAt[m.ID]), ID: m.ID}
+	}), nil
 }
 
 func (r *PostgresSocialRepository) MarkChapterAsRead(ctx context.Context, userID, chapterID uuid.UUID) error {
+	var exists bool
+	err := r.DB.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM chapters WHERE id = $1 AND deleted_at IS NULL)`, chapterID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to find chapter: %w", err)
+	}
+	if !exists {
+		return repository.ErrChapterNotFound
+	}
+
 	query := "INSERT INTO user_reading_progress (user_id, chapter_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
-	_, err := r.DB.Exec(ctx, query, userID, chapterID)
+	_, err = r.DB.Exec(ctx, query, userID, chapterID)
 	if err != nil {
 		return fmt.Errorf("failed to mark chapter as read: %w", err)
 	}
 	return nil
 }
 
-func (r *PostgresSocialRepository) ListReadChapters(ctx context.Context, userID uuid.UUID) ([]*domain.Chapter, error) {
+func (r *PostgresSocialRepository) ListReadChapters(ctx context.Context, userID uuid.UUID, ratings []domain.ContentRating) ([]*domain.Chapter, error) {
+	where := "urp.user_id = $1 AND c.publication_state = 'published' AND c.deleted_at IS NULL"
+	args := []interface{}{userID}
+	if ratings != nil {
+		where += " AND m.content_rating = ANY($2::text[]::content_rating[])"
+		args = append(args, ratings)
+	}
 	query := `
-        SELECT c.id, c.manga_id, c.chapter_number, c.title, c.pages, c.created_at, c.updated_at
+        SELECT c.id, c.manga_id, c.chapter_number, c.title, c.volume, c.pages, c.publication_state, c.publish_at, c.created_at, c.updated_at
         FROM chapters c
         JOIN user_reading_progress urp ON c.id = urp.chapter_id
-        WHERE urp.user_id = $1
+        JOIN manga m ON m.id = c.manga_id
+        WHERE ` + where + `
         ORDER BY urp.created_at DESC`
 
-	rows, err := r.DB.Query(ctx, query, userID)
+	rows, err := r.DB.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list read chapters: %w", err)
 	}
@@ -114,7 +161,7 @@ func (r *PostgresSocialRepository) ListReadChapters(ctx context.Context, userID
 	for rows.Next() {
 		var chapter domain.Chapter
 		if err := rows.Scan(
-			&chapter.ID, &chapter.MangaID, &chapter.ChapterNumber, &chapter.Title, &chapter.Pages, &chapter.CreatedAt, &chapter.UpdatedAt,
+			&chapter.ID, &chapter.MangaID, &chapter.ChapterNumber, &chapter.Title, &chapter.Volume, &chapter.Pages, &chapter.PublicationState, &chapter.PublishAt, &chapter.CreatedAt, &chapter.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan read chapter row: %w", err)
 		}
@@ -123,10 +170,14 @@ func (r *PostgresSocialRepository) ListReadChapters(ctx context.Context, userID
 	return chapters, nil
 }
 
+// CreateComment adds a comment, returning ErrMangaNotFound or ErrChapterNotFound if its parent
+// is missing or in the trash.
 func (r *PostgresSocialRepository) CreateComment(ctx context.Context, comment *domain.Comment) error {
 	query := `
         INSERT INTO comments (user_id, manga_id, chapter_id, content)
-        VALUES ($1, $2, $3, $4)
+        SELECT $1::uuid, $2::uuid, $3::uuid, $4::text
+        WHERE EXISTS (SELECT 1 FROM manga WHERE id = $2 AND deleted_at IS NULL)
+           OR EXISTS (SELECT 1 FROM chapters WHERE id = $3 AND deleted_at IS NULL)
         RETURNING id, created_at, updated_at`
 
 	err := r.DB.QueryRow(ctx, query, comment.UserID, comment.MangaID, comment.ChapterID, comment.Content).Scan(
@@ -135,41 +186,64 @@ func (r *PostgresSocialRepository) CreateComment(ctx context.Context, comment *d
 		&comment.UpdatedAt,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if comment.MangaID != nil {
+				return repository.ErrMangaNotFound
+			}
+			return repository.ErrChapterNotFound
+		}
 		return fmt.Errorf("failed to create comment: %w", err)
 	}
 	return nil
 }
 
-func (r *PostgresSocialRepository) ListComments(ctx context.Context, params repository.ListCommentsParams) ([]*domain.CommentWithUser, error) {
-	// Base query
-	query := `
-        SELECT
-            c.id, c.user_id, c.manga_id, c.chapter_id, c.content, c.created_at, c.updated_at,
-            u.username
-        FROM comments c
-        JOIN users u ON c.user_id = u.id
-    `
+// ListComments lists the comments on a manga or chapter, newest first.
+func (r *PostgresSocialRepository) ListComments(ctx context.Context, params repository.ListCommentsParams) (*repository.Page[*domain.CommentWithUser], error) {
 	var args []interface{}
 	var conditions []string
 
 	// Polymorphic condition
 	if params.ParentType == domain.ParentTypeManga {
-		conditions = append(conditions, "c.manga_id = $1")
+		conditions = append(conditions, "c.manga_id = $1", "EXISTS (SELECT 1 FROM manga WHERE id = $1 AND deleted_at IS NULL)")
 		args = append(args, params.ParentID)
 	} else if params.ParentType == domain.ParentTypeChapter {
-		conditions = append(conditions, "c.chapter_id = $1")
+		conditions = append(conditions, "c.chapter_id = $1", "EXISTS (SELECT 1 FROM chapters WHERE id = $1 AND deleted_at IS NULL)")
 		args = append(args, params.ParentID)
 	} else {
 		return nil, errors.New("invalid comment parent type")
 	}
 
+	var total int
+	countQuery := "SELECT count(*) FROM comments c WHERE " + strings.Join(conditions, " AND ")
+	if err := r.DB.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	const sortName = "created_at:desc"
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor, sortName, "timestamptz")
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, keysetCondition("c.created_at", "c.id", "timestamptz", true, len(args)+1))
+		args = append(args, c.Key, c.ID)
+		params.Offset = 0
+	}
+
+	query := `
+        SELECT
+            c.id, c.user_id, c.manga_id, c.chapter_id, c.content, c.created_at, c.updated_at,
+            u.username
+        FROM comments c
+        JOIN users u ON c.user_id = u.id
+    `
 	query += " WHERE " + strings.Join(conditions, " AND ")
-	query += " ORDER BY c.created_at DESC"
+	query += " ORDER BY c.created_at DESC, c.id DESC"
 
-	// Add pagination
+	// Add pagination, fetching one more to see if there's a next page
 	argID := len(args) + 1
 	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argID, argID+1)
-	args = append(args, params.Limit, params.Offset)
+	args = append(args, params.Limit+1, params.Offset)
 
 	rows, err := r.DB.Query(ctx, query, args...)
 	if err != nil {
@@ -188,5 +262,11 @@ func (r *PostgresSocialRepository) ListComments(ctx context.Context, params repo
 		}
 		comments = append(comments, &comment)
 	}
-	return comments, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return newPage(comments, params.Limit, total, func(c *domain.CommentWithUser) cursor {
+		return cursor{Sort: sortName, Key: timeKey(c.CreatedAt), ID: c.ID}
+	}), nil
 }
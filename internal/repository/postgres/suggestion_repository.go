@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// suggestionColumns lists the columns scanned by scanSuggestion, in order. Queries join manga as
+// m and the submitter as u.
+const suggestionColumns = `
+            s.id, s.manga_id, m.title, s.user_id, u.username, s.changes, s.note, s.status,
+            s.reviewed_by, s.reviewed_at, s.rejection_reason, s.created_at`
+
+const suggestionTables = ` FROM edit_suggestions s JOIN manga m ON s.manga_id = m.id JOIN users u ON s.user_id = u.id`
+
+type PostgresSuggestionRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresSuggestionRepository(db *pgxpool.Pool) *PostgresSuggestionRepository {
+	return &PostgresSuggestionRepository{DB: db}
+}
+
+func scanSuggestion(row pgx.Row) (*domain.EditSuggestion, error) {
+	var s domain.EditSuggestion
+	err := row.Scan(
+		&s.ID, &s.MangaID, &s.MangaTitle, &s.UserID, &s.Username, &s.Changes, &s.Note, &s.Status,
+		&s.ReviewedBy, &s.ReviewedAt, &s.RejectionReason, &s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Create saves a pending suggestion, returning ErrMangaNotFound if the manga is gone or in the trash.
+func (r *PostgresSuggestionRepository) Create(ctx context.Context, suggestion *domain.EditSuggestion) error {
+	query := `
+        INSERT INTO edit_suggestions (manga_id, user_id, changes, note)
+        SELECT m.id, $2::uuid, $3::jsonb, $4::text FROM manga m WHERE m.id = $1 AND m.deleted_at IS NULL
+        RETURNING id, status, created_at`
+
+	err := r.DB.QueryRow(ctx, query, suggestion.MangaID, suggestion.UserID, suggestion.Changes, suggestion.Note).
+		Scan(&suggestion.ID, &suggestion.Status, &suggestion.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrMangaNotFound
+		}
+		return fmt.Errorf("failed to create suggestion: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresSuggestionRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.EditSuggestion, error) {
+	query := `SELECT ` + suggestionColumns + suggestionTables + ` WHERE s.id = $1`
+
+	suggestion, err := scanSuggestion(r.DB.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrSuggestionNotFound
+		}
+		return nil, fmt.Errorf("failed to find suggestion by id: %w", err)
+	}
+	return suggestion, nil
+}
+
+func (r *PostgresSuggestionRepository) List(ctx context.Context, params repository.ListSuggestionsParams) (*repository.Page[*domain.EditSuggestion], error) {
+	where := ` WHERE m.deleted_at IS NULL`
+	var args []interface{}
+	if params.MangaID != uuid.Nil {
+		args = append(args, params.MangaID)
+		where += fmt.Sprintf(" AND s.manga_id = $%d", len(args))
+	}
+	if params.UserID != uuid.Nil {
+		args = append(args, params.UserID)
+		where += fmt.Sprintf(" AND s.user_id = $%d", len(args))
+	}
+	if params.Status != "" {
+		args = append(args, params.Status)
+		where += fmt.Sprintf(" AND s.status = $%d", len(args))
+	}
+
+	var total int
+	if err := r.DB.QueryRow(ctx, `SELECT count(*)`+suggestionTables+where, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count suggestions: %w", err)
+	}
+
+	sortName, order := "created_at:desc", "DESC"
+	if params.OldestFirst {
+		sortName, order = "created_at:asc", "ASC"
+	}
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor, sortName, "timestamptz")
+		if err != nil {
+			return nil, err
+		}
+		where += " AND " + keysetCondition("s.created_at", "s.id", "timestamptz", !params.OldestFirst, len(args)+1)
+		args = append(args, c.Key, c.ID)
+		params.Offset = 0
+	}
+
+	query := `SELECT ` + suggestionColumns + suggestionTables + where + fmt.Sprintf(`
+        ORDER BY s.created_at %s, s.id %s
+        LIMIT $%d OFFSET $%d`, order, order, len(args)+1, len(args)+2)
+	args = append(args, params.Limit+1, params.Offset) // One more to see if there's a next page
+
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	var suggestions []*domain.EditSuggestion
+	for rows.Next() {
+		suggestion, err := scanSuggestion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan suggestion row: %w", err)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return newPage(suggestions, params.Limit, total, func(s *domain.EditSuggestion) cursor {
+		return cursor{Sort: sortName, Key: timeKey(s.CreatedAt), ID: s.ID}
+	}), nil
+}
+
+func (r *PostgresSuggestionRepository) Review(ctx context.Context, id uuid.UUID, status domain.SuggestionStatus, reviewerID uuid.UUID, reason *string) error {
+	query := `
+        UPDATE edit_suggestions
+        SET status = $2, reviewed_by = $3, reviewed_at = now(), rejection_reason = $4
+        WHERE id = $1 AND status = 'pending'`
+
+	tag, err := r.DB.Exec(ctx, query, id, status, reviewerID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to review suggestion: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		var exists bool
+		if err := r.DB.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM edit_suggestions WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to review suggestion: %w", err)
+		}
+		if !exists {
+			return repository.ErrSuggestionNotFound
+		}
+		return repository.ErrSuggestionReviewed
+	}
+	return nil
+}
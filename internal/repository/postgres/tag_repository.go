@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Tags live in the genres table, which predates tag groups.
+const tagColumns = `id, name, slug, description, "group", created_at`
+
+type PostgresTagRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewPostgresTagRepository(db *pgxpool.Pool) *PostgresTagRepository {
+	return &PostgresTagRepository{DB: db}
+}
+
+func scanTag(row pgx.Row) (*domain.Tag, error) {
+	var tag domain.Tag
+	if err := row.Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.Description, &tag.Group, &tag.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *PostgresTagRepository) Create(ctx context.Context, tag *domain.Tag) error {
+	query := `
+        INSERT INTO genres (name, slug, description, "group")
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at`
+
+	err := r.DB.QueryRow(ctx, query, tag.Name, tag.Slug, tag.Description, tag.Group).Scan(&tag.ID, &tag.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return repository.ErrTagAlreadyExists
+		}
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresTagRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM genres WHERE id = $1`
+
+	tag, err := scanTag(r.DB.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("failed to find tag by id: %w", err)
+	}
+	return tag, nil
+}
+
+func (r *PostgresTagRepository) List(ctx context.Context, group domain.TagGroup) ([]*domain.Tag, error) {
+	query := `
+        SELECT ` + tagColumns + `
+        FROM genres
+        WHERE $1 = '' OR "group"::text = $1
+        ORDER BY "group", name`
+
+	rows, err := r.DB.Query(ctx, query, string(group))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*domain.Tag
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tag row: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// bumpTaggedMangas increments the version of every manga with the tag, whose genres change
+// along with it.
+func bumpTaggedMangas(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	_, err := tx.Exec(ctx, `
+        UPDATE manga SET version = version + 1, updated_at = now()
+        WHERE id IN (SELECT manga_id FROM manga_genres WHERE genre_id = $1)`, id)
+	if err != nil {
+		return fmt.Errorf("failed to bump tagged manga versions: %w", err)
+	}
+	return nil
+}
+
+// Update saves the tag. Renaming it bumps the version of every manga that has it.
+func (r *PostgresTagRepository) Update(ctx context.Context, tag *domain.Tag) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // Rollback is a no-op if the transaction is committed
+
+	// The self-join yields the row as it was before the update
+	query := `
+        UPDATE genres g
+        SET name = $1, slug = $2, description = $3, "group" = $4
+        FROM genres old
+        WHERE g.id = $5 AND old.id = g.id
+        RETURNING g.created_at, old.name`
+
+	var oldName string
+	err = tx.QueryRow(ctx, query, tag.Name, tag.Slug, tag.Description, tag.Group, tag.ID).Scan(&tag.CreatedAt, &oldName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrTagNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return repository.ErrTagAlreadyExists
+		}
+		return fmt.Errorf("failed to update tag: %w", err)
+	}
+	if oldName != tag.Name {
+		if err := bumpTaggedMangas(ctx, tx, tag.ID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// Delete removes the tag. It is removed from every manga that had it, bumping their versions.
+func (r *PostgresTagRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // Rollback is a no-op if the transaction is committed
+
+	if err := bumpTaggedMangas(ctx, tx, id); err != nil {
+		return err
+	}
+	cmdTag, err := tx.Exec(ctx, "DELETE FROM genres WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrTagNotFound
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresTagRepository) ListMangaIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.DB.Query(ctx, "SELECT manga_id FROM manga_genres WHERE genre_id = $1", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tagged manga: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan tagged manga: %w", err)
+	}
+	return ids, nil
+}
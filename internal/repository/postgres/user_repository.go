@@ -52,7 +52,7 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User)
 func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
 	query := `
-        SELECT id, username, email, password_hash, role_id, created_at, updated_at
+        SELECT id, username, email, password_hash, role_id, content_ratings::text[], created_at, updated_at
         FROM users
         WHERE email = $1`
 
@@ -62,6 +62,7 @@ func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string)
 		&user.Email,
 		&user.PasswordHash,
 		&user.RoleID,
+		&user.ContentRatings,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -97,7 +98,7 @@ func (r *PostgresUserRepository) FindDefaultUserRoleID(ctx context.Context) (uui
 func (r *PostgresUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	var user domain.User
 	query := `
-        SELECT id, username, email, password_hash, role_id, created_at, updated_at
+        SELECT id, username, email, password_hash, role_id, content_ratings::text[], created_at, updated_at
         FROM users
         WHERE id = $1`
 
@@ -107,6 +108,7 @@ func (r *PostgresUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*d
 		&user.Email,
 		&user.PasswordHash,
 		&user.RoleID,
+		&user.ContentRatings,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -147,6 +149,19 @@ func (r *PostgresUserRepository) GetRoleAndPermissions(ctx context.Context, user
 	return &role, nil
 }
 
+// UpdateContentRatings replaces the content ratings the user has opted into.
+func (r *PostgresUserRepository) UpdateContentRatings(ctx context.Context, userID uuid.UUID, ratings []domain.ContentRating) error {
+	query := `UPDATE users SET content_ratings = $1::text[]::content_rating[], updated_at = now() WHERE id = $2`
+	cmdTag, err := r.DB.Exec(ctx, query, ratings, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update content ratings: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: user not found", apperrors.ErrNotFound)
+	}
+	return nil
+}
+
 func (r *PostgresUserRepository) CreatePasswordResetToken(ctx context.Context, userID uuid.UUID, tokenHash []byte, expiresAt time.Time) error {
 	query := `INSERT INTO password_reset_tokens (user_id, token_hash, expires_at) VALUES ($1, $2, $3)`
 	_, err := r.DB.Exec(ctx, query, userID, tokenHash, expiresAt)
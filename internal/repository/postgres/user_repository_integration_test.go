@@ -13,6 +13,7 @@ import (
 
 func TestPostgresUserRepository_Create(t *testing.T) {
 	// The testPool is initialized in main_test.go
+	requireTestDB(t)
 	repo := NewPostgresUserRepository(testPool)
 	ctx := context.Background()
 
@@ -42,6 +43,7 @@ func TestPostgresUserRepository_Create(t *testing.T) {
 }
 
 func TestPostgresUserRepository_FindByEmail(t *testing.T) {
+	requireTestDB(t)
 	repo := NewPostgresUserRepository(testPool)
 	ctx := context.Background()
 
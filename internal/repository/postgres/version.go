@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// versionCondition matches rows at the expected version, given by the argument argID. Version
+// 0 matches any version, for unconditional writes.
+func versionCondition(argID int) string {
+	return fmt.Sprintf("($%[1]d = 0 OR version = $%[1]d)", argID)
+}
+
+// missingOrConflict tells why a write to the row of table with the id, expecting the version,
+// matched nothing: notFound if the row doesn't exist or is in the trash, ErrVersionConflict if
+// it has changed.
+func missingOrConflict(ctx context.Context, db *pgxpool.Pool, table string, id uuid.UUID, version int, notFound error) error {
+	if version == 0 {
+		return notFound
+	}
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM ` + table + ` WHERE id = $1 AND deleted_at IS NULL)`
+	if err := db.QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check the %s version: %w", table, err)
+	}
+	if exists {
+		return repository.ErrVersionConflict
+	}
+	return notFound
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/google/uuid"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+type ListRevisionsParams struct {
+	MangaID uuid.UUID
+	Limit   int
+	Offset  int
+	Cursor  string // NextCursor of the previous page; replaces Offset
+}
+
+// Revise returns the revision recording a change, given the record as it was saved (its ID for
+// deletions), or nil if the change isn't worth recording. Repositories that take one write the revision in the
+// transaction of the change, so a change is never saved without its history.
+type Revise[T any] func(saved T) (*domain.Revision, error)
+
+type RevisionRepository interface {
+	Create(ctx context.Context, revision *domain.Revision) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Revision, error)
+	// ListByMangaID lists the revisions of a manga and its chapters, newest first.
+	ListByMangaID(ctx context.Context, params ListRevisionsParams) (*Page[*domain.Revision], error)
+}
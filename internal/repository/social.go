@@ -16,18 +16,21 @@ type ListCommentsParams struct {
 	ParentType domain.CommentParentType
 	Limit      int
 	Offset     int
+	Cursor     string // NextCursor of the previous page; replaces Offset
 }
 
 type SocialRepository interface {
 	// Favorites
 	ToggleFavorite(ctx context.Context, userID, mangaID uuid.UUID) (*ToggleFavoriteResult, error)
-	ListFavorites(ctx context.Context, userID uuid.UUID, params ListMangaParams) ([]*domain.Manga, error)
+	ListFavorites(ctx context.Context, userID uuid.UUID, params ListMangaParams) (*Page[*domain.Manga], error)
 
 	// Reading Progress
 	MarkChapterAsRead(ctx context.Context, userID, chapterID uuid.UUID) error
-	ListReadChapters(ctx context.Context, userID uuid.UUID) ([]*domain.Chapter, error)
+	// ListReadChapters lists published chapters only, of manga with one of the content ratings
+	// if not nil.
+	ListReadChapters(ctx context.Context, userID uuid.UUID, ratings []domain.ContentRating) ([]*domain.Chapter, error)
 
 	// Comments
 	CreateComment(ctx context.Context, comment *domain.Comment) error
-	ListComments(ctx context.Context, params ListCommentsParams) ([]*domain.CommentWithUser, error)
+	ListComments(ctx context.Context, params ListCommentsParams) (*Page[*domain.CommentWithUser], error)
 }
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSuggestionNotFound = errors.New("suggestion not found")
+	ErrSuggestionReviewed = errors.New("suggestion has already been reviewed")
+)
+
+type ListSuggestionsParams struct {
+	MangaID     uuid.UUID               // Only suggestions for this manga, if set
+	UserID      uuid.UUID               // Only suggestions by this user, if set
+	Status      domain.SuggestionStatus // Only suggestions with this status, if set
+	OldestFirst bool                    // As a queue, rather than newest first
+	Limit       int
+	Offset      int
+	Cursor      string // NextCursor of the previous page; replaces Offset
+}
+
+type SuggestionRepository interface {
+	Create(ctx context.Context, suggestion *domain.EditSuggestion) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.EditSuggestion, error)
+	// List lists suggestions for manga that aren't in the trash.
+	List(ctx context.Context, params ListSuggestionsParams) (*Page[*domain.EditSuggestion], error)
+	// Review approves or rejects a pending suggestion, returning ErrSuggestionReviewed if it
+	// isn't pending anymore. The reason is for rejections.
+	Review(ctx context.Context, id uuid.UUID, status domain.SuggestionStatus, reviewerID uuid.UUID, reason *string) error
+}
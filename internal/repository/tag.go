@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTagNotFound      = errors.New("tag not found")
+	ErrTagAlreadyExists = errors.New("tag with this name or slug already exists")
+	// ErrUnknownTags is returned when a manga references tags that don't exist. The wrapping error lists them.
+	ErrUnknownTags = errors.New("unknown tags")
+)
+
+type TagRepository interface {
+	Create(ctx context.Context, tag *domain.Tag) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Tag, error)
+	// List returns all tags, or only those of a group if one is given.
+	List(ctx context.Context, group domain.TagGroup) ([]*domain.Tag, error)
+	// Update and Delete bump the version of the manga whose genres change: those with the tag,
+	// when it is renamed or deleted.
+	Update(ctx context.Context, tag *domain.Tag) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ListMangaIDs returns the manga tagged with the tag.
+	ListMangaIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+}
@@ -0,0 +1,8 @@
+package repository
+
+// ListTrashParams pages through trashed manga or chapters, most recently deleted first.
+type ListTrashParams struct {
+	Limit  int
+	Offset int
+	Cursor string // NextCursor of the previous page; replaces Offset
+}
@@ -20,5 +20,6 @@ type UserRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	FindDefaultUserRoleID(ctx context.Context) (uuid.UUID, error)
 	GetRoleAndPermissions(ctx context.Context, userID uuid.UUID) (*domain.Role, error)
+	UpdateContentRatings(ctx context.Context, userID uuid.UUID, ratings []domain.ContentRating) error
 	CreatePasswordResetToken(ctx context.Context, userID uuid.UUID, tokenHash []byte, expiresAt time.Time) error
 }
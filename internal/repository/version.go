@@ -0,0 +1,7 @@
+package repository
+
+import "errors"
+
+// ErrVersionConflict is returned by conditional updates and deletes when the record has
+// changed since the expected version was read.
+var ErrVersionConflict = errors.New("the resource has been modified")
@@ -0,0 +1,60 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+)
+
+// Indexer copies the catalog into an index: one manga at a time as manga change, or all of them.
+type Indexer struct {
+	index     Index
+	mangaRepo repository.MangaRepository
+}
+
+func NewIndexer(index Index, mangaRepo repository.MangaRepository) *Indexer {
+	return &Indexer{index: index, mangaRepo: mangaRepo}
+}
+
+// Sync indexes the current version of the manga, or removes it from the index if it is gone.
+// Events may arrive late or twice, so it never relies on their contents.
+func (i *Indexer) Sync(ctx context.Context, id uuid.UUID) error {
+	manga, err := i.mangaRepo.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrMangaNotFound) {
+		return i.index.Delete(ctx, id)
+	}
+	if err != nil {
+		return err
+	}
+	return i.index.Upsert(ctx, manga)
+}
+
+// Reindex sets up the index and indexes every manga, batchSize at a time. It returns how many
+// manga were indexed.
+func (i *Indexer) Reindex(ctx context.Context, batchSize int) (int, error) {
+	if err := i.index.Setup(ctx); err != nil {
+		return 0, fmt.Errorf("failed to set up the search index: %w", err)
+	}
+
+	params := repository.ListMangaParams{Limit: batchSize, SortBy: "created_at", SortOrder: "asc"}
+	count := 0
+	for {
+		page, err := i.mangaRepo.List(ctx, params)
+		if err != nil {
+			return count, err
+		}
+		if len(page.Items) > 0 {
+			if err := i.index.Upsert(ctx, page.Items...); err != nil {
+				return count, err
+			}
+			count += len(page.Items)
+		}
+		if page.NextCursor == "" {
+			return count, nil
+		}
+		params.Cursor = page.NextCursor
+	}
+}
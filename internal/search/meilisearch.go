@@ -0,0 +1,520 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+)
+
+// DefaultMeilisearchIndex is the index used when none is configured.
+const DefaultMeilisearchIndex = "manga"
+
+// MeilisearchIndex searches a Meilisearch index through its REST API. The index only stores
+// what is searched, filtered and sorted on; the manga it finds are loaded from the database.
+//
+// Meilisearch has no cursors, so the NextCursor of a search holds the offset of the next page.
+// Unlike the keyset cursors of the Postgres search, the pages it continues can shift if the index
+// changes in between. Like there, facet counts leave out the search's own filter on each facet.
+type MeilisearchIndex struct {
+	baseURL   string
+	apiKey    string
+	uid       string
+	client    *http.Client
+	mangaRepo repository.MangaRepository
+}
+
+func NewMeilisearchIndex(baseURL, apiKey, uid string, mangaRepo repository.MangaRepository) (*MeilisearchIndex, error) {
+	if baseURL == "" {
+		return nil, errors.New("meilisearch URL is required")
+	}
+	if uid == "" {
+		uid = DefaultMeilisearchIndex
+	}
+	return &MeilisearchIndex{
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		apiKey:    apiKey,
+		uid:       uid,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		mangaRepo: mangaRepo,
+	}, nil
+}
+
+// meiliDocument is a manga as stored in the index.
+type meiliDocument struct {
+	ID               string   `json:"id"`
+	Title            string   `json:"title"`
+	AltTitles        []string `json:"alt_titles"`
+	Description      string   `json:"description"`
+	Author           string   `json:"author"`
+	CreatorIDs       []string `json:"creator_ids"`
+	Genres           []string `json:"genres"` // Tag names
+	Status           string   `json:"status"`
+	ContentRating    string   `json:"content_rating"`
+	Demographic      *string  `json:"demographic"`
+	Year             *int     `json:"year"`
+	OriginalLanguage *string  `json:"original_language"`
+	MyAnimeListID    *int     `json:"mal_id"`
+	AniListID        *int     `json:"anilist_id"`
+	MangaUpdatesID   *string  `json:"mangaupdates_id"`
+	CreatedAt        int64    `json:"created_at"` // Unix time
+}
+
+func newMeiliDocument(manga *domain.Manga) meiliDocument {
+	doc := meiliDocument{
+		ID:               manga.ID.String(),
+		Title:            manga.Title,
+		AltTitles:        []string{},
+		Description:      manga.Description,
+		Author:           manga.Author,
+		CreatorIDs:       []string{},
+		Genres:           manga.Genres,
+		Status:           string(manga.Status),
+		ContentRating:    string(manga.ContentRating),
+		Year:             manga.Year,
+		OriginalLanguage: manga.OriginalLanguage,
+		MyAnimeListID:    manga.Links.MyAnimeListID,
+		AniListID:        manga.Links.AniListID,
+		MangaUpdatesID:   manga.Links.MangaUpdatesID,
+		CreatedAt:        manga.CreatedAt.Unix(),
+	}
+	for _, t := range manga.AltTitles {
+		doc.AltTitles = append(doc.AltTitles, t.Title)
+	}
+	for _, credit := range manga.Creators {
+		doc.CreatorIDs = append(doc.CreatorIDs, credit.CreatorID.String())
+	}
+	if doc.Genres == nil {
+		doc.Genres = []string{}
+	}
+	if manga.Demographic != nil {
+		demographic := string(*manga.Demographic)
+		doc.Demographic = &demographic
+	}
+	return doc
+}
+
+// Index settings. Titles rank above the author and the description.
+var meiliSettings = map[string][]string{
+	"searchableAttributes": {"title", "alt_titles", "author", "description"},
+	"filterableAttributes": {
+		"genres", "status", "content_rating", "demographic", "year", "original_language",
+		"creator_ids", "mal_id", "anilist_id", "mangaupdates_id",
+	},
+	"sortableAttributes": {"title", "year", "created_at"},
+}
+
+// meiliFacets are the attributes counted for domain.MangaFacets.
+var meiliFacets = []string{"genres", "status", "content_rating", "year"}
+
+func (i *MeilisearchIndex) Setup(ctx context.Context) error {
+	return i.do(ctx, http.MethodPatch, i.indexPath("/settings"), meiliSettings, nil)
+}
+
+// Upsert replaces the documents of the manga. Meilisearch applies writes asynchronously, so they
+// may take a moment to show up in searches.
+func (i *MeilisearchIndex) Upsert(ctx context.Context, mangas ...*domain.Manga) error {
+	if len(mangas) == 0 {
+		return nil
+	}
+	docs := make([]meiliDocument, len(mangas))
+	for n, manga := range mangas {
+		docs[n] = newMeiliDocument(manga)
+	}
+	return i.do(ctx, http.MethodPost, i.indexPath("/documents?primaryKey=id"), docs, nil)
+}
+
+func (i *MeilisearchIndex) Delete(ctx context.Context, id uuid.UUID) error {
+	return i.do(ctx, http.MethodDelete, i.indexPath("/documents/"+url.PathEscape(id.String())), nil, nil)
+}
+
+type meiliSearchRequest struct {
+	IndexUID             string   `json:"indexUid"`
+	Query                string   `json:"q"`
+	Offset               int      `json:"offset"`
+	Limit                int      `json:"limit"`
+	Filter               []string `json:"filter,omitempty"`
+	Sort                 []string `json:"sort,omitempty"`
+	Facets               []string `json:"facets,omitempty"`
+	AttributesToRetrieve []string `json:"attributesToRetrieve"`
+}
+
+type meiliHit struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type meiliSearchResponse struct {
+	Hits               []meiliHit                `json:"hits"`
+	EstimatedTotalHits int                       `json:"estimatedTotalHits"`
+	FacetDistribution  map[string]map[string]int `json:"facetDistribution"`
+}
+
+// Search finds the IDs of the matches in the index, then loads the manga from the database,
+// checking their content rating again in case the index is behind.
+func (i *MeilisearchIndex) Search(ctx context.Context, params repository.ListMangaParams) (*repository.MangaPage, error) {
+	sort := meiliSort(params)
+	offset := params.Offset
+	if params.Cursor != "" {
+		c, err := decodeMeiliCursor(params.Cursor, strings.Join(sort, ","))
+		if err != nil {
+			return nil, err
+		}
+		offset = c.Offset
+	}
+	filters, ok := meiliFilters(params)
+	if !ok {
+		return i.emptyPage(params), nil
+	}
+
+	// Fetch one more hit to see if there's a next page
+	queries := []meiliSearchRequest{{
+		Query:                params.SearchQuery,
+		Offset:               offset,
+		Limit:                params.Limit + 1,
+		Filter:               meiliExprs(filters, ""),
+		Sort:                 sort,
+		AttributesToRetrieve: []string{"id"},
+	}}
+	if params.Facets {
+		queries = append(queries, meiliFacetQueries(&queries[0], filters)...)
+	}
+	results, err := i.multiSearch(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := results[0]
+	page := i.emptyPage(params)
+	page.Total = resp.EstimatedTotalHits
+	if params.Facets {
+		distribution := map[string]map[string]int{}
+		for _, result := range results {
+			for facet, counts := range result.FacetDistribution {
+				distribution[facet] = counts
+			}
+		}
+		page.Facets = newFacets(distribution)
+	}
+	hits := resp.Hits
+	if params.Limit > 0 && len(hits) > params.Limit {
+		hits = hits[:params.Limit]
+		page.NextCursor = encodeMeiliCursor(meiliCursor{Sort: strings.Join(sort, ","), Offset: offset + params.Limit})
+	}
+	if len(hits) == 0 {
+		return page, nil
+	}
+
+	ids := make([]uuid.UUID, len(hits))
+	for n, hit := range hits {
+		ids[n] = hit.ID
+	}
+	found, err := i.mangaRepo.List(ctx, repository.ListMangaParams{
+		IDs:            ids,
+		ContentRatings: params.ContentRatings,
+		Limit:          len(ids),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the order of the hits
+	byID := make(map[uuid.UUID]*domain.Manga, len(found.Items))
+	for _, manga := range found.Items {
+		byID[manga.ID] = manga
+	}
+	for _, id := range ids {
+		if manga, ok := byID[id]; ok {
+			page.Items = append(page.Items, manga)
+		}
+	}
+	return page, nil
+}
+
+// multiSearch runs the queries on the index in one request, returning their results in order.
+func (i *MeilisearchIndex) multiSearch(ctx context.Context, queries []meiliSearchRequest) ([]meiliSearchResponse, error) {
+	for n := range queries {
+		queries[n].IndexUID = i.uid
+	}
+	var resp struct {
+		Results []meiliSearchResponse `json:"results"`
+	}
+	if err := i.do(ctx, http.MethodPost, "/multi-search", map[string]interface{}{"queries": queries}, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != len(queries) {
+		return nil, fmt.Errorf("meilisearch returned %d results for %d queries", len(resp.Results), len(queries))
+	}
+	return resp.Results, nil
+}
+
+// meiliFacetQueries sets the facets counted along with the search, and returns a query for each
+// facet the search filters on. Those are counted without their own filter, as picking another
+// value widens the results.
+func meiliFacetQueries(search *meiliSearchRequest, filters []meiliFilter) []meiliSearchRequest {
+	var queries []meiliSearchRequest
+	for _, facet := range meiliFacets {
+		filtered := false
+		for _, f := range filters {
+			filtered = filtered || f.facet == facet
+		}
+		if !filtered {
+			search.Facets = append(search.Facets, facet)
+			continue
+		}
+		queries = append(queries, meiliSearchRequest{
+			Query:                search.Query,
+			Filter:               meiliExprs(filters, facet),
+			Facets:               []string{facet},
+			AttributesToRetrieve: []string{"id"},
+		})
+	}
+	return queries
+}
+
+// meiliCursor is the opaque cursor of a Meilisearch search: the offset of the next page, and
+// the sort it was made for.
+type meiliCursor struct {
+	Sort   string `json:"sort"`
+	Offset int    `json:"offset"`
+}
+
+func encodeMeiliCursor(c meiliCursor) string {
+	data, _ := json.Marshal(c) // Can't fail for these field types
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeMeiliCursor reads a cursor made for the given sort.
+func decodeMeiliCursor(s, sort string) (*meiliCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, repository.ErrInvalidCursor
+	}
+	var c meiliCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != sort || c.Offset < 0 {
+		return nil, repository.ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+func (i *MeilisearchIndex) emptyPage(params repository.ListMangaParams) *repository.MangaPage {
+	page := &repository.MangaPage{Page: repository.Page[*domain.Manga]{Items: []*domain.Manga{}}}
+	if params.Facets {
+		page.Facets = newFacets(nil)
+	}
+	return page
+}
+
+func newFacets(distribution map[string]map[string]int) *domain.MangaFacets {
+	facets := &domain.MangaFacets{
+		Genres:         map[string]int{},
+		Statuses:       map[domain.MangaStatus]int{},
+		ContentRatings: map[domain.ContentRating]int{},
+		Years:          map[int]int{},
+	}
+	for value, count := range distribution["genres"] {
+		facets.Genres[value] = count
+	}
+	for value, count := range distribution["status"] {
+		facets.Statuses[domain.MangaStatus(value)] = count
+	}
+	for value, count := range distribution["content_rating"] {
+		facets.ContentRatings[domain.ContentRating(value)] = count
+	}
+	for value, count := range distribution["year"] {
+		if year, err := strconv.Atoi(value); err == nil {
+			facets.Years[year] = count
+		}
+	}
+	return facets
+}
+
+// meiliFilter is a filter expression, with the facet it filters on, if any.
+type meiliFilter struct {
+	facet string
+	expr  string
+}
+
+// meiliExprs returns the expressions of the filters, leaving out those on the skipped facet.
+func meiliExprs(filters []meiliFilter, skip string) []string {
+	var exprs []string
+	for _, f := range filters {
+		if skip == "" || f.facet != skip {
+			exprs = append(exprs, f.expr)
+		}
+	}
+	return exprs
+}
+
+// meiliFilters translates the filters of a search into Meilisearch filter expressions, which
+// all have to match. It reports false if nothing can match. Tags are matched by name, the
+// index doesn't know their slugs.
+func meiliFilters(params repository.ListMangaParams) ([]meiliFilter, bool) {
+	var filters []meiliFilter
+	add := func(facet, expr string) {
+		filters = append(filters, meiliFilter{facet: facet, expr: expr})
+	}
+
+	// The ratings the user allows always apply, unlike a filter on the content_rating facet
+	if params.ContentRatings != nil {
+		if len(params.ContentRatings) == 0 {
+			return nil, false
+		}
+		add("", "content_rating IN "+meiliList(ratingStrings(params.ContentRatings)))
+	}
+	if params.ContentRatingFilter != nil {
+		if len(params.ContentRatingFilter) == 0 {
+			return nil, false
+		}
+		add("content_rating", "content_rating IN "+meiliList(ratingStrings(params.ContentRatingFilter)))
+	}
+	if params.Demographics != nil {
+		if len(params.Demographics) == 0 {
+			return nil, false
+		}
+		var values []string
+		for _, d := range params.Demographics {
+			values = append(values, string(d))
+		}
+		add("", "demographic IN "+meiliList(values))
+	}
+
+	if params.Status != "" {
+		add("status", "status = "+meiliQuote(params.Status))
+	}
+	if params.YearFrom != 0 {
+		add("year", fmt.Sprintf("year >= %d", params.YearFrom))
+	}
+	if params.YearTo != 0 {
+		add("year", fmt.Sprintf("year <= %d", params.YearTo))
+	}
+	if params.OriginalLanguage != "" {
+		add("", "original_language = "+meiliQuote(params.OriginalLanguage))
+	}
+	if params.MyAnimeListID != 0 {
+		add("", fmt.Sprintf("mal_id = %d", params.MyAnimeListID))
+	}
+	if params.AniListID != 0 {
+		add("", fmt.Sprintf("anilist_id = %d", params.AniListID))
+	}
+	if params.MangaUpdatesID != "" {
+		add("", "mangaupdates_id = "+meiliQuote(params.MangaUpdatesID))
+	}
+	if params.CreatorID != uuid.Nil {
+		add("", "creator_ids = "+meiliQuote(params.CreatorID.String()))
+	}
+
+	if len(params.Genres) > 0 {
+		if params.GenreMode == repository.TagModeAny {
+			// Picking another genre widens the results, so they are counted without this filter
+			add("genres", "genres IN "+meiliList(params.Genres))
+		} else {
+			for _, genre := range params.Genres {
+				add("", "genres = "+meiliQuote(genre))
+			}
+		}
+	}
+	if len(params.ExcludedGenres) > 0 {
+		if params.ExcludedGenreMode == repository.TagModeAll {
+			var all []string
+			for _, genre := range params.ExcludedGenres {
+				all = append(all, "genres = "+meiliQuote(genre))
+			}
+			add("", "NOT ("+strings.Join(all, " AND ")+")")
+		} else {
+			add("", "genres NOT IN "+meiliList(params.ExcludedGenres))
+		}
+	}
+	return filters, true
+}
+
+// meiliSort sorts by the requested attribute, or by relevance.
+func meiliSort(params repository.ListMangaParams) []string {
+	switch params.SortBy {
+	case "title", "year", "created_at":
+		order := "asc"
+		if strings.ToLower(params.SortOrder) == "desc" {
+			order = "desc"
+		}
+		return []string{params.SortBy + ":" + order}
+	default:
+		return nil
+	}
+}
+
+func ratingStrings(ratings []domain.ContentRating) []string {
+	values := make([]string, len(ratings))
+	for n, r := range ratings {
+		values[n] = string(r)
+	}
+	return values
+}
+
+// meiliQuote quotes a string for a filter expression.
+func meiliQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func meiliList(values []string) string {
+	quoted := make([]string, len(values))
+	for n, v := range values {
+		quoted[n] = meiliQuote(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// indexPath returns the path of an API of the index.
+func (i *MeilisearchIndex) indexPath(path string) string {
+	return "/indexes/" + url.PathEscape(i.uid) + path
+}
+
+// do sends a request to the API at path, decoding the response into out if it isn't nil.
+func (i *MeilisearchIndex) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal meilisearch request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, i.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create meilisearch request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if i.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+i.apiKey)
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("meilisearch request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("meilisearch %s %s: %s: %s", method, path, resp.Status, bytes.TrimSpace(message))
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode meilisearch response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeiliFilters(t *testing.T) {
+	filters, ok := meiliFilters(repository.ListMangaParams{
+		ContentRatings:    []domain.ContentRating{domain.ContentRatingSafe},
+		Status:            `on"going`,
+		YearFrom:          2000,
+		Genres:            []string{"Action", "Drama"},
+		ExcludedGenres:    []string{"Horror"},
+		ExcludedGenreMode: repository.TagModeAny,
+	})
+	assert.True(t, ok)
+	assert.Equal(t, []string{
+		`content_rating IN ["safe"]`,
+		`status = "on\"going"`,
+		"year >= 2000",
+		`genres = "Action"`,
+		`genres = "Drama"`,
+		`genres NOT IN ["Horror"]`,
+	}, meiliExprs(filters, ""))
+	assert.Equal(t, []string{`content_rating IN ["safe"]`, "year >= 2000", `genres = "Action"`, `genres = "Drama"`, `genres NOT IN ["Horror"]`},
+		meiliExprs(filters, "status"))
+
+	// An empty set of allowed ratings can't match anything
+	_, ok = meiliFilters(repository.ListMangaParams{ContentRatings: []domain.ContentRating{}})
+	assert.False(t, ok)
+}
+
+func TestMeiliSort(t *testing.T) {
+	assert.Equal(t, []string{"year:desc"}, meiliSort(repository.ListMangaParams{SortBy: "year", SortOrder: "DESC"}))
+	assert.Equal(t, []string{"title:asc"}, meiliSort(repository.ListMangaParams{SortBy: "title"}))
+	assert.Nil(t, meiliSort(repository.ListMangaParams{SortBy: repository.SortByRelevance}))
+}
+
+func TestMeiliFacetQueries(t *testing.T) {
+	filters, ok := meiliFilters(repository.ListMangaParams{
+		ContentRatings: []domain.ContentRating{domain.ContentRatingSafe},
+		Status:         "ongoing",
+		Genres:         []string{"Action", "Drama"},
+		GenreMode:      repository.TagModeAny,
+	})
+	require.True(t, ok)
+	search := meiliSearchRequest{Query: "berserk", Filter: meiliExprs(filters, "")}
+	queries := meiliFacetQueries(&search, filters)
+
+	// Facets without a filter are counted along with the search, the others without their own filter
+	assert.Equal(t, []string{"content_rating", "year"}, search.Facets)
+	require.Len(t, queries, 2)
+	assert.Equal(t, []string{"genres"}, queries[0].Facets)
+	assert.Equal(t, []string{`content_rating IN ["safe"]`, `status = "ongoing"`}, queries[0].Filter)
+	assert.Equal(t, []string{"status"}, queries[1].Facets)
+	assert.Equal(t, []string{`content_rating IN ["safe"]`, `genres IN ["Action", "Drama"]`}, queries[1].Filter)
+	assert.Equal(t, "berserk", queries[1].Query)
+	assert.Zero(t, queries[1].Limit)
+}
+
+func TestMeiliCursor(t *testing.T) {
+	cursor := encodeMeiliCursor(meiliCursor{Sort: "year:desc", Offset: 40})
+	c, err := decodeMeiliCursor(cursor, "year:desc")
+	require.NoError(t, err)
+	assert.Equal(t, 40, c.Offset)
+
+	for _, tc := range []struct{ cursor, sort string }{
+		{cursor, ""},        // Made for another sort
+		{"not base64!", ""}, // Garbled
+		{encodeMeiliCursor(meiliCursor{Offset: -1}), ""},
+	} {
+		_, err := decodeMeiliCursor(tc.cursor, tc.sort)
+		assert.ErrorIs(t, err, repository.ErrInvalidCursor, tc.cursor)
+	}
+}
+
+// stubMangaRepo finds every manga it is asked for.
+type stubMangaRepo struct {
+	repository.MangaRepository
+}
+
+func (stubMangaRepo) List(ctx context.Context, params repository.ListMangaParams) (*repository.MangaPage, error) {
+	page := &repository.MangaPage{}
+	for _, id := range params.IDs {
+		page.Items = append(page.Items, &domain.Manga{ID: id})
+	}
+	return page, nil
+}
+
+func TestMeilisearchIndex_SearchPages(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	var offsets []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/multi-search", r.URL.Path)
+		var req struct {
+			Queries []meiliSearchRequest `json:"queries"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		query := req.Queries[0]
+		assert.Equal(t, "manga", query.IndexUID)
+		offsets = append(offsets, query.Offset)
+
+		var resp meiliSearchResponse
+		resp.EstimatedTotalHits = len(ids)
+		for _, id := range ids[min(query.Offset, len(ids)):min(query.Offset+query.Limit, len(ids))] {
+			resp.Hits = append(resp.Hits, meiliHit{ID: id})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": []meiliSearchResponse{resp}})
+	}))
+	defer server.Close()
+
+	index, err := NewMeilisearchIndex(server.URL, "", "", stubMangaRepo{})
+	require.NoError(t, err)
+
+	params := repository.ListMangaParams{SearchQuery: "a", Limit: 2, SortBy: "year"}
+	page, err := index.Search(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, ids[1], page.Items[1].ID)
+	require.NotEmpty(t, page.NextCursor)
+
+	params.Cursor = page.NextCursor
+	page, err = index.Search(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, ids[2], page.Items[0].ID)
+	assert.Empty(t, page.NextCursor)
+	assert.Equal(t, []int{0, 2}, offsets)
+
+	// The cursor was made for the year sort
+	params.SortBy = "title"
+	_, err = index.Search(context.Background(), params)
+	assert.ErrorIs(t, err, repository.ErrInvalidCursor)
+}
@@ -0,0 +1,35 @@
+package search
+
+import (
+	"context"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+)
+
+// PostgresIndex searches the manga table itself. Its search_tsv column is kept up to date by
+// triggers, so there is nothing to sync.
+type PostgresIndex struct {
+	mangaRepo repository.MangaRepository
+}
+
+func NewPostgresIndex(mangaRepo repository.MangaRepository) *PostgresIndex {
+	return &PostgresIndex{mangaRepo: mangaRepo}
+}
+
+func (i *PostgresIndex) Search(ctx context.Context, params repository.ListMangaParams) (*repository.MangaPage, error) {
+	return i.mangaRepo.List(ctx, params)
+}
+
+func (i *PostgresIndex) Upsert(ctx context.Context, mangas ...*domain.Manga) error {
+	return nil
+}
+
+func (i *PostgresIndex) Delete(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (i *PostgresIndex) Setup(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,53 @@
+// Package search answers manga text searches. Postgres full-text search is the default engine;
+// an external engine such as Meilisearch takes the load off the database, kept in sync with the
+// catalog by the Indexer.
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+)
+
+// Index is implemented by every search engine.
+type Index interface {
+	// Search lists the manga matching params.SearchQuery and the other filters of params.
+	Search(ctx context.Context, params repository.ListMangaParams) (*repository.MangaPage, error)
+	// Upsert adds the manga to the index, replacing any previous version.
+	Upsert(ctx context.Context, mangas ...*domain.Manga) error
+	// Delete removes the manga from the index. Deleting a missing manga is not an error.
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Setup prepares the index, e.g. its settings. It is run before a full reindex.
+	Setup(ctx context.Context) error
+}
+
+// Supported values for Config.Engine.
+const (
+	EnginePostgres    = "postgres"
+	EngineMeilisearch = "meilisearch"
+)
+
+// Config selects and configures a search engine.
+type Config struct {
+	Engine string
+
+	MeilisearchURL    string
+	MeilisearchAPIKey string
+	MeilisearchIndex  string
+}
+
+// New creates the index of the engine selected by cfg.Engine. An empty engine defaults to
+// Postgres. The manga repository loads the manga an external engine finds.
+func New(cfg Config, mangaRepo repository.MangaRepository) (Index, error) {
+	switch cfg.Engine {
+	case "", EnginePostgres:
+		return NewPostgresIndex(mangaRepo), nil
+	case EngineMeilisearch:
+		return NewMeilisearchIndex(cfg.MeilisearchURL, cfg.MeilisearchAPIKey, cfg.MeilisearchIndex, mangaRepo)
+	default:
+		return nil, fmt.Errorf("unknown search engine %q", cfg.Engine)
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/pkg/broker"
+	"github.com/google/uuid"
+)
+
+// ChapterReviewedEvent is published when a submitted chapter is approved or rejected. The
+// worker emails the uploader the decision.
+const ChapterReviewedEvent = "chapter.reviewed"
+
+// ChapterReviewedPayload is the payload of ChapterReviewedEvent.
+type ChapterReviewedPayload struct {
+	ChapterID     string `json:"chapter_id"`
+	MangaID       string `json:"manga_id"`
+	MangaTitle    string `json:"manga_title"`
+	ChapterNumber string `json:"chapter_number"`
+	Username      string `json:"username"`
+	Email         string `json:"email"`
+	Status        string `json:"status"`
+	Reason        string `json:"reason,omitempty"`
+	Timestamp     string `json:"timestamp"`
+}
+
+// ChapterReviewService lets moderators go through the chapters submitted by uploaders, which
+// only go live once approved.
+type ChapterReviewService struct {
+	chapterRepo  repository.ChapterRepository
+	userRepo     repository.UserRepository
+	revisionRepo repository.RevisionRepository
+	broker       *broker.RabbitMQBroker
+}
+
+func NewChapterReviewService(
+	chapterRepo repository.ChapterRepository,
+	userRepo repository.UserRepository,
+	revisionRepo repository.RevisionRepository,
+	broker *broker.RabbitMQBroker,
+) *ChapterReviewService {
+	return &ChapterReviewService{
+		chapterRepo:  chapterRepo,
+		userRepo:     userRepo,
+		revisionRepo: revisionRepo,
+		broker:       broker,
+	}
+}
+
+func (s *ChapterReviewService) List(ctx context.Context, params repository.ListSubmissionsParams) (*repository.Page[*domain.ChapterSubmission], error) {
+	return s.chapterRepo.ListSubmissions(ctx, params)
+}
+
+// Approve publishes a pending chapter, or schedules it if publishAt is in the future.
+func (s *ChapterReviewService) Approve(ctx context.Context, id, reviewerID uuid.UUID, publishAt *time.Time) (*domain.ChapterSubmission, error) {
+	existing, err := s.chapterRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	publication := approvedPublication(publishAt)
+	submission, err := s.chapterRepo.Approve(ctx, id, reviewerID, publication.PublicationState, publication.PublishAt)
+	if err != nil {
+		return nil, err
+	}
+	if submission.IsPublished() {
+		publishChapterEvent(s.broker, &submission.Chapter)
+	}
+	err = recordChapterRevision(ctx, s.revisionRepo, domain.RevisionUpdate, &submission.Chapter, chapterFieldsOf(existing), chapterFieldsOf(&submission.Chapter))
+	s.notifyUploader(ctx, submission)
+	return submission, err
+}
+
+// approvedPublication returns the publication state and time of a chapter approved to go live
+// at publishAt: scheduled for a time in the future, published otherwise.
+func approvedPublication(publishAt *time.Time) *domain.Chapter {
+	publication := &domain.Chapter{PublicationState: domain.PublicationPublished, PublishAt: publishAt}
+	if publishAt != nil && publishAt.After(time.Now()) {
+		publication.PublicationState = domain.PublicationScheduled
+	}
+	_ = preparePublication(publication) // Only fails for scheduled chapters without a time
+	return publication
+}
+
+// Reject moves a pending chapter to the trash, with a reason for the uploader.
+func (s *ChapterReviewService) Reject(ctx context.Context, id, reviewerID uuid.UUID, reason string) (*domain.ChapterSubmission, error) {
+	submission, err := s.chapterRepo.Reject(ctx, id, reviewerID, reason)
+	if err != nil {
+		return nil, err
+	}
+	err = recordChapterRevision(ctx, s.revisionRepo, domain.RevisionDelete, &submission.Chapter, nil, nil)
+	s.notifyUploader(ctx, submission)
+	return submission, err
+}
+
+// notifyUploader publishes ChapterReviewedEvent in the background. Like other events, a
+// failure is only logged.
+func (s *ChapterReviewService) notifyUploader(ctx context.Context, submission *domain.ChapterSubmission) {
+	if submission.UploadedBy == nil {
+		return
+	}
+	uploader, err := s.userRepo.FindByID(ctx, *submission.UploadedBy)
+	if err != nil {
+		log.Printf("Failed to find the uploader of chapter %s: %v", submission.ID, err)
+		return
+	}
+
+	payload := ChapterReviewedPayload{
+		ChapterID:     submission.ID.String(),
+		MangaID:       submission.MangaID.String(),
+		MangaTitle:    submission.MangaTitle,
+		ChapterNumber: submission.ChapterNumber,
+		Username:      uploader.Username,
+		Email:         uploader.Email,
+		Status:        string(submission.ReviewStatus),
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+	}
+	if submission.RejectionReason != nil {
+		payload.Reason = *submission.RejectionReason
+	}
+	go func() {
+		if err := s.broker.Publish(context.Background(), ChapterReviewedEvent, payload); err != nil {
+			log.Printf("Failed to publish %s event for chapter %s: %v", ChapterReviewedEvent, payload.ChapterID, err)
+		}
+	}()
+}
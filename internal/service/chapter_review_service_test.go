@@ -0,0 +1,25 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApprovedPublication(t *testing.T) {
+	publication := approvedPublication(nil)
+	assert.Equal(t, domain.PublicationPublished, publication.PublicationState)
+	assert.WithinDuration(t, time.Now(), *publication.PublishAt, time.Minute, "published now")
+
+	past := time.Now().Add(-time.Hour)
+	publication = approvedPublication(&past)
+	assert.Equal(t, domain.PublicationPublished, publication.PublicationState)
+	assert.Equal(t, past, *publication.PublishAt, "backdated")
+
+	future := time.Now().Add(time.Hour)
+	publication = approvedPublication(&future)
+	assert.Equal(t, domain.PublicationScheduled, publication.PublicationState)
+	assert.Equal(t, future, *publication.PublishAt)
+}
@@ -1,47 +1,236 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"mime"
 	"mime/multipart"
+	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/0xpanadol/manga/internal/actor"
 	"github.com/0xpanadol/manga/internal/domain"
 	"github.com/0xpanadol/manga/internal/repository"
-	"github.com/0xpanadol/manga/pkg/uploader"
+	"github.com/0xpanadol/manga/pkg/archive"
+	"github.com/0xpanadol/manga/pkg/broker"
+	"github.com/0xpanadol/manga/pkg/storage"
 	"github.com/google/uuid"
 )
 
 type ChapterService struct {
-	chapterRepo repository.ChapterRepository
-	uploader    *uploader.MinioUploader // Add uploader
-
+	chapterRepo   repository.ChapterRepository
+	jobRepo       repository.JobRepository
+	revisionRepo  repository.RevisionRepository
+	storage       storage.Storage
+	broker        *broker.RabbitMQBroker
+	archiveLimits archive.Limits
 }
 
-func NewChapterService(chapterRepo repository.ChapterRepository, uploader *uploader.MinioUploader) *ChapterService {
+func NewChapterService(
+	chapterRepo repository.ChapterRepository,
+	jobRepo repository.JobRepository,
+	revisionRepo repository.RevisionRepository,
+	storage storage.Storage,
+	broker *broker.RabbitMQBroker,
+	archiveLimits archive.Limits,
+) *ChapterService {
 	return &ChapterService{
-		chapterRepo: chapterRepo,
-		uploader:    uploader,
+		chapterRepo:   chapterRepo,
+		jobRepo:       jobRepo,
+		revisionRepo:  revisionRepo,
+		storage:       storage,
+		broker:        broker,
+		archiveLimits: archiveLimits,
 	}
 }
 
+var ErrPublishAtRequired = errors.New("publish_at is required to schedule a chapter")
+
+// ChapterPublishedPayload is published to the broker when a chapter becomes visible to readers.
+type ChapterPublishedPayload struct {
+	ChapterID     string `json:"chapter_id"`
+	MangaID       string `json:"manga_id"`
+	ChapterNumber string `json:"chapter_number"`
+	Timestamp     string `json:"timestamp"`
+}
+
+// Create saves the chapter, uploaded by the user in the context if any.
 func (s *ChapterService) Create(ctx context.Context, chapter *domain.Chapter) error {
-	return s.chapterRepo.Create(ctx, chapter)
+	if userID, ok := actor.UserID(ctx); ok && chapter.UploadedBy == nil {
+		chapter.UploadedBy = &userID
+	}
+	if chapter.PublicationState == "" {
+		chapter.PublicationState = domain.PublicationDraft
+	}
+	if err := preparePublication(chapter); err != nil {
+		return err
+	}
+
+	revise := func(saved *domain.Chapter) (*domain.Revision, error) {
+		return newChapterRevision(ctx, domain.RevisionCreate, saved, nil, chapterFieldsOf(saved))
+	}
+	if err := s.chapterRepo.Create(ctx, chapter, revise); err != nil {
+		return err
+	}
+	if chapter.IsPublished() {
+		publishChapterEvent(s.broker, chapter)
+	}
+	return nil
+}
+
+// Submit saves the chapter as a draft that waits for a moderator's review, see
+// ChapterReviewService. The publication state is decided on approval.
+func (s *ChapterService) Submit(ctx context.Context, chapter *domain.Chapter) error {
+	chapter.PublicationState = domain.PublicationDraft
+	chapter.PublishAt = nil
+	chapter.ReviewStatus = domain.ChapterReviewPending
+	return s.Create(ctx, chapter)
 }
 
 func (s *ChapterService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Chapter, error) {
 	return s.chapterRepo.FindByID(ctx, id)
 }
 
-func (s *ChapterService) ListByMangaID(ctx context.Context, params repository.ListChaptersParams) ([]*domain.Chapter, error) {
+func (s *ChapterService) GetByNumber(ctx context.Context, mangaID uuid.UUID, chapterNumber string) (*domain.Chapter, error) {
+	return s.chapterRepo.FindByMangaAndNumber(ctx, mangaID, chapterNumber)
+}
+
+func (s *ChapterService) ListByMangaID(ctx context.Context, params repository.ListChaptersParams) (*repository.Page[*domain.Chapter], error) {
 	return s.chapterRepo.ListByMangaID(ctx, params)
 }
 
+// Update saves the chapter. Nil pages and an empty publication state keep the chapter's
+// current ones. A chapter.Version other than 0 makes the update conditional on it.
 func (s *ChapterService) Update(ctx context.Context, chapter *domain.Chapter) error {
-	return s.chapterRepo.Update(ctx, chapter)
+	return s.update(ctx, chapter, &domain.Revision{Action: domain.RevisionUpdate})
+}
+
+// update saves the chapter and records the change as the revision.
+func (s *ChapterService) update(ctx context.Context, chapter *domain.Chapter, revision *domain.Revision) error {
+	existing, err := s.chapterRepo.FindByID(ctx, chapter.ID)
+	if err != nil {
+		return err
+	}
+	if chapter.Version != 0 && chapter.Version != existing.Version {
+		return repository.ErrVersionConflict
+	}
+	if chapter.Pages == nil {
+		chapter.Pages = existing.Pages
+	}
+	if chapter.PublicationState == "" {
+		chapter.PublicationState = existing.PublicationState
+		if chapter.PublishAt == nil {
+			chapter.PublishAt = existing.PublishAt
+		}
+	}
+	if err := preparePublication(chapter); err != nil {
+		return err
+	}
+
+	revise := func(saved *domain.Chapter) (*domain.Revision, error) {
+		revision.MangaID, revision.ChapterID = saved.MangaID, &saved.ID
+		return newRevision(ctx, revision, chapterFieldsOf(existing), chapterFieldsOf(saved))
+	}
+	if err := s.chapterRepo.Update(ctx, chapter, revise); err != nil {
+		return err
+	}
+	if chapter.IsPublished() && !existing.IsPublished() {
+		publishChapterEvent(s.broker, chapter)
+	}
+	return nil
+}
+
+// Revert sets the chapter's tracked fields back to what they were after the revision, if the
+// chapter is at the version or that is 0. The revert is recorded as a revision of its own.
+func (s *ChapterService) Revert(ctx context.Context, revision *domain.Revision, version int) (*domain.Chapter, error) {
+	if revision.ChapterID == nil {
+		return nil, repository.ErrRevisionNotFound
+	}
+	if revision.Snapshot == nil {
+		return nil, ErrRevisionNotRevertible
+	}
+	var fields chapterFields
+	if err := readFields(revision, &fields); err != nil {
+		return nil, err
+	}
+
+	chapter, err := s.chapterRepo.FindByID(ctx, *revision.ChapterID)
+	if err != nil {
+		return nil, err
+	}
+	fields.applyTo(chapter)
+	chapter.Version = version
+	if err := s.update(ctx, chapter, &domain.Revision{Action: domain.RevisionRevert, RevertedFrom: &revision.ID}); err != nil {
+		return nil, err
+	}
+	return chapter, nil
+}
+
+// PublishDue makes scheduled chapters whose time has come visible and returns how many there were.
+// It is run periodically by the worker.
+func (s *ChapterService) PublishDue(ctx context.Context) (int, error) {
+	revise := func(saved *domain.Chapter) (*domain.Revision, error) {
+		scheduled := chapterFieldsOf(saved)
+		scheduled.PublicationState = domain.PublicationScheduled
+		return newChapterRevision(ctx, domain.RevisionUpdate, saved, scheduled, chapterFieldsOf(saved))
+	}
+	chapters, err := s.chapterRepo.PublishDue(ctx, time.Now(), revise)
+	if err != nil {
+		return 0, err
+	}
+	for _, chapter := range chapters {
+		publishChapterEvent(s.broker, chapter)
+	}
+	return len(chapters), nil
+}
+
+// preparePublication validates the publication state and records when a chapter was published.
+func preparePublication(chapter *domain.Chapter) error {
+	switch chapter.PublicationState {
+	case domain.PublicationScheduled:
+		if chapter.PublishAt == nil {
+			return ErrPublishAtRequired
+		}
+	case domain.PublicationPublished:
+		if chapter.PublishAt == nil || chapter.PublishAt.After(time.Now()) {
+			now := time.Now().UTC()
+			chapter.PublishAt = &now
+		}
+	}
+	return nil
 }
 
-func (s *ChapterService) Delete(ctx context.Context, id uuid.UUID) error {
-	return s.chapterRepo.Delete(ctx, id)
+// publishChapterEvent publishes chapter.published for the chapter in the background.
+func publishChapterEvent(b *broker.RabbitMQBroker, chapter *domain.Chapter) {
+	payload := ChapterPublishedPayload{
+		ChapterID:     chapter.ID.String(),
+		MangaID:       chapter.MangaID.String(),
+		ChapterNumber: chapter.ChapterNumber,
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+	}
+	go func() {
+		if err := b.Publish(context.Background(), "chapter.published", payload); err != nil {
+			log.Printf("Failed to publish chapter.published event for chapter %s: %v", payload.ChapterID, err)
+		}
+	}()
+}
+
+// Delete deletes the chapter, only if it is at the version unless that is 0.
+func (s *ChapterService) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	chapter, err := s.chapterRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	revise := func(uuid.UUID) (*domain.Revision, error) {
+		return newChapterRevision(ctx, domain.RevisionDelete, chapter, nil, nil)
+	}
+	return s.chapterRepo.Delete(ctx, id, version, revise)
 }
 
 func (s *ChapterService) UploadPages(ctx context.Context, chapterID uuid.UUID, files []*multipart.FileHeader) error {
@@ -54,15 +243,9 @@ func (s *ChapterService) UploadPages(ctx context.Context, chapterID uuid.UUID, f
 	var newPageURLs []string
 	// 2. Iterate over the files and upload them
 	for _, fileHeader := range files {
-		file, err := fileHeader.Open()
+		url, err := s.uploadPage(ctx, chapterID, fileHeader)
 		if err != nil {
-			return fmt.Errorf("failed to open file %s: %w", fileHeader.Filename, err)
-		}
-		defer file.Close()
-
-		url, err := s.uploader.UploadFile(ctx, file, fileHeader.Size, fileHeader.Filename)
-		if err != nil {
-			return fmt.Errorf("failed to upload file %s: %w", fileHeader.Filename, err)
+			return err
 		}
 		newPageURLs = append(newPageURLs, url)
 	}
@@ -71,3 +254,209 @@ func (s *ChapterService) UploadPages(ctx context.Context, chapterID uuid.UUID, f
 	allPages := append(chapter.Pages, newPageURLs...)
 	return s.chapterRepo.UpdatePages(ctx, chapterID, allPages)
 }
+
+// uploadPage stores a single page file and returns its public URL.
+func (s *ChapterService) uploadPage(ctx context.Context, chapterID uuid.UUID, fileHeader *multipart.FileHeader) (string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %s: %w", fileHeader.Filename, err)
+	}
+	defer file.Close()
+
+	ext := filepath.Ext(fileHeader.Filename)
+	contentType := fileHeader.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = mime.TypeByExtension(ext)
+	}
+
+	url, err := s.storage.Put(ctx, pageKey(chapterID, ext), file, fileHeader.Size, contentType)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file %s: %w", fileHeader.Filename, err)
+	}
+	return url, nil
+}
+
+// pageKey generates a unique storage key for a chapter page.
+// Format: chapters/<chapter_id>/<uuid>.<original_extension>
+func pageKey(chapterID uuid.UUID, ext string) string {
+	return fmt.Sprintf("chapters/%s/%s%s", chapterID, uuid.New(), ext)
+}
+
+// ImportArchive replaces the chapter's pages with the images of a CBZ/ZIP archive
+// and returns the number of pages created.
+func (s *ChapterService) ImportArchive(ctx context.Context, chapterID uuid.UUID, r io.ReaderAt, size int64) (int, error) {
+	if _, err := s.chapterRepo.FindByID(ctx, chapterID); err != nil {
+		return 0, err
+	}
+
+	pages, err := archive.ExtractPages(r, size, s.archiveLimits)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.ReplacePages(ctx, chapterID, pages); err != nil {
+		return 0, err
+	}
+	return len(pages), nil
+}
+
+// ReplacePages uploads the given pages in order and swaps them in for the chapter's current pages.
+// Objects belonging to the previous pages are removed afterwards on a best-effort basis.
+func (s *ChapterService) ReplacePages(ctx context.Context, chapterID uuid.UUID, pages []archive.Page) error {
+	chapter, err := s.chapterRepo.FindByID(ctx, chapterID)
+	if err != nil {
+		return err
+	}
+
+	urls := make([]string, 0, len(pages))
+	for _, page := range pages {
+		url, err := s.storage.Put(ctx, pageKey(chapterID, page.Extension()), bytes.NewReader(page.Data), int64(len(page.Data)), page.ContentType)
+		if err != nil {
+			s.deleteObjects(ctx, urls)
+			return fmt.Errorf("failed to upload page %s: %w", page.Name, err)
+		}
+		urls = append(urls, url)
+	}
+
+	if err := s.chapterRepo.UpdatePages(ctx, chapterID, urls); err != nil {
+		s.deleteObjects(ctx, urls)
+		return err
+	}
+
+	s.deleteObjects(ctx, chapter.Pages)
+	return nil
+}
+
+// deleteObjects removes stored objects by their public URLs, logging failures.
+// URLs that don't belong to the storage backend are ignored.
+func (s *ChapterService) deleteObjects(ctx context.Context, urls []string) {
+	for _, url := range urls {
+		key, ok := s.storage.KeyFromURL(url)
+		if !ok {
+			continue
+		}
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Printf("Failed to delete object %s: %v", key, err)
+		}
+	}
+}
+
+// ChapterArchiveJobPayload is stored with a chapter.archive job.
+type ChapterArchiveJobPayload struct {
+	ChapterID  string `json:"chapter_id"`
+	ArchiveKey string `json:"archive_key"`
+}
+
+// ChapterArchiveJobResult is stored once a chapter.archive job completes.
+type ChapterArchiveJobResult struct {
+	PageCount int `json:"page_count"`
+}
+
+// EnqueueArchive stores the uploaded archive and creates a job for the worker to process it.
+// Archives too large for the archive limits are rejected with archive.ErrLimitExceeded up front,
+// rather than stored only for the job to fail.
+func (s *ChapterService) EnqueueArchive(ctx context.Context, chapterID uuid.UUID, file io.Reader, size int64, createdBy uuid.UUID) (*domain.Job, error) {
+	if _, err := s.chapterRepo.FindByID(ctx, chapterID); err != nil {
+		return nil, err
+	}
+	if err := s.archiveLimits.CheckSize(size); err != nil {
+		return nil, err
+	}
+
+	archiveKey := fmt.Sprintf("archives/%s/%s.zip", chapterID, uuid.New())
+	if _, err := s.storage.Put(ctx, archiveKey, file, size, "application/zip"); err != nil {
+		return nil, fmt.Errorf("failed to store archive: %w", err)
+	}
+
+	payload, err := json.Marshal(ChapterArchiveJobPayload{
+		ChapterID:  chapterID.String(),
+		ArchiveKey: archiveKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job payload: %w", err)
+	}
+
+	job := &domain.Job{
+		Type:      domain.JobTypeChapterArchive,
+		Status:    domain.JobStatusPending,
+		Payload:   payload,
+		CreatedBy: &createdBy,
+	}
+	if err := enqueueJob(ctx, s.jobRepo, s.broker, "chapter.archive.uploaded", job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ProcessArchiveJob runs a queued chapter.archive job. It is called by the worker.
+func (s *ChapterService) ProcessArchiveJob(ctx context.Context, jobID uuid.UUID) error {
+	job, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if job.Status != domain.JobStatusPending {
+		return nil // Already picked up, e.g. a redelivered message
+	}
+
+	var payload ChapterArchiveJobPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return failJob(ctx, s.jobRepo, jobID, fmt.Errorf("invalid job payload: %w", err))
+	}
+	chapterID, err := uuid.Parse(payload.ChapterID)
+	if err != nil {
+		s.deleteArchive(ctx, payload.ArchiveKey)
+		return failJob(ctx, s.jobRepo, jobID, fmt.Errorf("invalid chapter ID in job payload: %w", err))
+	}
+
+	started, err := s.jobRepo.Start(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if !started {
+		return nil // Picked up by another consumer in the meantime
+	}
+
+	// The archive is no longer needed once its pages are stored, or once the import failed for good.
+	pageCount, err := s.importStoredArchive(ctx, chapterID, payload.ArchiveKey)
+	s.deleteArchive(ctx, payload.ArchiveKey)
+	if err != nil {
+		return failJob(ctx, s.jobRepo, jobID, err)
+	}
+
+	result, err := json.Marshal(ChapterArchiveJobResult{PageCount: pageCount})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job result: %w", err)
+	}
+	return s.jobRepo.UpdateStatus(ctx, jobID, domain.JobStatusCompleted, result, nil)
+}
+
+// deleteArchive deletes an uploaded archive from storage, logging failures.
+func (s *ChapterService) deleteArchive(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		log.Printf("Failed to delete archive %s: %v", key, err)
+	}
+}
+
+// importStoredArchive copies an archive from storage to a temporary file, which provides the
+// random access the ZIP reader needs, and imports it.
+func (s *ChapterService) importStoredArchive(ctx context.Context, chapterID uuid.UUID, archiveKey string) (int, error) {
+	rc, err := s.storage.Get(ctx, archiveKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch archive: %w", err)
+	}
+	defer rc.Close()
+
+	tmp, err := os.CreateTemp("", "chapter-archive-*.zip")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, rc)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download archive: %w", err)
+	}
+
+	return s.ImportArchive(ctx, chapterID, tmp, size)
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/pkg/imaging"
+	"github.com/0xpanadol/manga/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Thumbnails are scaled to fit within these bounds, which suit list and grid views.
+const (
+	coverThumbnailWidth  = 320
+	coverThumbnailHeight = 480
+)
+
+var coverExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+type CoverService struct {
+	coverRepo repository.CoverRepository
+	mangaRepo repository.MangaRepository
+	storage   storage.Storage
+	redis     *redis.Client
+}
+
+func NewCoverService(
+	coverRepo repository.CoverRepository,
+	mangaRepo repository.MangaRepository,
+	storage storage.Storage,
+	redisClient *redis.Client,
+) *CoverService {
+	return &CoverService{
+		coverRepo: coverRepo,
+		mangaRepo: mangaRepo,
+		storage:   storage,
+		redis:     redisClient,
+	}
+}
+
+// CoverUpload describes a new cover image.
+type CoverUpload struct {
+	Volume   *string
+	Language *string
+	Primary  bool // The manga's first cover is always primary
+}
+
+// Upload validates and stores a cover image along with a JPEG thumbnail.
+func (s *CoverService) Upload(ctx context.Context, mangaID uuid.UUID, r io.Reader, upload CoverUpload) (*domain.Cover, error) {
+	if _, err := s.mangaRepo.FindByID(ctx, mangaID); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cover: %w", err)
+	}
+	img, contentType, err := imaging.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	ext, ok := coverExtensions[contentType]
+	if !ok {
+		return nil, imaging.ErrUnsupportedImage
+	}
+	thumbnail, err := imaging.Thumbnail(img, coverThumbnailWidth, coverThumbnailHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	// Format: covers/<manga_id>/<uuid>.<ext>, with the thumbnail alongside as <uuid>-thumb.jpg
+	name := uuid.New().String()
+	url, err := s.storage.Put(ctx, fmt.Sprintf("covers/%s/%s%s", mangaID, name, ext), bytes.NewReader(data), int64(len(data)), contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload cover: %w", err)
+	}
+	thumbnailURL, err := s.storage.Put(ctx, fmt.Sprintf("covers/%s/%s-thumb.jpg", mangaID, name), bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/jpeg")
+	if err != nil {
+		s.deleteObjects(ctx, url)
+		return nil, fmt.Errorf("failed to upload cover thumbnail: %w", err)
+	}
+
+	cover := &domain.Cover{
+		MangaID:      mangaID,
+		URL:          url,
+		ThumbnailURL: thumbnailURL,
+		Volume:       upload.Volume,
+		Language:     upload.Language,
+		IsPrimary:    upload.Primary,
+	}
+	if err := s.coverRepo.Create(ctx, cover); err != nil {
+		s.deleteObjects(ctx, url, thumbnailURL)
+		return nil, err
+	}
+
+	if cover.IsPrimary {
+		s.invalidateManga(ctx, mangaID)
+	}
+	return cover, nil
+}
+
+func (s *CoverService) ListByMangaID(ctx context.Context, mangaID uuid.UUID) ([]*domain.Cover, error) {
+	if _, err := s.mangaRepo.FindByID(ctx, mangaID); err != nil {
+		return nil, err
+	}
+	return s.coverRepo.ListByMangaID(ctx, mangaID)
+}
+
+// SetPrimary makes the cover the one shown for the manga.
+func (s *CoverService) SetPrimary(ctx context.Context, mangaID, coverID uuid.UUID) error {
+	if err := s.coverRepo.SetPrimary(ctx, mangaID, coverID); err != nil {
+		return err
+	}
+	s.invalidateManga(ctx, mangaID)
+	return nil
+}
+
+// Delete removes a cover and its stored images. Deleting the primary cover promotes the newest remaining one.
+func (s *CoverService) Delete(ctx context.Context, mangaID, coverID uuid.UUID) error {
+	cover, err := s.coverRepo.FindByID(ctx, coverID)
+	if err != nil {
+		return err
+	}
+	if cover.MangaID != mangaID {
+		return repository.ErrCoverNotFound
+	}
+
+	if err := s.coverRepo.Delete(ctx, mangaID, coverID); err != nil {
+		return err
+	}
+	s.deleteObjects(ctx, cover.URL, cover.ThumbnailURL)
+
+	if cover.IsPrimary {
+		s.invalidateManga(ctx, mangaID)
+	}
+	return nil
+}
+
+// invalidateManga drops the cached manga, whose cover image URL may have changed.
+func (s *CoverService) invalidateManga(ctx context.Context, mangaID uuid.UUID) {
+	key := getMangaCacheKey(mangaID)
+	log.Println("CACHE INVALIDATED for key:", key)
+	s.redis.Del(ctx, key) // We can ignore the error here for simplicity
+}
+
+// deleteObjects removes stored images by their public URLs, logging failures.
+func (s *CoverService) deleteObjects(ctx context.Context, urls ...string) {
+	for _, url := range urls {
+		key, ok := s.storage.KeyFromURL(url)
+		if !ok {
+			continue
+		}
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Printf("Failed to delete object %s: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+)
+
+// maxBibliography caps the number of works returned with a creator.
+const maxBibliography = 500
+
+type CreatorService struct {
+	creatorRepo repository.CreatorRepository
+	mangaRepo   repository.MangaRepository
+}
+
+func NewCreatorService(creatorRepo repository.CreatorRepository, mangaRepo repository.MangaRepository) *CreatorService {
+	return &CreatorService{
+		creatorRepo: creatorRepo,
+		mangaRepo:   mangaRepo,
+	}
+}
+
+// CreatorProfile is a creator together with their bibliography.
+type CreatorProfile struct {
+	Creator *domain.Creator `json:"creator"`
+	Works   []*domain.Manga `json:"works"`
+}
+
+func (s *CreatorService) Create(ctx context.Context, creator *domain.Creator) error {
+	return s.creatorRepo.Create(ctx, creator)
+}
+
+func (s *CreatorService) List(ctx context.Context, params repository.ListCreatorsParams) ([]*domain.Creator, error) {
+	return s.creatorRepo.List(ctx, params)
+}
+
+// GetProfile returns the creator and every manga with one of the content ratings they are
+// credited on, by title.
+func (s *CreatorService) GetProfile(ctx context.Context, id uuid.UUID, ratings []domain.ContentRating) (*CreatorProfile, error) {
+	creator, err := s.creatorRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	works, err := s.mangaRepo.List(ctx, repository.ListMangaParams{
+		CreatorID:      id,
+		ContentRatings: ratings,
+		Limit:          maxBibliography,
+		SortBy:         "title",
+		SortOrder:      "asc",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CreatorProfile{Creator: creator, Works: works.Items}, nil
+}
+
+// authorSeparator splits credit lines like "Tsugumi Ohba, Takeshi Obata" or "CLAMP & Someone".
+// It must match the split in migration 000013_create_creators.
+var authorSeparator = regexp.MustCompile(`(?i)\s*(?:,|&|/|;|\s+and\s+)\s*`)
+
+// creatorsFromAuthor turns a plain author string into credits. A single name is credited
+// for story and art; otherwise the first name is the story and the rest the art.
+func creatorsFromAuthor(author string) []domain.MangaCreator {
+	var names []string
+	for _, name := range authorSeparator.Split(author, -1) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	var credits []domain.MangaCreator
+	switch len(names) {
+	case 0:
+	case 1:
+		credits = append(credits,
+			domain.MangaCreator{Name: names[0], Role: domain.CreatorRoleStory},
+			domain.MangaCreator{Name: names[0], Role: domain.CreatorRoleArt},
+		)
+	default:
+		credits = append(credits, domain.MangaCreator{Name: names[0], Role: domain.CreatorRoleStory})
+		for _, name := range names[1:] {
+			credits = append(credits, domain.MangaCreator{Name: name, Role: domain.CreatorRoleArt})
+		}
+	}
+	return credits
+}
+
+// maxCreditLine is the length of the manga.author column.
+const maxCreditLine = 255
+
+// creditLine joins the distinct creator names, story first, into the manga's author string.
+// Names that don't fit are summarized as "et al.".
+func creditLine(credits []domain.MangaCreator) string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, role := range []domain.CreatorRole{domain.CreatorRoleStory, domain.CreatorRoleArt} {
+		for _, credit := range credits {
+			key := strings.ToLower(credit.Name)
+			if credit.Role == role && !seen[key] {
+				seen[key] = true
+				names = append(names, credit.Name)
+			}
+		}
+	}
+
+	line := strings.Join(names, ", ")
+	for len(line) > maxCreditLine && len(names) > 1 {
+		names = names[:len(names)-1]
+		line = strings.Join(names, ", ") + " et al."
+	}
+	return line
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatorsFromAuthor(t *testing.T) {
+	story, art := domain.CreatorRoleStory, domain.CreatorRoleArt
+
+	tests := []struct {
+		author string
+		want   []domain.MangaCreator
+	}{
+		{"Eiichiro Oda", []domain.MangaCreator{{Name: "Eiichiro Oda", Role: story}, {Name: "Eiichiro Oda", Role: art}}},
+		{"Tsugumi Ohba, Takeshi Obata", []domain.MangaCreator{{Name: "Tsugumi Ohba", Role: story}, {Name: "Takeshi Obata", Role: art}}},
+		{"ONE & Yusuke Murata", []domain.MangaCreator{{Name: "ONE", Role: story}, {Name: "Yusuke Murata", Role: art}}},
+		{"Riichiro Inagaki AND Boichi / Someone", []domain.MangaCreator{
+			{Name: "Riichiro Inagaki", Role: story}, {Name: "Boichi", Role: art}, {Name: "Someone", Role: art},
+		}},
+		{"Alexandre Dumas", []domain.MangaCreator{{Name: "Alexandre Dumas", Role: story}, {Name: "Alexandre Dumas", Role: art}}},
+		{"  ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.author, func(t *testing.T) {
+			assert.Equal(t, tt.want, creatorsFromAuthor(tt.author))
+		})
+	}
+}
+
+func TestCreditLine(t *testing.T) {
+	assert.Equal(t, "Eiichiro Oda", creditLine(creatorsFromAuthor("Eiichiro Oda")))
+	assert.Equal(t, "Tsugumi Ohba, Takeshi Obata", creditLine([]domain.MangaCreator{
+		{Name: "Takeshi Obata", Role: domain.CreatorRoleArt},
+		{Name: "Tsugumi Ohba", Role: domain.CreatorRoleStory},
+	}))
+
+	var many []domain.MangaCreator
+	for i := 0; i < 20; i++ {
+		many = append(many, domain.MangaCreator{Name: strings.Repeat("x", 20) + string(rune('a'+i)), Role: domain.CreatorRoleArt})
+	}
+	line := creditLine(many)
+	assert.LessOrEqual(t, len(line), maxCreditLine)
+	assert.True(t, strings.HasSuffix(line, " et al."))
+}
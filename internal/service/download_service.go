@@ -0,0 +1,407 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/pkg/archive"
+	"github.com/0xpanadol/manga/pkg/broker"
+	"github.com/0xpanadol/manga/pkg/comicbook"
+	"github.com/0xpanadol/manga/pkg/storage"
+	"github.com/google/uuid"
+)
+
+// downloadURLExpiry is how long links to cached volume bundles stay valid.
+const downloadURLExpiry = 24 * time.Hour
+
+var (
+	ErrNothingToDownload = errors.New("there are no pages to download")
+	ErrPageUnavailable   = errors.New("page is not held in object storage")
+)
+
+type DownloadService struct {
+	chapterRepo repository.ChapterRepository
+	mangaRepo   repository.MangaRepository
+	jobRepo     repository.JobRepository
+	storage     storage.Storage
+	broker      *broker.RabbitMQBroker
+}
+
+func NewDownloadService(
+	chapterRepo repository.ChapterRepository,
+	mangaRepo repository.MangaRepository,
+	jobRepo repository.JobRepository,
+	storage storage.Storage,
+	broker *broker.RabbitMQBroker,
+) *DownloadService {
+	return &DownloadService{
+		chapterRepo: chapterRepo,
+		mangaRepo:   mangaRepo,
+		jobRepo:     jobRepo,
+		storage:     storage,
+		broker:      broker,
+	}
+}
+
+// Download is a book that is ready to be streamed to a client.
+type Download struct {
+	Filename    string
+	ContentType string
+	format      string
+	meta        comicbook.Metadata
+	pages       []comicbook.Page
+}
+
+// Write assembles the book and streams it to w.
+func (d *Download) Write(ctx context.Context, w io.Writer) error {
+	return comicbook.Write(ctx, w, d.format, d.meta, d.pages)
+}
+
+// ChapterDownload prepares a single chapter for download in the given format.
+// All checks that can fail are done here, before anything is written to the client.
+// Chapters that aren't published are reported as not found unless includeUnpublished is set,
+// and chapters of manga without one of the content ratings are restricted.
+func (s *DownloadService) ChapterDownload(ctx context.Context, chapterID uuid.UUID, format string, includeUnpublished bool, ratings []domain.ContentRating) (*Download, error) {
+	chapter, err := s.chapterRepo.FindByID(ctx, chapterID)
+	if err != nil {
+		return nil, err
+	}
+	if !chapter.IsPublished() && !includeUnpublished {
+		return nil, repository.ErrChapterNotFound
+	}
+	manga, err := s.mangaRepo.FindByID(ctx, chapter.MangaID)
+	if err != nil {
+		return nil, err
+	}
+	if !manga.ContentRating.AllowedBy(ratings) {
+		return nil, ErrContentRestricted
+	}
+
+	meta := comicbook.Metadata{
+		Identifier: "urn:uuid:" + chapter.ID.String(),
+		Series:     manga.Title,
+		Number:     chapter.ChapterNumber,
+		Writer:     manga.Author,
+		Summary:    manga.Description,
+	}
+	if chapter.Title != nil {
+		meta.Title = *chapter.Title
+	}
+	if chapter.Volume != nil {
+		meta.Volume = *chapter.Volume
+	}
+
+	return s.prepare(format, meta, chapter.Pages)
+}
+
+func (s *DownloadService) prepare(format string, meta comicbook.Metadata, urls []string) (*Download, error) {
+	if format != comicbook.FormatCBZ && format != comicbook.FormatEPUB && format != comicbook.FormatPDF {
+		return nil, fmt.Errorf("%w: %s", comicbook.ErrUnsupportedFormat, format)
+	}
+	if len(urls) == 0 {
+		return nil, ErrNothingToDownload
+	}
+
+	pages := make([]comicbook.Page, 0, len(urls))
+	for _, pageURL := range urls {
+		page, err := s.storedPage(pageURL)
+		if err != nil {
+			return nil, err
+		}
+		// The standard library cannot decode WebP, so it can't be re-encoded for a PDF.
+		if format == comicbook.FormatPDF && page.ContentType == "image/webp" {
+			return nil, fmt.Errorf("%w: %s", comicbook.ErrUnsupportedImage, page.Name)
+		}
+		pages = append(pages, page)
+	}
+
+	return &Download{
+		Filename:    downloadFilename(meta, format),
+		ContentType: comicbook.ContentType(format),
+		format:      format,
+		meta:        meta,
+		pages:       pages,
+	}, nil
+}
+
+// storedPage maps a page URL to a lazily opened object in storage.
+func (s *DownloadService) storedPage(pageURL string) (comicbook.Page, error) {
+	key, ok := s.storage.KeyFromURL(pageURL)
+	if !ok {
+		return comicbook.Page{}, fmt.Errorf("%w: %s", ErrPageUnavailable, pageURL)
+	}
+	name := path.Base(key)
+	return comicbook.Page{
+		Name:        name,
+		ContentType: pageContentType(path.Ext(name)),
+		Open: func(ctx context.Context) (io.ReadCloser, error) {
+			return s.storage.Get(ctx, key)
+		},
+	}, nil
+}
+
+// pageContentType maps a page's extension to its MIME type. WebP is listed explicitly
+// because not every system mime database knows about it.
+func pageContentType(ext string) string {
+	ext = strings.ToLower(ext)
+	if ext == ".webp" {
+		return "image/webp"
+	}
+	return mime.TypeByExtension(ext)
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^\p{L}\p{N} ._-]+`)
+
+// downloadFilename builds e.g. "Berserk Vol. 1 Ch. 3.cbz", without characters that are unsafe in file names.
+func downloadFilename(meta comicbook.Metadata, format string) string {
+	title := meta.Series
+	if meta.Volume != "" {
+		title += " Vol. " + meta.Volume
+	}
+	if meta.Number != "" {
+		title += " Ch. " + meta.Number
+	}
+	title = strings.TrimSpace(unsafeFilenameChars.ReplaceAllString(title, "_"))
+	if title == "" {
+		title = "download"
+	}
+	return title + "." + format
+}
+
+// VolumeBundleJobPayload is stored with a volume.bundle job.
+type VolumeBundleJobPayload struct {
+	MangaID string `json:"manga_id"`
+	Volume  string `json:"volume"`
+	Format  string `json:"format"`
+}
+
+// VolumeBundleJobResult is stored once a volume.bundle job completes.
+type VolumeBundleJobResult struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// VolumeDownload is either a link to an up-to-date cached bundle or the job building it.
+type VolumeDownload struct {
+	URL       string      `json:"url,omitempty"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+	Job       *domain.Job `json:"job,omitempty"`
+}
+
+// RequestVolume returns a link to the volume bundle if a cached copy is still current,
+// and otherwise queues a job for the worker to build it. Manga without one of the content ratings
+// are restricted.
+func (s *DownloadService) RequestVolume(ctx context.Context, mangaID uuid.UUID, volume, format string, createdBy uuid.UUID, ratings []domain.ContentRating) (*VolumeDownload, error) {
+	manga, chapters, err := s.loadVolume(ctx, mangaID, volume)
+	if err != nil {
+		return nil, err
+	}
+	if !manga.ContentRating.AllowedBy(ratings) {
+		return nil, ErrContentRestricted
+	}
+	// Validate up front so the job can't fail on something the client can fix.
+	if _, err := s.prepare(format, comicbook.Metadata{}, volumePages(chapters)); err != nil {
+		return nil, err
+	}
+
+	key := volumeBundleKey(manga, volume, format, chapters)
+	_, err = s.storage.Stat(ctx, key)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, fmt.Errorf("failed to check cached bundle: %w", err)
+	}
+	if err == nil {
+		signedURL, err := s.storage.SignedURL(ctx, key, downloadURLExpiry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign bundle URL: %w", err)
+		}
+		expiresAt := time.Now().Add(downloadURLExpiry).UTC()
+		return &VolumeDownload{URL: signedURL, ExpiresAt: &expiresAt}, nil
+	}
+
+	payload, err := json.Marshal(VolumeBundleJobPayload{
+		MangaID: mangaID.String(),
+		Volume:  volume,
+		Format:  format,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job payload: %w", err)
+	}
+
+	job := &domain.Job{
+		Type:      domain.JobTypeVolumeBundle,
+		Status:    domain.JobStatusPending,
+		Payload:   payload,
+		CreatedBy: &createdBy,
+	}
+	if err := enqueueJob(ctx, s.jobRepo, s.broker, "volume.bundle.requested", job); err != nil {
+		return nil, err
+	}
+	return &VolumeDownload{Job: job}, nil
+}
+
+// ProcessVolumeJob builds a volume bundle and caches it in storage. It is called by the worker.
+func (s *DownloadService) ProcessVolumeJob(ctx context.Context, jobID uuid.UUID) error {
+	job, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if job.Status != domain.JobStatusPending {
+		return nil // Already picked up, e.g. a redelivered message
+	}
+
+	var payload VolumeBundleJobPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return failJob(ctx, s.jobRepo, jobID, fmt.Errorf("invalid job payload: %w", err))
+	}
+	mangaID, err := uuid.Parse(payload.MangaID)
+	if err != nil {
+		return failJob(ctx, s.jobRepo, jobID, fmt.Errorf("invalid manga ID in job payload: %w", err))
+	}
+
+	started, err := s.jobRepo.Start(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if !started {
+		return nil // Picked up by another consumer in the meantime
+	}
+
+	key, err := s.buildVolume(ctx, mangaID, payload)
+	if err != nil {
+		return failJob(ctx, s.jobRepo, jobID, err)
+	}
+
+	signedURL, err := s.storage.SignedURL(ctx, key, downloadURLExpiry)
+	if err != nil {
+		return failJob(ctx, s.jobRepo, jobID, fmt.Errorf("failed to sign bundle URL: %w", err))
+	}
+	result, err := json.Marshal(VolumeBundleJobResult{
+		URL:       signedURL,
+		ExpiresAt: time.Now().Add(downloadURLExpiry).UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job result: %w", err)
+	}
+	return s.jobRepo.UpdateStatus(ctx, jobID, domain.JobStatusCompleted, result, nil)
+}
+
+// buildVolume caches a bundle of the volume as it is now and returns its key, deleting the bundles
+// of earlier contents. It writes the bundle to a temporary file first, since storage needs the
+// size up front.
+func (s *DownloadService) buildVolume(ctx context.Context, mangaID uuid.UUID, payload VolumeBundleJobPayload) (string, error) {
+	manga, chapters, err := s.loadVolume(ctx, mangaID, payload.Volume)
+	if err != nil {
+		return "", err
+	}
+
+	meta := comicbook.Metadata{
+		Identifier: "urn:uuid:" + uuid.NewSHA1(mangaID, []byte("volume:"+payload.Volume)).String(),
+		Series:     manga.Title,
+		Volume:     payload.Volume,
+		Writer:     manga.Author,
+		Summary:    manga.Description,
+	}
+	download, err := s.prepare(payload.Format, meta, volumePages(chapters))
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp("", "volume-bundle-*."+payload.Format)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := download.Write(ctx, tmp); err != nil {
+		return "", fmt.Errorf("failed to build bundle: %w", err)
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", fmt.Errorf("failed to build bundle: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to build bundle: %w", err)
+	}
+
+	key := volumeBundleKey(manga, payload.Volume, payload.Format, chapters)
+	if _, err := s.storage.Put(ctx, key, tmp, size, download.ContentType); err != nil {
+		return "", fmt.Errorf("failed to store bundle: %w", err)
+	}
+	s.deleteStaleBundles(ctx, key)
+	return key, nil
+}
+
+// deleteStaleBundles deletes the bundles of the volume in the same format as the bundle at key,
+// built for earlier contents, logging failures.
+func (s *DownloadService) deleteStaleBundles(ctx context.Context, key string) {
+	objects, err := s.storage.List(ctx, path.Dir(key)+"/")
+	if err != nil {
+		log.Printf("Failed to list bundles of %s: %v", path.Dir(key), err)
+		return
+	}
+	for _, object := range objects {
+		if object.Key == key || path.Ext(object.Key) != path.Ext(key) {
+			continue
+		}
+		if err := s.storage.Delete(ctx, object.Key); err != nil {
+			log.Printf("Failed to delete stale bundle %s: %v", object.Key, err)
+		}
+	}
+}
+
+// loadVolume returns the manga and the volume's chapters in natural chapter order.
+func (s *DownloadService) loadVolume(ctx context.Context, mangaID uuid.UUID, volume string) (*domain.Manga, []*domain.Chapter, error) {
+	manga, err := s.mangaRepo.FindByID(ctx, mangaID)
+	if err != nil {
+		return nil, nil, err
+	}
+	chapters, err := s.chapterRepo.ListByVolume(ctx, mangaID, volume)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(chapters) == 0 {
+		return nil, nil, ErrNothingToDownload
+	}
+	sort.SliceStable(chapters, func(i, j int) bool {
+		return archive.NaturalLess(chapters[i].ChapterNumber, chapters[j].ChapterNumber)
+	})
+	return manga, chapters, nil
+}
+
+func volumePages(chapters []*domain.Chapter) []string {
+	var pages []string
+	for _, chapter := range chapters {
+		pages = append(pages, chapter.Pages...)
+	}
+	return pages
+}
+
+// volumeBundleKey is where a bundle of the volume's chapters, as loaded by loadVolume, is cached.
+// The key holds a digest of the manga's and chapters' versions, so it changes with anything in the
+// bundle: chapters edited, or added to or taken out of the volume by a deletion, a change of
+// publication state or a review, none of which shows in every updated_at.
+// Format: downloads/<manga_id>/volume-<volume>/<digest>.<format>
+func volumeBundleKey(manga *domain.Manga, volume, format string, chapters []*domain.Chapter) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d", manga.Version)
+	for _, chapter := range chapters {
+		fmt.Fprintf(h, ",%s:%d", chapter.ID, chapter.Version)
+	}
+	return fmt.Sprintf("downloads/%s/volume-%s/%x.%s", manga.ID, url.PathEscape(volume), h.Sum(nil)[:16], format)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errJobQueued is returned by stubJobRepo.Create, so no job event is published.
+var errJobQueued = errors.New("job queued")
+
+// stubVolumeRepo serves the chapters currently in the volume.
+type stubVolumeRepo struct {
+	repository.ChapterRepository
+	chapters []*domain.Chapter
+}
+
+func (r *stubVolumeRepo) ListByVolume(ctx context.Context, mangaID uuid.UUID, volume string) ([]*domain.Chapter, error) {
+	return slices.Clone(r.chapters), nil
+}
+
+type stubMangaRepo struct {
+	repository.MangaRepository
+	manga *domain.Manga
+}
+
+func (r stubMangaRepo) FindByID(ctx context.Context, id uuid.UUID) (*domain.Manga, error) {
+	return r.manga, nil
+}
+
+type stubJobRepo struct {
+	repository.JobRepository
+	jobs map[uuid.UUID]*domain.Job
+}
+
+func (r *stubJobRepo) Create(ctx context.Context, job *domain.Job) error {
+	return errJobQueued
+}
+
+func (r *stubJobRepo) FindByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	return r.jobs[id], nil
+}
+
+func (r *stubJobRepo) Start(ctx context.Context, id uuid.UUID) (bool, error) {
+	r.jobs[id].Status = domain.JobStatusRunning
+	return true, nil
+}
+
+func (r *stubJobRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.JobStatus, result json.RawMessage, errMsg *string) error {
+	r.jobs[id].Status, r.jobs[id].Result = status, result
+	return nil
+}
+
+func TestDownloadService_VolumeBundleFollowsChapters(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewMemoryStorage()
+	manga := &domain.Manga{ID: uuid.New(), Title: "Berserk", ContentRating: domain.ContentRatingSafe, Version: 1}
+	volume := "1"
+
+	var chapters []*domain.Chapter
+	for _, number := range []string{"1", "2"} {
+		pageURL, err := store.Put(ctx, "pages/"+number+".jpg", strings.NewReader("page "+number), 6, "image/jpeg")
+		require.NoError(t, err)
+		chapters = append(chapters, &domain.Chapter{ID: uuid.New(), MangaID: manga.ID, ChapterNumber: number, Volume: &volume, Pages: []string{pageURL}, Version: 1})
+	}
+	chapterRepo := &stubVolumeRepo{chapters: chapters}
+	jobRepo := &stubJobRepo{jobs: make(map[uuid.UUID]*domain.Job)}
+	s := NewDownloadService(chapterRepo, stubMangaRepo{manga: manga}, jobRepo, store, nil)
+
+	build := func() {
+		payload, err := json.Marshal(VolumeBundleJobPayload{MangaID: manga.ID.String(), Volume: volume, Format: "cbz"})
+		require.NoError(t, err)
+		job := &domain.Job{ID: uuid.New(), Type: domain.JobTypeVolumeBundle, Status: domain.JobStatusPending, Payload: payload}
+		jobRepo.jobs[job.ID] = job
+		require.NoError(t, s.ProcessVolumeJob(ctx, job.ID))
+		require.Equal(t, domain.JobStatusCompleted, job.Status)
+	}
+	request := func() (*VolumeDownload, error) {
+		return s.RequestVolume(ctx, manga.ID, volume, "cbz", uuid.New(), []domain.ContentRating{domain.ContentRatingSafe})
+	}
+
+	_, err := request()
+	require.ErrorIs(t, err, errJobQueued, "nothing cached yet")
+	build()
+	download, err := request()
+	require.NoError(t, err)
+	require.NotEmpty(t, download.URL)
+
+	// A chapter taken out of the volume, e.g. trashed, leaves its updated_at as it was
+	chapterRepo.chapters = chapters[:1]
+	_, err = request()
+	require.ErrorIs(t, err, errJobQueued, "the cached bundle still holds the removed chapter")
+
+	build()
+	rebuilt, err := request()
+	require.NoError(t, err)
+	assert.NotEqual(t, download.URL, rebuilt.URL)
+
+	bundles, err := store.List(ctx, "downloads/")
+	require.NoError(t, err)
+	require.Len(t, bundles, 1, "the stale bundle is deleted")
+	rc, err := store.Get(ctx, bundles[0].Key)
+	require.NoError(t, err)
+	defer rc.Close()
+	var data bytes.Buffer
+	_, err = data.ReadFrom(rc)
+	require.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(data.Bytes()), int64(data.Len()))
+	require.NoError(t, err)
+	assert.Len(t, zr.File, 2, "ComicInfo.xml and the remaining chapter's page")
+}
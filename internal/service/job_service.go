@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/pkg/broker"
+	"github.com/google/uuid"
+)
+
+type JobService struct {
+	jobRepo repository.JobRepository
+}
+
+func NewJobService(jobRepo repository.JobRepository) *JobService {
+	return &JobService{jobRepo: jobRepo}
+}
+
+func (s *JobService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	return s.jobRepo.FindByID(ctx, id)
+}
+
+// JobQueuedPayload is published to the broker when a job is handed off to the worker.
+type JobQueuedPayload struct {
+	JobID     string `json:"job_id"`
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+}
+
+// enqueueJob stores a pending job and notifies the worker through the given queue.
+func enqueueJob(ctx context.Context, jobRepo repository.JobRepository, b *broker.RabbitMQBroker, queue string, job *domain.Job) error {
+	if err := jobRepo.Create(ctx, job); err != nil {
+		return err
+	}
+
+	event := JobQueuedPayload{
+		JobID:     job.ID.String(),
+		Type:      string(job.Type),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+	go func() {
+		if err := b.Publish(context.Background(), queue, event); err != nil {
+			log.Printf("Failed to publish %s event for job %s: %v", queue, job.ID, err)
+		}
+	}()
+	return nil
+}
+
+// failJob marks a job as failed with the cause as its error message, and returns the cause.
+func failJob(ctx context.Context, jobRepo repository.JobRepository, jobID uuid.UUID, cause error) error {
+	msg := cause.Error()
+	if err := jobRepo.UpdateStatus(ctx, jobID, domain.JobStatusFailed, nil, &msg); err != nil {
+		return err
+	}
+	return cause
+}
@@ -2,33 +2,73 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"time"
 
 	"github.com/0xpanadol/manga/internal/domain"
 	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/internal/search"
+	"github.com/0xpanadol/manga/pkg/broker"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
 const (
-	cacheDuration = 5 * time.Minute
+	cacheDuration       = 5 * time.Minute
+	facetsCacheDuration = time.Minute // Facets aren't invalidated when manga change
 )
 
+var (
+	ErrContentRestricted = errors.New("this manga's content rating is hidden by your preferences")
+	ErrSelfRelation      = errors.New("a manga cannot be related to itself")
+	// ErrRevisionNotRevertible is returned for deletions, which are undone from the trash.
+	ErrRevisionNotRevertible = errors.New("a deletion can't be reverted, restore from the trash instead")
+)
+
+// Manga events, published when a manga is created, changed or deleted. The worker keeps the
+// search index in sync from them.
+const (
+	MangaCreatedEvent = "manga.created"
+	MangaUpdatedEvent = "manga.updated"
+	MangaDeletedEvent = "manga.deleted"
+)
+
+// MangaChangedPayload is the payload of the manga events.
+type MangaChangedPayload struct {
+	MangaID   string `json:"manga_id"`
+	Timestamp string `json:"timestamp"`
+}
+
 type MangaService struct {
-	mangaRepo repository.MangaRepository
-	redis     *redis.Client
+	mangaRepo    repository.MangaRepository
+	creatorRepo  repository.CreatorRepository
+	revisionRepo repository.RevisionRepository
+	searchIndex  search.Index
+	broker       *broker.RabbitMQBroker
+	redis        *redis.Client
 }
 
 func NewMangaService(
 	mangaRepo repository.MangaRepository,
+	creatorRepo repository.CreatorRepository,
+	revisionRepo repository.RevisionRepository,
+	searchIndex search.Index,
+	broker *broker.RabbitMQBroker,
 	redisClient *redis.Client,
 ) *MangaService {
 	return &MangaService{
-		mangaRepo: mangaRepo,
-		redis:     redisClient,
+		mangaRepo:    mangaRepo,
+		creatorRepo:  creatorRepo,
+		revisionRepo: revisionRepo,
+		searchIndex:  searchIndex,
+		broker:       broker,
+		redis:        redisClient,
 	}
 }
 
@@ -38,7 +78,45 @@ func getMangaCacheKey(id uuid.UUID) string {
 }
 
 func (s *MangaService) Create(ctx context.Context, manga *domain.Manga) error {
-	return s.mangaRepo.Create(ctx, manga)
+	if manga.ContentRating == "" {
+		manga.ContentRating = domain.ContentRatingSafe
+	}
+	if manga.SearchLanguage == "" {
+		manga.SearchLanguage = domain.DefaultSearchLanguage
+	}
+	if err := s.prepareCreators(ctx, manga); err != nil {
+		return err
+	}
+	revise := func(saved *domain.Manga) (*domain.Revision, error) {
+		return newMangaRevision(ctx, domain.RevisionCreate, saved.ID, nil, mangaFieldsOf(saved))
+	}
+	if err := s.mangaRepo.Create(ctx, manga, revise); err != nil {
+		return err
+	}
+	publishMangaEvent(s.broker, MangaCreatedEvent, manga.ID)
+	return nil
+}
+
+// prepareCreators keeps the author string and the credits in sync. Without explicit credits the
+// author string is split into creators; otherwise the author string is derived from the credits.
+func (s *MangaService) prepareCreators(ctx context.Context, manga *domain.Manga) error {
+	if len(manga.Creators) == 0 {
+		manga.Creators = creatorsFromAuthor(manga.Author)
+		return nil
+	}
+
+	for i, credit := range manga.Creators {
+		if credit.CreatorID == uuid.Nil {
+			continue
+		}
+		creator, err := s.creatorRepo.FindByID(ctx, credit.CreatorID)
+		if err != nil {
+			return err
+		}
+		manga.Creators[i].Name = creator.Name
+	}
+	manga.Author = creditLine(manga.Creators)
+	return nil
 }
 
 // GetByID now implements the cache-aside pattern.
@@ -83,13 +161,131 @@ func (s *MangaService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Manga
 	return manga, nil
 }
 
-func (s *MangaService) List(ctx context.Context, params repository.ListMangaParams) ([]*domain.Manga, error) {
+// GetByExternalID finds a manga by the key it was imported under, see internal/catalog.
+func (s *MangaService) GetByExternalID(ctx context.Context, externalID string) (*domain.Manga, error) {
+	return s.mangaRepo.FindByExternalID(ctx, externalID)
+}
+
+// CheckContentRating returns ErrContentRestricted unless the manga has one of the content ratings.
+func (s *MangaService) CheckContentRating(ctx context.Context, id uuid.UUID, ratings []domain.ContentRating) error {
+	manga, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !manga.ContentRating.AllowedBy(ratings) {
+		return ErrContentRestricted
+	}
+	return nil
+}
+
+// List lists a page of manga. Facets are the same on every page and sort order, so they are
+// cached by the search's filters for a short while.
+func (s *MangaService) List(ctx context.Context, params repository.ListMangaParams) (*repository.MangaPage, error) {
+	if !params.Facets {
+		return s.list(ctx, params)
+	}
+
+	key := getFacetsCacheKey(params)
+	cached, err := s.redis.Get(ctx, key).Result()
+	if err == nil {
+		var facets domain.MangaFacets
+		if err := json.Unmarshal([]byte(cached), &facets); err == nil {
+			params.Facets = false
+			page, err := s.list(ctx, params)
+			if err != nil {
+				return nil, err
+			}
+			page.Facets = &facets
+			return page, nil
+		}
+	} else if err != redis.Nil {
+		log.Printf("Failed to read cached manga facets: %v", err)
+	}
+
+	page, err := s.list(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if facetsJSON, err := json.Marshal(page.Facets); err == nil {
+		if err := s.redis.Set(ctx, key, facetsJSON, facetsCacheDuration).Err(); err != nil {
+			log.Printf("Failed to cache manga facets: %v", err)
+		}
+	}
+	return page, nil
+}
+
+// list leaves searches to the search index and lists the catalog otherwise.
+func (s *MangaService) list(ctx context.Context, params repository.ListMangaParams) (*repository.MangaPage, error) {
+	if params.SearchQuery != "" {
+		return s.searchIndex.Search(ctx, params)
+	}
 	return s.mangaRepo.List(ctx, params)
 }
 
-// Update now includes cache invalidation.
+// Autocomplete suggests up to limit manga for a search being typed.
+func (s *MangaService) Autocomplete(ctx context.Context, search string, ratings []domain.ContentRating, limit int) ([]*domain.MangaSuggestion, error) {
+	return s.mangaRepo.Autocomplete(ctx, search, ratings, limit)
+}
+
+// getFacetsCacheKey derives the facets cache key from the filters of a search, normalized so
+// that the same search in another order ("Action,Drama" or "Drama,Action") shares a key.
+func getFacetsCacheKey(params repository.ListMangaParams) string {
+	normalize := func(values []string) []string {
+		if values == nil {
+			return nil
+		}
+		normalized := slices.Clone(values)
+		slices.Sort(normalized)
+		return slices.Compact(normalized)
+	}
+	ratings := func(values []domain.ContentRating) []string {
+		if values == nil {
+			return nil
+		}
+		strs := make([]string, len(values))
+		for i, v := range values {
+			strs[i] = string(v)
+		}
+		return normalize(strs)
+	}
+	var demographics []string
+	for _, d := range params.Demographics {
+		demographics = append(demographics, string(d))
+	}
+
+	filters := []interface{}{
+		params.SearchQuery, params.SearchLanguage,
+		normalize(params.Genres), params.GenreMode,
+		normalize(params.ExcludedGenres), params.ExcludedGenreMode,
+		params.Status, ratings(params.ContentRatings), ratings(params.ContentRatingFilter),
+		params.CreatorID, normalize(demographics), params.YearFrom, params.YearTo,
+		params.OriginalLanguage, params.MyAnimeListID, params.AniListID, params.MangaUpdatesID,
+	}
+	data, _ := json.Marshal(filters) // Can't fail for these types
+	sum := sha256.Sum256(data)
+	return "manga:facets:" + hex.EncodeToString(sum[:])
+}
+
+// Update now includes cache invalidation. A manga.Version other than 0 makes it conditional,
+// see MangaRepository.Update.
 func (s *MangaService) Update(ctx context.Context, manga *domain.Manga) error {
-	if err := s.mangaRepo.Update(ctx, manga); err != nil {
+	return s.update(ctx, manga, &domain.Revision{Action: domain.RevisionUpdate})
+}
+
+// update saves the manga and records the change as the revision.
+func (s *MangaService) update(ctx context.Context, manga *domain.Manga, revision *domain.Revision) error {
+	existing, err := s.mangaRepo.FindByID(ctx, manga.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.prepareCreators(ctx, manga); err != nil {
+		return err
+	}
+	revise := func(saved *domain.Manga) (*domain.Revision, error) {
+		revision.MangaID = saved.ID
+		return newRevision(ctx, revision, mangaFieldsOf(existing), mangaFieldsOf(saved))
+	}
+	if err := s.mangaRepo.Update(ctx, manga, revise); err != nil {
 		return err
 	}
 
@@ -98,12 +294,63 @@ func (s *MangaService) Update(ctx context.Context, manga *domain.Manga) error {
 	log.Println("CACHE INVALIDATED for key:", key)
 	s.redis.Del(ctx, key) // We can ignore the error here for simplicity
 
+	publishMangaEvent(s.broker, MangaUpdatedEvent, manga.ID)
 	return nil
 }
 
-// Delete now includes cache invalidation.
-func (s *MangaService) Delete(ctx context.Context, id uuid.UUID) error {
-	if err := s.mangaRepo.Delete(ctx, id); err != nil {
+// History lists the revisions of the manga and its chapters, newest first.
+func (s *MangaService) History(ctx context.Context, params repository.ListRevisionsParams) (*repository.Page[*domain.Revision], error) {
+	if _, err := s.mangaRepo.FindByID(ctx, params.MangaID); err != nil {
+		return nil, err
+	}
+	return s.revisionRepo.ListByMangaID(ctx, params)
+}
+
+// GetRevision returns a revision of the manga or one of its chapters.
+func (s *MangaService) GetRevision(ctx context.Context, mangaID, revisionID uuid.UUID) (*domain.Revision, error) {
+	revision, err := s.revisionRepo.FindByID(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if revision.MangaID != mangaID {
+		return nil, repository.ErrRevisionNotFound
+	}
+	return revision, nil
+}
+
+// Revert sets the manga's tracked fields back to what they were after the revision, if the
+// manga is at the version or that is 0. The revert is recorded as a revision of its own.
+func (s *MangaService) Revert(ctx context.Context, revision *domain.Revision, version int) (*domain.Manga, error) {
+	if revision.ChapterID != nil {
+		return nil, repository.ErrRevisionNotFound
+	}
+	if revision.Snapshot == nil {
+		return nil, ErrRevisionNotRevertible
+	}
+	var fields mangaFields
+	if err := readFields(revision, &fields); err != nil {
+		return nil, err
+	}
+
+	manga, err := s.mangaRepo.FindByID(ctx, revision.MangaID)
+	if err != nil {
+		return nil, err
+	}
+	fields.applyTo(manga)
+	manga.Version = version
+	if err := s.update(ctx, manga, &domain.Revision{Action: domain.RevisionRevert, RevertedFrom: &revision.ID}); err != nil {
+		return nil, err
+	}
+	return manga, nil
+}
+
+// Delete now includes cache invalidation. A version other than 0 makes it conditional, see
+// MangaRepository.Delete.
+func (s *MangaService) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	revise := func(id uuid.UUID) (*domain.Revision, error) {
+		return newMangaRevision(ctx, domain.RevisionDelete, id, nil, nil)
+	}
+	if err := s.mangaRepo.Delete(ctx, id, version, revise); err != nil {
 		return err
 	}
 
@@ -112,5 +359,50 @@ func (s *MangaService) Delete(ctx context.Context, id uuid.UUID) error {
 	log.Println("CACHE INVALIDATED for key:", key)
 	s.redis.Del(ctx, key) // We can ignore the error here for simplicity
 
+	publishMangaEvent(s.broker, MangaDeletedEvent, id)
 	return nil
 }
+
+// SetRelation records that the related manga is a relationType of the manga, e.g. its sequel.
+// The inverse relation is recorded on the related manga.
+func (s *MangaService) SetRelation(ctx context.Context, mangaID, relatedID uuid.UUID, relationType domain.RelationType) error {
+	if mangaID == relatedID {
+		return ErrSelfRelation
+	}
+	if err := s.mangaRepo.SetRelation(ctx, mangaID, relatedID, relationType); err != nil {
+		return err
+	}
+	s.invalidateMangas(ctx, mangaID, relatedID)
+	return nil
+}
+
+// DeleteRelation removes the relation between the two manga, from both sides.
+func (s *MangaService) DeleteRelation(ctx context.Context, mangaID, relatedID uuid.UUID) error {
+	if err := s.mangaRepo.DeleteRelation(ctx, mangaID, relatedID); err != nil {
+		return err
+	}
+	s.invalidateMangas(ctx, mangaID, relatedID)
+	return nil
+}
+
+func (s *MangaService) invalidateMangas(ctx context.Context, ids ...uuid.UUID) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = getMangaCacheKey(id)
+	}
+	log.Println("CACHE INVALIDATED for keys:", keys)
+	s.redis.Del(ctx, keys...) // We can ignore the error here for simplicity
+}
+
+// publishMangaEvent publishes a manga event for each of the manga in the background.
+func publishMangaEvent(b *broker.RabbitMQBroker, event string, ids ...uuid.UUID) {
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	go func() {
+		for _, id := range ids {
+			payload := MangaChangedPayload{MangaID: id.String(), Timestamp: timestamp}
+			if err := b.Publish(context.Background(), event, payload); err != nil {
+				log.Printf("Failed to publish %s event for manga %s: %v", event, id, err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFacetsCacheKey(t *testing.T) {
+	params := repository.ListMangaParams{
+		Genres:         []string{"Action", "Drama"},
+		ContentRatings: []domain.ContentRating{domain.ContentRatingSafe, domain.ContentRatingSuggestive},
+		Limit:          20,
+	}
+	key := getFacetsCacheKey(params)
+
+	// Pagination, sorting and the order of values don't change the facets
+	reordered := params
+	reordered.Genres = []string{"Drama", "Action", "Drama"}
+	reordered.ContentRatings = []domain.ContentRating{domain.ContentRatingSuggestive, domain.ContentRatingSafe}
+	reordered.Limit, reordered.Offset, reordered.SortBy = 50, 100, "title"
+	assert.Equal(t, key, getFacetsCacheKey(reordered))
+
+	// Filters do
+	filtered := params
+	filtered.Status = "ongoing"
+	assert.NotEqual(t, key, getFacetsCacheKey(filtered))
+
+	// No filter and one matching nothing are different searches
+	allRatings, noRatings := params, params
+	allRatings.ContentRatingFilter = nil
+	noRatings.ContentRatingFilter = []domain.ContentRating{}
+	assert.NotEqual(t, getFacetsCacheKey(allRatings), getFacetsCacheKey(noRatings))
+}
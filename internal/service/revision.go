@@ -0,0 +1,223 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/actor"
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+)
+
+// mangaFields are the fields of a manga its revisions track. Revisions store them as JSON, so
+// renaming one stops older revisions from reverting it.
+type mangaFields struct {
+	Title            string
+	AltTitles        []domain.MangaTitle
+	Description      string
+	Author           string
+	Creators         []domain.MangaCreator
+	Status           domain.MangaStatus
+	ContentRating    domain.ContentRating
+	Genres           []string
+	Demographic      *domain.Demographic
+	Year             *int
+	OriginalLanguage *string
+	LastVolume       *string
+	LastChapter      *string
+	Links            domain.MangaLinks
+	SearchLanguage   string
+}
+
+func mangaFieldsOf(manga *domain.Manga) *mangaFields {
+	genres := slices.Clone(manga.Genres)
+	slices.Sort(genres) // Stored sorted, whatever order they were given in
+	return &mangaFields{
+		Title:            manga.Title,
+		AltTitles:        manga.AltTitles,
+		Description:      manga.Description,
+		Author:           manga.Author,
+		Creators:         manga.Creators,
+		Status:           manga.Status,
+		ContentRating:    manga.ContentRating,
+		Genres:           genres,
+		Demographic:      manga.Demographic,
+		Year:             manga.Year,
+		OriginalLanguage: manga.OriginalLanguage,
+		LastVolume:       manga.LastVolume,
+		LastChapter:      manga.LastChapter,
+		Links:            manga.Links,
+		SearchLanguage:   manga.SearchLanguage,
+	}
+}
+
+func (f *mangaFields) applyTo(manga *domain.Manga) {
+	manga.Title = f.Title
+	manga.AltTitles = f.AltTitles
+	manga.Description = f.Description
+	manga.Author = f.Author
+	manga.Creators = f.Creators
+	manga.Status = f.Status
+	manga.ContentRating = f.ContentRating
+	manga.Genres = f.Genres
+	manga.Demographic = f.Demographic
+	manga.Year = f.Year
+	manga.OriginalLanguage = f.OriginalLanguage
+	manga.LastVolume = f.LastVolume
+	manga.LastChapter = f.LastChapter
+	manga.Links = f.Links
+	manga.SearchLanguage = f.SearchLanguage
+}
+
+// chapterFields are the fields of a chapter its revisions track. Pages aren't: replaced pages
+// are deleted from storage, so there would be nothing to revert to.
+type chapterFields struct {
+	ChapterNumber    string
+	Title            *string
+	Volume           *string
+	PublicationState domain.PublicationState
+	PublishAt        *time.Time
+}
+
+func chapterFieldsOf(chapter *domain.Chapter) *chapterFields {
+	fields := &chapterFields{
+		ChapterNumber:    chapter.ChapterNumber,
+		Title:            chapter.Title,
+		Volume:           chapter.Volume,
+		PublicationState: chapter.PublicationState,
+	}
+	if chapter.PublishAt != nil {
+		// As stored, so that an unchanged time compares equal
+		publishAt := chapter.PublishAt.UTC().Truncate(time.Microsecond)
+		fields.PublishAt = &publishAt
+	}
+	return fields
+}
+
+func (f *chapterFields) applyTo(chapter *domain.Chapter) {
+	chapter.ChapterNumber = f.ChapterNumber
+	chapter.Title = f.Title
+	chapter.Volume = f.Volume
+	chapter.PublicationState = f.PublicationState
+	chapter.PublishAt = f.PublishAt
+}
+
+// diffFields compares tracked fields field by field. Old is nil for creations.
+func diffFields[F any](old, new *F) (map[string]domain.FieldChange, error) {
+	oldValues, err := fieldValues(old)
+	if err != nil {
+		return nil, err
+	}
+	newValues, err := fieldValues(new)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]domain.FieldChange)
+	for name, value := range newValues {
+		if old, ok := oldValues[name]; !ok || !reflect.DeepEqual(old, value) {
+			changes[name] = domain.FieldChange{Old: old, New: value}
+		}
+	}
+	return changes, nil
+}
+
+// fieldValues decodes the JSON of tracked fields into a map by field name. Numbers are kept as
+// json.Number, so they encode again exactly as they were.
+func fieldValues[F any](fields *F) (map[string]any, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var values map[string]any
+	err = decoder.Decode(&values)
+	return values, err
+}
+
+// readFields reads a revision's snapshot back into tracked fields.
+func readFields[F any](revision *domain.Revision, fields *F) error {
+	if err := decodeFields(revision.Snapshot, fields); err != nil {
+		return fmt.Errorf("failed to read revision %s: %w", revision.ID, err)
+	}
+	return nil
+}
+
+// decodeFields is the inverse of fieldValues.
+func decodeFields[F any](values map[string]any, fields *F) error {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, fields)
+}
+
+// sameValue reports whether two decoded field values are equal, however their numbers were
+// decoded: json.Number by fieldValues, float64 when read back from the database.
+func sameValue(a, b any) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
+
+// newRevision completes the revision of a change from the old to the new tracked fields, made by
+// the user in the context if any. Old is nil for creations and new for deletions. It returns nil
+// for updates that changed nothing, which aren't recorded.
+func newRevision[F any](ctx context.Context, revision *domain.Revision, old, new *F) (*domain.Revision, error) {
+	changes, err := diffFields(old, new)
+	if err == nil {
+		revision.Snapshot, err = fieldValues(new)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff revision of manga %s: %w", revision.MangaID, err)
+	}
+	if revision.Action == domain.RevisionUpdate && len(changes) == 0 {
+		return nil, nil
+	}
+
+	revision.Changes = changes
+	if userID, ok := actor.UserID(ctx); ok {
+		revision.ActorID = &userID
+	}
+	return revision, nil
+}
+
+// recordRevision records a change that has already been saved, see newRevision. Most changes
+// record theirs in their own transaction instead, through repository.Revise; for the others an
+// error here means the change was saved without its revision.
+func recordRevision[F any](ctx context.Context, repo repository.RevisionRepository, revision *domain.Revision, old, new *F) error {
+	revision, err := newRevision(ctx, revision, old, new)
+	if err != nil || revision == nil {
+		return err
+	}
+	if err := repo.Create(ctx, revision); err != nil {
+		return fmt.Errorf("failed to record %s revision of manga %s: %w", revision.Action, revision.MangaID, err)
+	}
+	return nil
+}
+
+func newMangaRevision(ctx context.Context, action domain.RevisionAction, mangaID uuid.UUID, old, new *mangaFields) (*domain.Revision, error) {
+	return newRevision(ctx, &domain.Revision{MangaID: mangaID, Action: action}, old, new)
+}
+
+func newChapterRevision(ctx context.Context, action domain.RevisionAction, chapter *domain.Chapter, old, new *chapterFields) (*domain.Revision, error) {
+	return newRevision(ctx, &domain.Revision{MangaID: chapter.MangaID, ChapterID: &chapter.ID, Action: action}, old, new)
+}
+
+func recordMangaRevision(ctx context.Context, repo repository.RevisionRepository, action domain.RevisionAction, mangaID uuid.UUID, old, new *mangaFields) error {
+	return recordRevision(ctx, repo, &domain.Revision{MangaID: mangaID, Action: action}, old, new)
+}
+
+func recordChapterRevision(ctx context.Context, repo repository.RevisionRepository, action domain.RevisionAction, chapter *domain.Chapter, old, new *chapterFields) error {
+	return recordRevision(ctx, repo, &domain.Revision{MangaID: chapter.MangaID, ChapterID: &chapter.ID, Action: action}, old, new)
+}
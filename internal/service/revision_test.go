@@ -0,0 +1,57 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFields(t *testing.T) {
+	old := mangaFieldsOf(&domain.Manga{Title: "Berserk", Status: "ongoing", Genres: []string{"Horror", "Action"}})
+	new := mangaFieldsOf(&domain.Manga{Title: "Berserk", Status: "completed", Genres: []string{"Action", "Horror"}})
+
+	changes, err := diffFields(old, new)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.FieldChange{
+		"Status": {Old: "ongoing", New: "completed"},
+	}, changes, "genres only differ in order")
+
+	changes, err = diffFields(nil, new)
+	require.NoError(t, err)
+	assert.Len(t, changes, 15, "every field is new on creation")
+	assert.Nil(t, changes["Title"].Old)
+	assert.Equal(t, "Berserk", changes["Title"].New)
+}
+
+func TestChapterFieldsPublishAt(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 123456789, time.FixedZone("JST", 9*60*60))
+	stored := at.UTC().Truncate(time.Microsecond)
+
+	changes, err := diffFields(
+		chapterFieldsOf(&domain.Chapter{ChapterNumber: "1", PublishAt: &at}),
+		chapterFieldsOf(&domain.Chapter{ChapterNumber: "1", PublishAt: &stored}),
+	)
+	require.NoError(t, err)
+	assert.Empty(t, changes, "the same time as given and as stored")
+}
+
+func TestRevertFields(t *testing.T) {
+	year := 1989
+	before := &domain.Manga{Title: "Berserk", Year: &year, Genres: []string{"Action"}, SearchLanguage: "en"}
+	snapshot, err := fieldValues(mangaFieldsOf(before))
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("1989"), snapshot["Year"])
+
+	var fields mangaFields
+	require.NoError(t, readFields(&domain.Revision{Snapshot: snapshot}, &fields))
+	after := &domain.Manga{Title: "Berserk (Deluxe)", Genres: []string{"Action", "Horror"}}
+	fields.applyTo(after)
+
+	changes, err := diffFields(mangaFieldsOf(before), mangaFieldsOf(after))
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
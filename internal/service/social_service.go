@@ -20,7 +20,7 @@ func (s *SocialService) ToggleFavorite(ctx context.Context, userID, mangaID uuid
 	return s.socialRepo.ToggleFavorite(ctx, userID, mangaID)
 }
 
-func (s *SocialService) ListFavorites(ctx context.Context, userID uuid.UUID, params repository.ListMangaParams) ([]*domain.Manga, error) {
+func (s *SocialService) ListFavorites(ctx context.Context, userID uuid.UUID, params repository.ListMangaParams) (*repository.Page[*domain.Manga], error) {
 	return s.socialRepo.ListFavorites(ctx, userID, params)
 }
 
@@ -28,16 +28,17 @@ func (s *SocialService) MarkChapterAsRead(ctx context.Context, userID, chapterID
 	return s.socialRepo.MarkChapterAsRead(ctx, userID, chapterID)
 }
 
-func (s *SocialService) ListReadChapters(ctx context.Context, userID uuid.UUID) ([]*domain.Chapter, error) {
-	return s.socialRepo.ListReadChapters(ctx, userID)
+// ListReadChapters lists the published chapters the user has read, of manga with one of the
+// content ratings, if not nil.
+func (s *SocialService) ListReadChapters(ctx context.Context, userID uuid.UUID, ratings []domain.ContentRating) ([]*domain.Chapter, error) {
+	return s.socialRepo.ListReadChapters(ctx, userID, ratings)
 }
 
 func (s *SocialService) CreateComment(ctx context.Context, comment *domain.Comment) error {
-	// Business logic could go here, e.g., checking if the parent manga/chapter exists.
-	// For now, we rely on the database's foreign key constraints.
+	// The repository checks that the parent manga/chapter exists and isn't in the trash.
 	return s.socialRepo.CreateComment(ctx, comment)
 }
 
-func (s *SocialService) ListComments(ctx context.Context, params repository.ListCommentsParams) ([]*domain.CommentWithUser, error) {
+func (s *SocialService) ListComments(ctx context.Context, params repository.ListCommentsParams) (*repository.Page[*domain.CommentWithUser], error) {
 	return s.socialRepo.ListComments(ctx, params)
 }
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xpanadol/manga/internal/actor"
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNoSuggestedChanges = errors.New("the suggestion doesn't change anything")
+	// ErrSuggestionOutdated is returned when a field a suggestion changes has been changed
+	// differently since it was submitted.
+	ErrSuggestionOutdated = errors.New("the manga has changed since the suggestion was made")
+)
+
+// SuggestionService queues the edits users suggest to manga until a moderator approves them,
+// which applies them as an update by the submitter, or rejects them with a reason.
+type SuggestionService struct {
+	suggestionRepo repository.SuggestionRepository
+	mangaRepo      repository.MangaRepository
+	mangaService   *MangaService
+}
+
+func NewSuggestionService(
+	suggestionRepo repository.SuggestionRepository,
+	mangaRepo repository.MangaRepository,
+	mangaService *MangaService,
+) *SuggestionService {
+	return &SuggestionService{
+		suggestionRepo: suggestionRepo,
+		mangaRepo:      mangaRepo,
+		mangaService:   mangaService,
+	}
+}
+
+// Submit queues the suggestion to make the manga look like proposed. Only the fields that
+// differ from the manga as it is now are kept.
+func (s *SuggestionService) Submit(ctx context.Context, suggestion *domain.EditSuggestion, proposed *domain.Manga) (*domain.EditSuggestion, error) {
+	current, err := s.mangaRepo.FindByID(ctx, suggestion.MangaID)
+	if err != nil {
+		return nil, err
+	}
+	// Left empty, these keep their current value on update, so they aren't a change
+	if proposed.ContentRating == "" {
+		proposed.ContentRating = current.ContentRating
+	}
+	if proposed.SearchLanguage == "" {
+		proposed.SearchLanguage = current.SearchLanguage
+	}
+	if len(proposed.Creators) == 0 && len(current.Creators) == 0 {
+		proposed.Creators = current.Creators
+	}
+
+	changes, err := diffFields(mangaFieldsOf(current), mangaFieldsOf(proposed))
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, ErrNoSuggestedChanges
+	}
+	suggestion.Changes = changes
+	if err := s.suggestionRepo.Create(ctx, suggestion); err != nil {
+		return nil, err
+	}
+	return s.suggestionRepo.FindByID(ctx, suggestion.ID)
+}
+
+func (s *SuggestionService) GetByID(ctx context.Context, id uuid.UUID) (*domain.EditSuggestion, error) {
+	return s.suggestionRepo.FindByID(ctx, id)
+}
+
+func (s *SuggestionService) List(ctx context.Context, params repository.ListSuggestionsParams) (*repository.Page[*domain.EditSuggestion], error) {
+	return s.suggestionRepo.List(ctx, params)
+}
+
+// Approve applies a pending suggestion to the manga. The update is recorded in the manga's
+// history as made by the submitter; the suggestion records who approved it.
+func (s *SuggestionService) Approve(ctx context.Context, id, reviewerID uuid.UUID) (*domain.EditSuggestion, error) {
+	suggestion, err := s.suggestionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if suggestion.Status != domain.SuggestionPending {
+		return nil, repository.ErrSuggestionReviewed
+	}
+
+	manga, err := s.mangaRepo.FindByID(ctx, suggestion.MangaID)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := applyChanges(mangaFieldsOf(manga), suggestion.Changes)
+	if err != nil {
+		return nil, err
+	}
+	fields.applyTo(manga) // At the version just read, so a concurrent update is a conflict
+	if err := s.mangaService.Update(actor.WithUserID(ctx, suggestion.UserID), manga); err != nil {
+		return nil, err
+	}
+
+	if err := s.suggestionRepo.Review(ctx, id, domain.SuggestionApproved, reviewerID, nil); err != nil {
+		return nil, err
+	}
+	return s.suggestionRepo.FindByID(ctx, id)
+}
+
+// Reject closes a pending suggestion, with a reason shown to the submitter.
+func (s *SuggestionService) Reject(ctx context.Context, id, reviewerID uuid.UUID, reason string) (*domain.EditSuggestion, error) {
+	if err := s.suggestionRepo.Review(ctx, id, domain.SuggestionRejected, reviewerID, &reason); err != nil {
+		return nil, err
+	}
+	return s.suggestionRepo.FindByID(ctx, id)
+}
+
+// applyChanges sets the new value of each change on the fields. A field that has neither the
+// old nor already the new value has been changed differently since, and the changes are
+// outdated.
+func applyChanges(fields *mangaFields, changes map[string]domain.FieldChange) (*mangaFields, error) {
+	values, err := fieldValues(fields)
+	if err != nil {
+		return nil, err
+	}
+	for name, change := range changes {
+		if current := values[name]; !sameValue(current, change.Old) && !sameValue(current, change.New) {
+			return nil, ErrSuggestionOutdated
+		}
+		values[name] = change.New
+	}
+
+	var applied mangaFields
+	if err := decodeFields(values, &applied); err != nil {
+		return nil, err
+	}
+	return &applied, nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyChanges(t *testing.T) {
+	year := 1989
+	manga := &domain.Manga{Title: "Berserk", Status: "ongoing", Year: &year, Genres: []string{"Action"}}
+	// As read back from the database, with numbers decoded as float64
+	changes := map[string]domain.FieldChange{
+		"Year":   {Old: float64(1989), New: float64(1990)},
+		"Genres": {Old: []any{"Action"}, New: []any{"Action", "Horror"}},
+	}
+
+	fields, err := applyChanges(mangaFieldsOf(manga), changes)
+	require.NoError(t, err)
+	assert.Equal(t, "Berserk", fields.Title)
+	assert.Equal(t, 1990, *fields.Year)
+	assert.Equal(t, []string{"Action", "Horror"}, fields.Genres)
+
+	manga.Genres = []string{"Action", "Horror"}
+	_, err = applyChanges(mangaFieldsOf(manga), changes)
+	assert.NoError(t, err, "a field that already has the new value")
+
+	manga.Genres = []string{"Drama"}
+	_, err = applyChanges(mangaFieldsOf(manga), changes)
+	assert.ErrorIs(t, err, ErrSuggestionOutdated)
+}
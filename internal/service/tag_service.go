@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/pkg/broker"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrInvalidSlug = errors.New("tag slug must contain letters or digits")
+
+type TagService struct {
+	tagRepo repository.TagRepository
+	broker  *broker.RabbitMQBroker
+	redis   *redis.Client
+}
+
+func NewTagService(tagRepo repository.TagRepository, broker *broker.RabbitMQBroker, redisClient *redis.Client) *TagService {
+	return &TagService{
+		tagRepo: tagRepo,
+		broker:  broker,
+		redis:   redisClient,
+	}
+}
+
+// Create adds a tag. The slug is derived from the name unless one is given.
+func (s *TagService) Create(ctx context.Context, tag *domain.Tag) error {
+	tag.Slug = slugify(tag.Slug, tag.Name)
+	if tag.Slug == "" {
+		return ErrInvalidSlug
+	}
+	return s.tagRepo.Create(ctx, tag)
+}
+
+func (s *TagService) List(ctx context.Context, group domain.TagGroup) ([]*domain.Tag, error) {
+	return s.tagRepo.List(ctx, group)
+}
+
+// Update saves the tag. Renaming a tag changes the genres of every manga that has it, so their
+// versions are bumped, their cached copies dropped and they are indexed again.
+func (s *TagService) Update(ctx context.Context, tag *domain.Tag) error {
+	tag.Slug = slugify(tag.Slug, tag.Name)
+	if tag.Slug == "" {
+		return ErrInvalidSlug
+	}
+
+	mangaIDs, err := s.tagRepo.ListMangaIDs(ctx, tag.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.tagRepo.Update(ctx, tag); err != nil {
+		return err
+	}
+	s.invalidateMangas(ctx, mangaIDs)
+	return nil
+}
+
+// Delete removes the tag from the catalog and from every manga that has it, which are
+// invalidated like on a rename.
+func (s *TagService) Delete(ctx context.Context, id uuid.UUID) error {
+	mangaIDs, err := s.tagRepo.ListMangaIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.tagRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateMangas(ctx, mangaIDs)
+	return nil
+}
+
+func (s *TagService) invalidateMangas(ctx context.Context, ids []uuid.UUID) {
+	if len(ids) == 0 {
+		return
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = getMangaCacheKey(id)
+	}
+	log.Printf("CACHE INVALIDATED for %d manga after a tag change", len(keys))
+	s.redis.Del(ctx, keys...) // We can ignore the error here for simplicity
+
+	publishMangaEvent(s.broker, MangaUpdatedEvent, ids...)
+}
+
+var slugSeparator = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify normalizes slug, or derives one from name if slug is empty: "Slice of Life" -> "slice-of-life".
+// It matches the backfill in migration 000014_add_tag_groups.
+func slugify(slug, name string) string {
+	if slug == "" {
+		slug = name
+	}
+	return strings.Trim(slugSeparator.ReplaceAllString(strings.ToLower(slug), "-"), "-")
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "slice-of-life", slugify("", "Slice of Life"))
+	assert.Equal(t, "sci-fi", slugify("", "Sci-Fi"))
+	assert.Equal(t, "boys-love", slugify("Boys' Love", "BL"))
+	assert.Equal(t, "", slugify("", "!!!"))
+}
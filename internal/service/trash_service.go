@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/pkg/broker"
+	"github.com/0xpanadol/manga/pkg/storage"
+	"github.com/google/uuid"
+)
+
+// TrashService lists and restores deleted manga and chapters, and purges them for good once
+// they have been in the trash for the retention period.
+type TrashService struct {
+	mangaRepo    repository.MangaRepository
+	chapterRepo  repository.ChapterRepository
+	revisionRepo repository.RevisionRepository
+	storage      storage.Storage
+	broker       *broker.RabbitMQBroker
+}
+
+func NewTrashService(
+	mangaRepo repository.MangaRepository,
+	chapterRepo repository.ChapterRepository,
+	revisionRepo repository.RevisionRepository,
+	storage storage.Storage,
+	broker *broker.RabbitMQBroker,
+) *TrashService {
+	return &TrashService{
+		mangaRepo:    mangaRepo,
+		chapterRepo:  chapterRepo,
+		revisionRepo: revisionRepo,
+		storage:      storage,
+		broker:       broker,
+	}
+}
+
+func (s *TrashService) ListManga(ctx context.Context, params repository.ListTrashParams) (*repository.Page[*domain.Manga], error) {
+	return s.mangaRepo.ListDeleted(ctx, params)
+}
+
+func (s *TrashService) ListChapters(ctx context.Context, params repository.ListTrashParams) (*repository.Page[*domain.Chapter], error) {
+	return s.chapterRepo.ListDeleted(ctx, params)
+}
+
+// RestoreManga takes the manga out of the trash, with the chapters deleted along with it.
+func (s *TrashService) RestoreManga(ctx context.Context, id uuid.UUID) (*domain.Manga, error) {
+	if err := s.mangaRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	publishMangaEvent(s.broker, MangaUpdatedEvent, id)
+
+	manga, err := s.mangaRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return manga, recordMangaRevision(ctx, s.revisionRepo, domain.RevisionRestore, id, nil, mangaFieldsOf(manga))
+}
+
+func (s *TrashService) RestoreChapter(ctx context.Context, id uuid.UUID) (*domain.Chapter, error) {
+	if err := s.chapterRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	chapter, err := s.chapterRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return chapter, recordChapterRevision(ctx, s.revisionRepo, domain.RevisionRestore, chapter, nil, chapterFieldsOf(chapter))
+}
+
+// Purge deletes the manga and chapters trashed before the given time for good, along with
+// their stored files, and returns how many it deleted. Files are deleted first, so a failure
+// leaves the row in the trash for the next run.
+func (s *TrashService) Purge(ctx context.Context, before time.Time) (int, error) {
+	count := 0
+
+	chapterIDs, err := s.chapterRepo.ListDeletedBefore(ctx, before)
+	if err != nil {
+		return count, err
+	}
+	for _, id := range chapterIDs {
+		if err := s.deleteChapterFiles(ctx, id); err != nil {
+			return count, err
+		}
+		if err := s.chapterRepo.Purge(ctx, id); err != nil && !errors.Is(err, repository.ErrChapterNotFound) {
+			return count, err
+		}
+		count++
+	}
+
+	mangaIDs, err := s.mangaRepo.ListDeletedBefore(ctx, before)
+	if err != nil {
+		return count, err
+	}
+	for _, id := range mangaIDs {
+		chapterIDs, err := s.chapterRepo.ListIDsByMangaID(ctx, id)
+		if err != nil {
+			return count, err
+		}
+		for _, chapterID := range chapterIDs {
+			if err := s.deleteChapterFiles(ctx, chapterID); err != nil {
+				return count, err
+			}
+		}
+		for _, prefix := range []string{"covers/%s/", "downloads/%s/"} {
+			if err := s.deletePrefix(ctx, fmt.Sprintf(prefix, id)); err != nil {
+				return count, err
+			}
+		}
+		if err := s.mangaRepo.Purge(ctx, id); err != nil && !errors.Is(err, repository.ErrMangaNotFound) {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// deleteChapterFiles deletes a chapter's pages and uploaded archives.
+func (s *TrashService) deleteChapterFiles(ctx context.Context, chapterID uuid.UUID) error {
+	for _, prefix := range []string{"chapters/%s/", "archives/%s/"} {
+		if err := s.deletePrefix(ctx, fmt.Sprintf(prefix, chapterID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *TrashService) deletePrefix(ctx context.Context, prefix string) error {
+	objects, err := s.storage.List(ctx, prefix)
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", prefix, err)
+	}
+	for _, object := range objects {
+		if err := s.storage.Delete(ctx, object.Key); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", object.Key, err)
+		}
+	}
+	return nil
+}
@@ -19,3 +19,27 @@ func NewUserService(userRepo repository.UserRepository) *UserService {
 func (s *UserService) GetProfile(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
 	return s.userRepo.FindByID(ctx, userID)
 }
+
+// ContentRatings returns the manga content ratings the user has opted into.
+func (s *UserService) ContentRatings(ctx context.Context, userID uuid.UUID) ([]domain.ContentRating, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return user.ContentRatings, nil
+}
+
+// UpdateContentRatings saves the content ratings the user wants to see, in order from least
+// to most mature and without duplicates.
+func (s *UserService) UpdateContentRatings(ctx context.Context, userID uuid.UUID, ratings []domain.ContentRating) ([]domain.ContentRating, error) {
+	var normalized []domain.ContentRating
+	for _, rating := range domain.ContentRatings {
+		if rating.AllowedBy(ratings) {
+			normalized = append(normalized, rating)
+		}
+	}
+	if err := s.userRepo.UpdateContentRatings(ctx, userID, normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
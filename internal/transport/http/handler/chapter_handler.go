@@ -3,31 +3,59 @@ package handler
 import (
 	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/0xpanadol/manga/internal/domain"
 	"github.com/0xpanadol/manga/internal/repository"
 	"github.com/0xpanadol/manga/internal/service"
+	"github.com/0xpanadol/manga/internal/transport/http/middleware"
+	"github.com/0xpanadol/manga/pkg/archive"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type ChapterHandler struct {
 	chapterService *service.ChapterService
+	mangaService   *service.MangaService
 }
 
-func NewChapterHandler(chapterService *service.ChapterService) *ChapterHandler {
-	return &ChapterHandler{chapterService: chapterService}
+func NewChapterHandler(chapterService *service.ChapterService, mangaService *service.MangaService) *ChapterHandler {
+	return &ChapterHandler{chapterService: chapterService, mangaService: mangaService}
+}
+
+// checkContentRating responds with an error and returns false if the user may not read the manga.
+func checkContentRating(c *gin.Context, mangaService *service.MangaService, mangaID uuid.UUID) bool {
+	err := mangaService.CheckContentRating(c.Request.Context(), mangaID, middleware.ContentRatings(c))
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, repository.ErrMangaNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+	case errors.Is(err, service.ErrContentRestricted):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve manga"})
+	}
+	return false
 }
 
 type createChapterRequest struct {
 	ChapterNumber string   `json:"chapter_number" binding:"required,max=20"`
 	Title         *string  `json:"title,omitempty" binding:"max=255"`
-	Pages         []string `json:"pages,omitempty"` // Initially, pages might be empty before upload
+	Volume        *string  `json:"volume,omitempty" binding:"omitempty,max=20"`
+	Pages         []string `json:"pages,omitempty"` // Initially, pages might be empty before upload; left unchanged on update when omitted
+	// Defaults to draft on create and to the current state on update
+	PublicationState domain.PublicationState `json:"publication_state,omitempty" binding:"omitempty,oneof=draft scheduled published unpublished"`
+	PublishAt        *time.Time              `json:"publish_at,omitempty"` // Required when scheduling
 }
 
 // @Summary      Create a new chapter
-// @Description  Adds a new chapter to a specific manga. Requires 'chapters:manage' permission.
+// @Description  Adds a new chapter to a specific manga. Chapters are drafts unless a publication state is given; scheduled chapters go live at publish_at.
+// @Description  Requires 'chapters:manage' or 'chapters:submit' permission. Chapters of users with only 'chapters:submit' are drafts pending review, whatever publication state is given; a moderator publishes them on approval.
 // @Tags         Chapters
 // @Accept       json
 // @Produce      json
@@ -38,6 +66,7 @@ type createChapterRequest struct {
 // @Failure      400      {object}  map[string]string
 // @Failure      401      {object}  map[string]string
 // @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
 // @Failure      409      {object}  map[string]string
 // @Failure      500      {object}  map[string]string
 // @Router       /manga/{manga_id}/chapters [post]
@@ -64,14 +93,30 @@ func (h *ChapterHandler) CreateChapter(c *gin.Context) {
 		MangaID:       mangaID,
 		ChapterNumber: req.ChapterNumber,
 		Title:         req.Title,
+		Volume:        req.Volume,
 		Pages:         pages, // Use the non-nil slice
+
+		PublicationState: req.PublicationState,
+		PublishAt:        req.PublishAt,
 	}
 
-	if err := h.chapterService.Create(c.Request.Context(), chapter); err != nil {
+	create := h.chapterService.Create
+	if !middleware.HasPermission(c, "chapters:manage") {
+		create = h.chapterService.Submit
+	}
+	if err := create(c.Request.Context(), chapter); err != nil {
+		if errors.Is(err, repository.ErrMangaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+			return
+		}
 		if errors.Is(err, repository.ErrChapterAlreadyExists) {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, service.ErrPublishAtRequired) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create chapter"})
 		return
 	}
@@ -80,12 +125,17 @@ func (h *ChapterHandler) CreateChapter(c *gin.Context) {
 }
 
 // @Summary      Get a single chapter by ID
-// @Description  Retrieves details for a single chapter.
+// @Description  Retrieves details for a single chapter. Chapters that aren't published are only visible to users with 'chapters:manage' and to their uploader.
+// @Description  Chapters of manga with a content rating the user hasn't opted into are forbidden.
+// @Description  The ETag header holds the chapter's version: send it as If-None-Match to revalidate a copy, or as If-Match to update or delete the chapter.
 // @Tags         Chapters
 // @Produce      json
 // @Param        id   path      string  true  "Chapter ID"
+// @Param        If-None-Match  header  string  false  "ETag of a cached copy"
 // @Success      200  {object}  domain.Chapter
+// @Success      304  "Not Modified"
 // @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /chapters/{id} [get]
@@ -106,18 +156,33 @@ func (h *ChapterHandler) GetChapter(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve chapter"})
 		return
 	}
+	// Unpublished chapters don't exist as far as readers are concerned
+	if !canSeeChapter(c, chapter) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+		return
+	}
+	if !checkContentRating(c, h.mangaService, chapter.MangaID) {
+		return
+	}
+	if notModified(c, chapter.Version) {
+		return
+	}
 	c.JSON(http.StatusOK, chapter)
 }
 
 // @Summary      List chapters for a manga
-// @Description  Retrieves a paginated list of chapters for a specific manga.
+// @Description  Retrieves a paginated list of chapters for a specific manga. Only published chapters are listed, unless the user has 'chapters:manage'.
+// @Description  Chapters of manga with a content rating the user hasn't opted into are forbidden.
 // @Tags         Chapters
 // @Produce      json
 // @Param        manga_id  path      string  true  "Manga ID"
 // @Param        page      query     int     false "Page number" default(1)
-// @Param        per_page  query     int     false "Items per page" default(20)
-// @Success      200       {array}   domain.Chapter
+// @Param        per_page  query     int     false "Items per page (at most 100)" default(20)
+// @Param        cursor    query     string  false "next_cursor of the previous page, to continue right after it"
+// @Success      200       {object}  handler.listResponse[domain.Chapter]
 // @Failure      400       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
 // @Failure      500       {object}  map[string]string
 // @Router       /manga/{manga_id}/chapters [get]
 func (h *ChapterHandler) ListChapters(c *gin.Context) {
@@ -128,39 +193,51 @@ func (h *ChapterHandler) ListChapters(c *gin.Context) {
 		return
 	}
 
-	var req listMangaRequest // Re-using the struct from manga handler
+	var req pageRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
 		return
 	}
+	if !checkContentRating(c, h.mangaService, mangaID) {
+		return
+	}
 
 	params := repository.ListChaptersParams{
-		MangaID: mangaID,
-		Limit:   req.PerPage,
-		Offset:  (req.Page - 1) * req.PerPage,
+		MangaID:       mangaID,
+		Limit:         req.PerPage,
+		Offset:        req.offset(),
+		Cursor:        req.Cursor,
+		PublishedOnly: !middleware.HasPermission(c, "chapters:manage"),
 	}
 
-	chapters, err := h.chapterService.ListByMangaID(c.Request.Context(), params)
+	page, err := h.chapterService.ListByMangaID(c.Request.Context(), params)
 	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			invalidCursor(c)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list chapters"})
 		return
 	}
-	c.JSON(http.StatusOK, chapters)
+	c.JSON(http.StatusOK, newListResponse(page, req))
 }
 
 // @Summary      Update a chapter
-// @Description  Updates the details of a specific chapter. Requires 'chapters:manage' permission.
+// @Description  Updates the details of a specific chapter. Pages are kept unless given. Requires 'chapters:manage' permission.
 // @Tags         Chapters
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id      path      string  true  "Chapter ID"
+// @Param        If-Match header   string  false "ETag of the chapter as last read"
 // @Param        request body      handler.createChapterRequest true "Chapter Update Info"
 // @Success      200     {object}  domain.Chapter
 // @Failure      400     {object}  map[string]string
 // @Failure      401     {object}  map[string]string
 // @Failure      403     {object}  map[string]string
 // @Failure      404     {object}  map[string]string
+// @Failure      412     {object}  map[string]string
+// @Failure      428     {object}  map[string]string
 // @Failure      500     {object}  map[string]string
 // @Router       /chapters/{id} [put]
 func (h *ChapterHandler) UpdateChapter(c *gin.Context) {
@@ -171,27 +248,111 @@ func (h *ChapterHandler) UpdateChapter(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
 	var req createChapterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
 		return
 	}
 
-	pages := req.Pages
-	if pages == nil {
-		pages = []string{}
+	h.saveChapter(c, id, version, req)
+}
+
+// @Summary      Partially update a chapter
+// @Description  Applies a JSON Merge Patch (RFC 7386) to the chapter: only the fields in the body change and null clears an optional field.
+// @Description  Pages are replaced as a whole when given. Requires 'chapters:manage' permission.
+// @Tags         Chapters
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string  true  "Chapter ID"
+// @Param        If-Match header   string  false "ETag of the chapter as last read"
+// @Param        request body      handler.createChapterRequest true "Fields to change"
+// @Success      200     {object}  domain.Chapter
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
+// @Failure      412     {object}  map[string]string
+// @Failure      428     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /chapters/{id} [patch]
+func (h *ChapterHandler) PatchChapter(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chapter ID format"})
+		return
+	}
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	chapter, err := h.chapterService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrChapterNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve chapter"})
+		return
+	}
+	if version != 0 && version != chapter.Version {
+		preconditionFailed(c)
+		return
 	}
 
-	// Fetch existing chapter to get manga_id (or pass it in the request)
-	// For simplicity, we just update the fields provided
+	current := createChapterRequest{
+		ChapterNumber:    chapter.ChapterNumber,
+		Title:            chapter.Title,
+		Volume:           chapter.Volume,
+		Pages:            chapter.Pages,
+		PublicationState: chapter.PublicationState,
+		PublishAt:        chapter.PublishAt,
+	}
+	var req createChapterRequest
+	if err := applyMergePatch(current, patch, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+	if _, ok := patchedFields(patch)["pages"]; !ok {
+		req.Pages = nil // Keep the stored pages rather than writing back the ones just read
+	}
+
+	// Conditional on the version just read even without If-Match, so a concurrent change, such
+	// as replaced pages, isn't overwritten
+	h.saveChapter(c, id, chapter.Version, req)
+}
+
+// saveChapter replaces the chapter with the one described by the request and responds with it.
+// The update is conditional on the version unless that is 0.
+func (h *ChapterHandler) saveChapter(c *gin.Context, id uuid.UUID, version int, req createChapterRequest) {
 	chapter := &domain.Chapter{
 		ID:            id,
+		Version:       version,
 		ChapterNumber: req.ChapterNumber,
 		Title:         req.Title,
-		Pages:         pages, // Use the non-nil slice
+		Volume:        req.Volume,
+		Pages:         req.Pages, // nil keeps the current pages
+
+		PublicationState: req.PublicationState,
+		PublishAt:        req.PublishAt,
 	}
 
 	if err := h.chapterService.Update(c.Request.Context(), chapter); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			versionConflict(c)
+			return
+		}
 		if errors.Is(err, repository.ErrChapterNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
 			return
@@ -200,23 +361,31 @@ func (h *ChapterHandler) UpdateChapter(c *gin.Context) {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, service.ErrPublishAtRequired) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update chapter"})
 		return
 	}
 
+	setETag(c, chapter.Version)
 	c.JSON(http.StatusOK, chapter)
 }
 
 // @Summary      Delete a chapter
-// @Description  Deletes a specific chapter. Requires 'chapters:manage' permission.
+// @Description  Moves a specific chapter to the trash, from which it can be restored until it is purged. Requires 'chapters:manage' permission.
 // @Tags         Chapters
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Chapter ID"
+// @Param        If-Match  header  string  false  "ETag of the chapter as last read"
 // @Success      204  "No Content"
 // @Failure      400  {object}  map[string]string
 // @Failure      401  {object}  map[string]string
 // @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
+// @Failure      412  {object}  map[string]string
+// @Failure      428  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /chapters/{id} [delete]
 func (h *ChapterHandler) DeleteChapter(c *gin.Context) {
@@ -227,7 +396,15 @@ func (h *ChapterHandler) DeleteChapter(c *gin.Context) {
 		return
 	}
 
-	if err := h.chapterService.Delete(c.Request.Context(), id); err != nil {
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+	if err := h.chapterService.Delete(c.Request.Context(), id, version); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			preconditionFailed(c)
+			return
+		}
 		if errors.Is(err, repository.ErrChapterNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
 			return
@@ -239,18 +416,24 @@ func (h *ChapterHandler) DeleteChapter(c *gin.Context) {
 }
 
 // @Summary      Upload chapter pages
-// @Description  Uploads one or more image files for a chapter. Requires 'chapters:manage' permission.
+// @Description  Uploads one or more image files for a chapter, or a single CBZ/ZIP archive whose images replace the chapter's pages.
+// @Description  Archives can be processed asynchronously by the worker with async=true; poll the returned job via GET /jobs/{id}.
+// @Description  Requires 'chapters:manage' permission, or 'chapters:submit' for the user's own chapters while they are pending review.
 // @Tags         Chapters
 // @Accept       multipart/form-data
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id     path      string  true  "Chapter ID"
-// @Param        pages  formData  file    true  "Image files for the chapter pages. Can be sent multiple times."
+// @Param        id       path      string  true   "Chapter ID"
+// @Param        pages    formData  file    false  "Image files for the chapter pages. Can be sent multiple times."
+// @Param        archive  formData  file    false  "CBZ/ZIP archive containing the chapter pages"
+// @Param        async    query     bool    false  "Process the archive in the background"
 // @Success      200    {object}  map[string]string
+// @Success      202    {object}  domain.Job
 // @Failure      400    {object}  map[string]string
 // @Failure      401    {object}  map[string]string
 // @Failure      403    {object}  map[string]string
 // @Failure      404    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
 // @Failure      500    {object}  map[string]string
 // @Router       /chapters/{id}/pages [post]
 func (h *ChapterHandler) UploadPages(c *gin.Context) {
@@ -261,12 +444,26 @@ func (h *ChapterHandler) UploadPages(c *gin.Context) {
 		return
 	}
 
+	if !middleware.HasPermission(c, "chapters:manage") && !h.checkPendingUpload(c, chapterID) {
+		return
+	}
+
 	form, err := c.MultipartForm()
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid multipart form", "details": err.Error()})
 		return
 	}
 
+	// A single "archive" file takes precedence over individual "pages"
+	if archives := form.File["archive"]; len(archives) > 0 {
+		if len(archives) > 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "only one archive can be uploaded at a time"})
+			return
+		}
+		h.uploadArchive(c, chapterID, archives[0])
+		return
+	}
+
 	// "pages" is the field name in the multipart form
 	files := form.File["pages"]
 	if len(files) == 0 {
@@ -286,3 +483,87 @@ func (h *ChapterHandler) UploadPages(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%d pages uploaded successfully", len(files))})
 }
+
+// checkPendingUpload responds with an error and returns false unless the chapter is the user's
+// own upload and still pending review, the only chapters submitters may add pages to.
+func (h *ChapterHandler) checkPendingUpload(c *gin.Context, chapterID uuid.UUID) bool {
+	chapter, err := h.chapterService.GetByID(c.Request.Context(), chapterID)
+	switch {
+	case errors.Is(err, repository.ErrChapterNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve chapter"})
+	case !isUploader(c, chapter):
+		// 404 rather than 403, so others' chapters under review can't be probed
+		c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+	case chapter.ReviewStatus != domain.ChapterReviewPending:
+		c.JSON(http.StatusConflict, gin.H{"error": repository.ErrChapterReviewed.Error()})
+	default:
+		return true
+	}
+	return false
+}
+
+// isUploader reports whether the authenticated user, if any, uploaded the chapter.
+// canSeeChapter reports whether the user may see the chapter: it is published, or they manage
+// chapters or uploaded it.
+func canSeeChapter(c *gin.Context, chapter *domain.Chapter) bool {
+	return chapter.IsPublished() || middleware.HasPermission(c, "chapters:manage") || isUploader(c, chapter)
+}
+
+func isUploader(c *gin.Context, chapter *domain.Chapter) bool {
+	userID, ok := c.Get(middleware.UserIDKey)
+	return ok && chapter.UploadedBy != nil && *chapter.UploadedBy == userID
+}
+
+// uploadArchive imports a CBZ/ZIP archive, either inline or through a worker job.
+func (h *ChapterHandler) uploadArchive(c *gin.Context, chapterID uuid.UUID, fileHeader *multipart.FileHeader) {
+	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
+	if ext != ".cbz" && ext != ".zip" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "archive must be a .cbz or .zip file"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read archive", "details": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	if c.Query("async") == "true" {
+		userID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
+		job, err := h.chapterService.EnqueueArchive(c.Request.Context(), chapterID, file, fileHeader.Size, userID)
+		if err != nil {
+			switch {
+			case errors.Is(err, repository.ErrChapterNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+			case errors.Is(err, archive.ErrLimitExceeded):
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid archive", "details": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to queue archive"})
+			}
+			return
+		}
+		c.JSON(http.StatusAccepted, job)
+		return
+	}
+
+	count, err := h.chapterService.ImportArchive(c.Request.Context(), chapterID, file, fileHeader.Size)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrChapterNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+		case errors.Is(err, archive.ErrInvalidArchive),
+			errors.Is(err, archive.ErrLimitExceeded),
+			errors.Is(err, archive.ErrInvalidImage),
+			errors.Is(err, archive.ErrNoPages):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid archive", "details": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to import archive"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%d pages uploaded successfully", count)})
+}
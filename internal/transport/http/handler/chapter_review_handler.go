@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/0xpanadol/manga/internal/transport/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ChapterReviewHandler serves the queue of chapters submitted for review, and uploaders' view
+// of their own chapters.
+type ChapterReviewHandler struct {
+	reviewService *service.ChapterReviewService
+}
+
+func NewChapterReviewHandler(reviewService *service.ChapterReviewService) *ChapterReviewHandler {
+	return &ChapterReviewHandler{reviewService: reviewService}
+}
+
+type approveChapterRequest struct {
+	PublishAt *time.Time `json:"publish_at,omitempty"` // Schedules the chapter if in the future; published now when omitted
+}
+
+type rejectChapterRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"` // Sent to the uploader
+}
+
+type listSubmissionsRequest struct {
+	pageRequest
+	Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+}
+
+// @Summary      List chapters pending review
+// @Description  The review queue: chapters submitted by uploaders that wait for approval, oldest first, with their pages to preview.
+// @Description  Requires 'chapters:manage' permission.
+// @Tags         Review
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page      query     int     false "Page number" default(1)
+// @Param        per_page  query     int     false "Items per page (at most 100)" default(20)
+// @Param        cursor    query     string  false "next_cursor of the previous page, to continue right after it"
+// @Success      200       {object}  handler.listResponse[domain.ChapterSubmission]
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /review/chapters [get]
+func (h *ChapterReviewHandler) ListPending(c *gin.Context) {
+	var req pageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	params := repository.ListSubmissionsParams{
+		Status:      domain.ChapterReviewPending,
+		OldestFirst: true,
+		Limit:       req.PerPage,
+		Offset:      req.offset(),
+		Cursor:      req.Cursor,
+	}
+	h.list(c, params, req)
+}
+
+// @Summary      List my uploaded chapters
+// @Description  Lists the chapters the user uploaded, newest first, with their review status and the reason of rejections. Rejected chapters are in the trash.
+// @Tags         Review
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status    query     string  false "Filter by review status" Enums(pending, approved, rejected)
+// @Param        page      query     int     false "Page number" default(1)
+// @Param        per_page  query     int     false "Items per page (at most 100)" default(20)
+// @Param        cursor    query     string  false "next_cursor of the previous page, to continue right after it"
+// @Success      200       {object}  handler.listResponse[domain.ChapterSubmission]
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /users/me/chapters [get]
+func (h *ChapterReviewHandler) ListMyChapters(c *gin.Context) {
+	var req listSubmissionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	params := repository.ListSubmissionsParams{
+		UploadedBy: c.MustGet(middleware.UserIDKey).(uuid.UUID),
+		Status:     domain.ChapterReviewStatus(req.Status),
+		Limit:      req.PerPage,
+		Offset:     req.offset(),
+		Cursor:     req.Cursor,
+	}
+	h.list(c, params, req.pageRequest)
+}
+
+func (h *ChapterReviewHandler) list(c *gin.Context, params repository.ListSubmissionsParams, req pageRequest) {
+	page, err := h.reviewService.List(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			invalidCursor(c)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list chapters"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newListResponse(page, req))
+}
+
+// @Summary      Approve a chapter
+// @Description  Publishes a chapter pending review, or schedules it for publish_at. The uploader is notified. Requires 'chapters:manage' permission.
+// @Tags         Review
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string  true  "Chapter ID"
+// @Param        request  body      handler.approveChapterRequest false "Publication"
+// @Success      200      {object}  domain.ChapterSubmission
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /review/chapters/{id}/approve [post]
+func (h *ChapterReviewHandler) ApproveChapter(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chapter ID format"})
+		return
+	}
+
+	var req approveChapterRequest
+	if c.Request.ContentLength != 0 { // The body is optional
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+			return
+		}
+	}
+
+	reviewerID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
+	submission, err := h.reviewService.Approve(c.Request.Context(), id, reviewerID, req.PublishAt)
+	if err != nil {
+		chapterReviewFailed(c, err)
+		return
+	}
+
+	setETag(c, submission.Version)
+	c.JSON(http.StatusOK, submission)
+}
+
+// @Summary      Reject a chapter
+// @Description  Moves a chapter pending review to the trash. The uploader is notified with the reason. Requires 'chapters:manage' permission.
+// @Tags         Review
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string  true  "Chapter ID"
+// @Param        request  body      handler.rejectChapterRequest true "Reason"
+// @Success      200      {object}  domain.ChapterSubmission
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /review/chapters/{id}/reject [post]
+func (h *ChapterReviewHandler) RejectChapter(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chapter ID format"})
+		return
+	}
+
+	var req rejectChapterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	reviewerID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
+	submission, err := h.reviewService.Reject(c.Request.Context(), id, reviewerID, req.Reason)
+	if err != nil {
+		chapterReviewFailed(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, submission)
+}
+
+// chapterReviewFailed responds with the error of a failed approval or rejection.
+func chapterReviewFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrChapterNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+	case errors.Is(err, repository.ErrChapterReviewed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to review chapter"})
+	}
+}
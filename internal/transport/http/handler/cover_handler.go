@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/0xpanadol/manga/pkg/imaging"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxCoverSize is the largest cover image accepted, in bytes.
+const maxCoverSize = 10 << 20 // 10 MiB
+
+type CoverHandler struct {
+	coverService *service.CoverService
+	mangaService *service.MangaService
+}
+
+func NewCoverHandler(coverService *service.CoverService, mangaService *service.MangaService) *CoverHandler {
+	return &CoverHandler{coverService: coverService, mangaService: mangaService}
+}
+
+type uploadCoverRequest struct {
+	Volume   string `form:"volume" binding:"omitempty,max=20"`
+	Language string `form:"language" binding:"omitempty,max=10"`
+	Primary  bool   `form:"primary"`
+}
+
+// @Summary      Upload a manga cover
+// @Description  Stores a JPEG, PNG or GIF cover and generates a JPEG thumbnail. Covers can be tagged with a volume and language.
+// @Description  The manga's first cover, or one uploaded with primary=true, becomes the primary cover shown as cover_image_url. Requires 'manga:manage' permission.
+// @Tags         Covers
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        manga_id  path      string  true   "Manga ID"
+// @Param        image     formData  file    true   "Cover image (max 10 MiB)"
+// @Param        volume    formData  string  false  "Volume the cover belongs to"
+// @Param        language  formData  string  false  "Language of the edition, e.g. en"
+// @Param        primary   formData  bool    false  "Make this the primary cover"
+// @Success      201       {object}  domain.Cover
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /manga/{manga_id}/covers [post]
+func (h *CoverHandler) UploadCover(c *gin.Context) {
+	mangaIDStr := c.Param("manga_id")
+	mangaID, err := uuid.Parse(mangaIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga ID format"})
+		return
+	}
+
+	var req uploadCoverRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no image uploaded"})
+		return
+	}
+	if fileHeader.Size > maxCoverSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cover image is too large"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read image", "details": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	upload := service.CoverUpload{Primary: req.Primary}
+	if req.Volume != "" {
+		upload.Volume = &req.Volume
+	}
+	if req.Language != "" {
+		upload.Language = &req.Language
+	}
+
+	cover, err := h.coverService.Upload(c.Request.Context(), mangaID, file, upload)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, cover)
+}
+
+// @Summary      List manga covers
+// @Description  Lists every cover of a manga, primary first. Covers of manga with a content rating the user hasn't opted into are forbidden.
+// @Tags         Covers
+// @Produce      json
+// @Param        id   path      string  true  "Manga ID"
+// @Success      200  {array}   domain.Cover
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /manga/{id}/covers [get]
+func (h *CoverHandler) ListCovers(c *gin.Context) {
+	mangaIDStr := c.Param("id")
+	mangaID, err := uuid.Parse(mangaIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga ID format"})
+		return
+	}
+	if !checkContentRating(c, h.mangaService, mangaID) {
+		return
+	}
+
+	covers, err := h.coverService.ListByMangaID(c.Request.Context(), mangaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, covers)
+}
+
+// @Summary      Set the primary cover
+// @Description  Makes the cover the one shown as the manga's cover_image_url. Requires 'manga:manage' permission.
+// @Tags         Covers
+// @Security     BearerAuth
+// @Param        id        path  string  true  "Manga ID"
+// @Param        cover_id  path  string  true  "Cover ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /manga/{id}/covers/{cover_id}/primary [put]
+func (h *CoverHandler) SetPrimaryCover(c *gin.Context) {
+	mangaIDStr := c.Param("id")
+	mangaID, err := uuid.Parse(mangaIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga ID format"})
+		return
+	}
+	coverID, err := uuid.Parse(c.Param("cover_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cover ID format"})
+		return
+	}
+
+	if err := h.coverService.SetPrimary(c.Request.Context(), mangaID, coverID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      Delete a cover
+// @Description  Deletes the cover and its images. If it was the primary cover, the newest remaining cover replaces it. Requires 'manga:manage' permission.
+// @Tags         Covers
+// @Security     BearerAuth
+// @Param        id        path  string  true  "Manga ID"
+// @Param        cover_id  path  string  true  "Cover ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /manga/{id}/covers/{cover_id} [delete]
+func (h *CoverHandler) DeleteCover(c *gin.Context) {
+	mangaIDStr := c.Param("id")
+	mangaID, err := uuid.Parse(mangaIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga ID format"})
+		return
+	}
+	coverID, err := uuid.Parse(c.Param("cover_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cover ID format"})
+		return
+	}
+
+	if err := h.coverService.Delete(c.Request.Context(), mangaID, coverID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CoverHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrMangaNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+	case errors.Is(err, repository.ErrCoverNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "cover not found"})
+	case errors.Is(err, imaging.ErrUnsupportedImage), errors.Is(err, imaging.ErrImageTooLarge):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cover image", "details": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process cover"})
+	}
+}
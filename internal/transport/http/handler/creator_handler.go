@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/0xpanadol/manga/internal/transport/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CreatorHandler struct {
+	creatorService *service.CreatorService
+}
+
+func NewCreatorHandler(creatorService *service.CreatorService) *CreatorHandler {
+	return &CreatorHandler{creatorService: creatorService}
+}
+
+type createCreatorRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+// @Summary      Create a creator
+// @Description  Adds an author or artist who can then be credited on manga. Requires 'manga:manage' permission.
+// @Tags         Authors
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.createCreatorRequest true "Creator Info"
+// @Success      201  {object}  domain.Creator
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /authors [post]
+func (h *CreatorHandler) CreateCreator(c *gin.Context) {
+	var req createCreatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	creator := &domain.Creator{Name: req.Name}
+	if err := h.creatorService.Create(c.Request.Context(), creator); err != nil {
+		if errors.Is(err, repository.ErrCreatorAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create creator"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, creator)
+}
+
+type listCreatorsRequest struct {
+	Page    int    `form:"page,default=1" binding:"min=1"`
+	PerPage int    `form:"per_page,default=20" binding:"min=1,max=100"`
+	Query   string `form:"q"`
+}
+
+// @Summary      List creators
+// @Description  Lists authors and artists by name.
+// @Tags         Authors
+// @Produce      json
+// @Param        page      query     int     false  "Page number" default(1)
+// @Param        per_page  query     int     false  "Items per page (at most 100)" default(20)
+// @Param        q         query     string  false  "Filter by part of the name"
+// @Success      200       {array}   domain.Creator
+// @Failure      400       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /authors [get]
+func (h *CreatorHandler) ListCreators(c *gin.Context) {
+	var req listCreatorsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	creators, err := h.creatorService.List(c.Request.Context(), repository.ListCreatorsParams{
+		Limit:  req.PerPage,
+		Offset: (req.Page - 1) * req.PerPage,
+		Query:  req.Query,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list creators"})
+		return
+	}
+
+	c.JSON(http.StatusOK, creators)
+}
+
+// @Summary      Get a creator
+// @Description  Retrieves an author or artist with their bibliography. Each work lists its credits, showing the creator's roles. Works with content ratings the user hasn't opted into are left out.
+// @Tags         Authors
+// @Produce      json
+// @Param        id    path      string  true   "Creator ID"
+// @Param        lang  query     string  false  "Preferred display languages, comma-separated (e.g., en,ja-ro)"
+// @Success      200   {object}  service.CreatorProfile
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /authors/{id} [get]
+func (h *CreatorHandler) GetCreator(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid creator ID format"})
+		return
+	}
+
+	profile, err := h.creatorService.GetProfile(c.Request.Context(), id, middleware.ContentRatings(c))
+	if err != nil {
+		if errors.Is(err, repository.ErrCreatorNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "creator not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve creator"})
+		return
+	}
+
+	languages := preferredLanguages(c)
+	for _, manga := range profile.Works {
+		manga.Localize(languages)
+	}
+	c.JSON(http.StatusOK, profile)
+}
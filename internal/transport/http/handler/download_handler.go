@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/0xpanadol/manga/internal/transport/http/middleware"
+	"github.com/0xpanadol/manga/pkg/comicbook"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type DownloadHandler struct {
+	downloadService *service.DownloadService
+}
+
+func NewDownloadHandler(downloadService *service.DownloadService) *DownloadHandler {
+	return &DownloadHandler{downloadService: downloadService}
+}
+
+type downloadRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=cbz epub pdf"`
+}
+
+func (r downloadRequest) format() string {
+	if r.Format == "" {
+		return comicbook.FormatCBZ
+	}
+	return r.Format
+}
+
+// @Summary      Download a chapter
+// @Description  Streams the chapter's pages as a CBZ (with ComicInfo.xml), EPUB or PDF file. Only published chapters can be downloaded, unless the user has 'chapters:manage'.
+// @Tags         Downloads
+// @Produce      application/vnd.comicbook+zip
+// @Produce      application/epub+zip
+// @Produce      application/pdf
+// @Param        id      path      string  true   "Chapter ID"
+// @Param        format  query     string  false  "Download format" Enums(cbz, epub, pdf) default(cbz)
+// @Success      200     {file}    file
+// @Failure      400     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /chapters/{id}/download [get]
+func (h *DownloadHandler) DownloadChapter(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chapter ID format"})
+		return
+	}
+
+	var req downloadRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	includeUnpublished := middleware.HasPermission(c, "chapters:manage")
+	download, err := h.downloadService.ChapterDownload(c.Request.Context(), id, req.format(), includeUnpublished, middleware.ContentRatings(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", download.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": download.Filename}))
+	c.Status(http.StatusOK)
+
+	// Headers are already sent, so a failure can only be logged and the response cut short.
+	if err := download.Write(c.Request.Context(), c.Writer); err != nil {
+		log.Printf("Failed to stream download of chapter %s: %v", id, err)
+		c.Abort()
+	}
+}
+
+// @Summary      Download a volume
+// @Description  Returns a link to a CBZ, EPUB or PDF bundle of every published chapter in the volume.
+// @Description  If no up-to-date bundle is cached, the worker builds one and 202 is returned with a job to poll via GET /jobs/{id}; the completed job's result holds the link.
+// @Tags         Downloads
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string  true   "Manga ID"
+// @Param        volume  path      string  true   "Volume"
+// @Param        format  query     string  false  "Download format" Enums(cbz, epub, pdf) default(cbz)
+// @Success      200     {object}  service.VolumeDownload
+// @Success      202     {object}  service.VolumeDownload
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /manga/{id}/volumes/{volume}/download [get]
+func (h *DownloadHandler) DownloadVolume(c *gin.Context) {
+	mangaIDStr := c.Param("id")
+	mangaID, err := uuid.Parse(mangaIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga ID format"})
+		return
+	}
+
+	var req downloadRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	userID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
+	download, err := h.downloadService.RequestVolume(c.Request.Context(), mangaID, c.Param("volume"), req.format(), userID, middleware.ContentRatings(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if download.Job != nil {
+		c.JSON(http.StatusAccepted, download)
+		return
+	}
+	c.JSON(http.StatusOK, download)
+}
+
+func (h *DownloadHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrChapterNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+	case errors.Is(err, repository.ErrMangaNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+	case errors.Is(err, service.ErrContentRestricted):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNothingToDownload):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, comicbook.ErrUnsupportedFormat),
+		errors.Is(err, comicbook.ErrUnsupportedImage),
+		errors.Is(err, service.ErrPageUnavailable):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cannot build download", "details": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to prepare download"})
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Manga and chapters are served with their version as ETag. Clients revalidate them with
+// If-None-Match and make updates and deletes conditional with If-Match. Representations that vary
+// for the same version, like localized manga, add a digest of the variant to the version.
+
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// variantETag is the ETag of one representation of a resource at the version, given what else
+// the representation depends on.
+func variantETag(version int, variant string) string {
+	sum := sha256.Sum256([]byte(variant))
+	return fmt.Sprintf(`"%d-%x"`, version, sum[:8])
+}
+
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", etag(version))
+}
+
+// notModified sets the ETag and, if the client's copy is current per If-None-Match, responds
+// with 304 Not Modified and returns true.
+func notModified(c *gin.Context, version int) bool {
+	return notModifiedTag(c, etag(version))
+}
+
+// notModifiedTag is notModified for an ETag other than the plain version, see variantETag.
+func notModifiedTag(c *gin.Context, current string) bool {
+	c.Header("ETag", current)
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/") // Weak comparison
+		if tag == "*" || tag == current {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// servedETag matches the ETags of a version, plain or with the digest of a variant.
+var servedETag = regexp.MustCompile(`^"([1-9][0-9]*)(?:-[0-9a-f]+)?"$`)
+
+// ifMatch returns the version required by the If-Match header, 0 when there is no header or it
+// is "*". Only a single strong ETag can match, whatever the representation it was served with;
+// for any other value it responds with 412 Precondition Failed and returns false.
+func ifMatch(c *gin.Context) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+	if m := servedETag.FindStringSubmatch(header); m != nil {
+		if version, err := strconv.Atoi(m[1]); err == nil {
+			return version, true
+		}
+	}
+	preconditionFailed(c)
+	return 0, false
+}
+
+// preconditionFailed responds that the resource has changed since the client read it.
+func preconditionFailed(c *gin.Context) {
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "the resource has been modified, fetch it again and retry"})
+}
+
+// versionConflict responds to an update that lost a race with another change. Without If-Match
+// the client didn't ask for a precondition, so it gets 409 Conflict and may simply retry.
+func versionConflict(c *gin.Context) {
+	if header := strings.TrimSpace(c.GetHeader("If-Match")); header != "" && header != "*" {
+		preconditionFailed(c)
+		return
+	}
+	c.JSON(http.StatusConflict, gin.H{"error": "the resource was modified at the same time, retry"})
+}
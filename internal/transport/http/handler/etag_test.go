@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// testContext returns a context for a request with the header set, if value isn't empty.
+func testContext(header, value string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	if value != "" {
+		c.Request.Header.Set(header, value)
+	}
+	return c, w
+}
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		version int
+		ok      bool
+	}{
+		{"", 0, true},
+		{"*", 0, true},
+		{`"3"`, 3, true},
+		{` "12" `, 12, true},
+		{`"3-0a1b2c3d4e5f6789"`, 3, true}, // A variant of the version
+		{`"3-"`, 0, false},
+		{`"3-XYZ"`, 0, false},
+		{`W/"3"`, 0, false}, // Weak ETags never match
+		{`"3", "4"`, 0, false},
+		{`3`, 0, false},
+		{`"0"`, 0, false},
+		{`"-1"`, 0, false},
+		{`"03"`, 0, false},
+		{`"3`, 0, false},
+	}
+	for _, tt := range tests {
+		c, w := testContext("If-Match", tt.header)
+		version, ok := ifMatch(c)
+		assert.Equal(t, tt.version, version, tt.header)
+		assert.Equal(t, tt.ok, ok, tt.header)
+		if !tt.ok {
+			assert.Equal(t, http.StatusPreconditionFailed, w.Code, tt.header)
+		}
+	}
+}
+
+func TestVariantETag(t *testing.T) {
+	tag := variantETag(3, "[en];[safe]")
+	assert.Regexp(t, `^"3-[0-9a-f]{16}"$`, tag)
+	assert.Equal(t, tag, variantETag(3, "[en];[safe]"))
+	assert.NotEqual(t, tag, variantETag(3, "[ja];[safe]"))
+	assert.NotEqual(t, tag, variantETag(4, "[en];[safe]"))
+
+	c, w := testContext("If-None-Match", tag)
+	assert.True(t, notModifiedTag(c, tag))
+	assert.Equal(t, tag, w.Header().Get("ETag"))
+	c, _ = testContext("If-None-Match", `"3"`)
+	assert.False(t, notModifiedTag(c, tag), "the plain version is another representation")
+
+	c, _ = testContext("If-Match", tag)
+	version, ok := ifMatch(c)
+	assert.True(t, ok)
+	assert.Equal(t, 3, version)
+}
+
+func TestNotModified(t *testing.T) {
+	tests := []struct {
+		header      string
+		notModified bool
+	}{
+		{"", false},
+		{`"3"`, true},
+		{`W/"3"`, true}, // Weak comparison
+		{`"1", "3"`, true},
+		{"*", true},
+		{`"2"`, false},
+		{`3`, false},
+	}
+	for _, tt := range tests {
+		c, w := testContext("If-None-Match", tt.header)
+		assert.Equal(t, tt.notModified, notModified(c, 3), tt.header)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"), tt.header)
+		if tt.notModified {
+			c.Writer.WriteHeaderNow()
+			assert.Equal(t, http.StatusNotModified, w.Code, tt.header)
+		}
+	}
+}
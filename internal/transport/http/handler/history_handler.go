@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// HistoryHandler serves the revisions recorded for every change to a manga and its chapters.
+type HistoryHandler struct {
+	mangaService   *service.MangaService
+	chapterService *service.ChapterService
+}
+
+func NewHistoryHandler(mangaService *service.MangaService, chapterService *service.ChapterService) *HistoryHandler {
+	return &HistoryHandler{mangaService: mangaService, chapterService: chapterService}
+}
+
+// @Summary      List a manga's history
+// @Description  Lists the revisions of a manga and its chapters, newest first: who made each change, when, and the old and new value of each changed field.
+// @Description  Requires 'manga:manage' permission.
+// @Tags         Manga
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string  true  "Manga ID"
+// @Param        page      query     int     false "Page number" default(1)
+// @Param        per_page  query     int     false "Items per page (at most 100)" default(20)
+// @Param        cursor    query     string  false "next_cursor of the previous page, to continue right after it"
+// @Success      200       {object}  handler.listResponse[domain.Revision]
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /manga/{id}/history [get]
+func (h *HistoryHandler) ListHistory(c *gin.Context) {
+	mangaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga ID format"})
+		return
+	}
+
+	var req pageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	params := repository.ListRevisionsParams{
+		MangaID: mangaID,
+		Limit:   req.PerPage,
+		Offset:  req.offset(),
+		Cursor:  req.Cursor,
+	}
+	page, err := h.mangaService.History(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, repository.ErrMangaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+			return
+		}
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			invalidCursor(c)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newListResponse(page, req))
+}
+
+// @Summary      Revert to a revision
+// @Description  Sets the manga, or the chapter the revision is of, back to how it was right after the revision. The revert is recorded as a new revision.
+// @Description  Deletions can't be reverted; restore from the trash instead. Requires 'manga:manage' permission.
+// @Tags         Manga
+// @Produce      json
+// @Security     BearerAuth
+// @Param        manga_id     path      string  true  "Manga ID"
+// @Param        revision_id  path      string  true  "Revision ID"
+// @Param        If-Match     header    string  false "ETag of the manga or chapter as last read"
+// @Success      200          {object}  domain.Manga  "The reverted manga, or chapter (domain.Chapter) for revisions of chapters"
+// @Failure      400          {object}  map[string]string
+// @Failure      401          {object}  map[string]string
+// @Failure      403          {object}  map[string]string
+// @Failure      404          {object}  map[string]string
+// @Failure      409          {object}  map[string]string
+// @Failure      412          {object}  map[string]string
+// @Failure      428          {object}  map[string]string
+// @Failure      500          {object}  map[string]string
+// @Router       /manga/{manga_id}/history/{revision_id}/revert [post]
+func (h *HistoryHandler) RevertRevision(c *gin.Context) {
+	mangaID, err := uuid.Parse(c.Param("manga_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga ID format"})
+		return
+	}
+	revisionID, err := uuid.Parse(c.Param("revision_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision ID format"})
+		return
+	}
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	revision, err := h.mangaService.GetRevision(c.Request.Context(), mangaID, revisionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRevisionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "revision not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve revision"})
+		return
+	}
+
+	if revision.ChapterID == nil {
+		manga, err := h.mangaService.Revert(c.Request.Context(), revision, version)
+		if err != nil {
+			revertFailed(c, err)
+			return
+		}
+		setETag(c, manga.Version)
+		manga.Localize(preferredLanguages(c))
+		c.JSON(http.StatusOK, manga)
+		return
+	}
+
+	chapter, err := h.chapterService.Revert(c.Request.Context(), revision, version)
+	if err != nil {
+		revertFailed(c, err)
+		return
+	}
+	setETag(c, chapter.Version)
+	c.JSON(http.StatusOK, chapter)
+}
+
+// revertFailed responds with the error of a failed revert.
+func revertFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrVersionConflict):
+		preconditionFailed(c)
+	case errors.Is(err, repository.ErrMangaNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+	case errors.Is(err, repository.ErrChapterNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+	case errors.Is(err, service.ErrRevisionNotRevertible),
+		errors.Is(err, repository.ErrExternalIDConflict),
+		errors.Is(err, repository.ErrChapterAlreadyExists),
+		errors.Is(err, repository.ErrCreatorNotFound),
+		errors.Is(err, repository.ErrUnknownTags): // Deleted since the revision
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revert"})
+	}
+}
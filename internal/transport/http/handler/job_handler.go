@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/0xpanadol/manga/internal/transport/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type JobHandler struct {
+	jobService *service.JobService
+}
+
+func NewJobHandler(jobService *service.JobService) *JobHandler {
+	return &JobHandler{jobService: jobService}
+}
+
+// @Summary      Get a background job
+// @Description  Retrieves the status and result of a job processed by the worker. Only the job's creator or a user with 'chapters:manage' may view it.
+// @Tags         Jobs
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  domain.Job
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /jobs/{id} [get]
+func (h *JobHandler) GetJob(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid job ID format"})
+		return
+	}
+
+	job, err := h.jobService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrJobNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve job"})
+		return
+	}
+
+	// Respond with 404 rather than 403 so job IDs of other users can't be probed.
+	userID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
+	isOwner := job.CreatedBy != nil && *job.CreatedBy == userID
+	if !isOwner && !middleware.HasPermission(c, "chapters:manage") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
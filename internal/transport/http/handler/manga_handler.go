@@ -2,12 +2,15 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/0xpanadol/manga/internal/domain"
 	"github.com/0xpanadol/manga/internal/repository"
 	"github.com/0xpanadol/manga/internal/service"
+	"github.com/0xpanadol/manga/internal/transport/http/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -21,15 +24,129 @@ func NewMangaHandler(mangaService *service.MangaService) *MangaHandler {
 }
 
 type createMangaRequest struct {
-	Title       string   `json:"title" binding:"required,min=2,max=255"`
-	Description string   `json:"description" binding:"required"`
-	Author      string   `json:"author" binding:"required,min=2,max=100"`
-	Status      string   `json:"status" binding:"required,oneof=ongoing completed hiatus cancelled"`
-	Genres      []string `json:"genres" binding:"required,min=1"`
+	Title       string `json:"title" binding:"required,min=2,max=255"`
+	Description string `json:"description" binding:"required"`
+	Author      string `json:"author" binding:"required_without=Creators,omitempty,min=2,max=255"` // Split into creators unless they are given
+	Status      string `json:"status" binding:"required,oneof=ongoing completed hiatus cancelled"`
+	// Defaults to safe on creation; left unchanged on update when omitted
+	ContentRating string   `json:"content_rating" binding:"omitempty,oneof=safe suggestive explicit"`
+	Genres        []string `json:"genres" binding:"required,min=1"` // Tag names or slugs, see GET /tags
+	// Alternative and localized titles, e.g. {"title": "進撃の巨人", "language": "ja"}
+	AltTitles []altTitleRequest `json:"alt_titles" binding:"omitempty,dive"`
+	// Credited creators; the author string is derived from them when given
+	Creators []creatorCreditRequest `json:"creators" binding:"omitempty,max=20,dive"`
+	// Optional publication details
+	Demographic      string            `json:"demographic,omitempty" binding:"omitempty,oneof=shounen shoujo seinen josei"`
+	Year             *int              `json:"year,omitempty" binding:"omitempty,min=1800,max=2200"`
+	OriginalLanguage string            `json:"original_language,omitempty" binding:"max=10"` // e.g. "ja"
+	LastVolume       string            `json:"last_volume,omitempty" binding:"max=20"`
+	LastChapter      string            `json:"last_chapter,omitempty" binding:"max=20"`
+	Links            mangaLinksRequest `json:"links"`
+	SearchLanguage   string            `json:"search_language,omitempty" binding:"max=10"` // Language of the title and description, "en" if omitted
+}
+
+type mangaLinksRequest struct {
+	OfficialURL    string `json:"official_url,omitempty" binding:"omitempty,url,max=255"`
+	MyAnimeListID  *int   `json:"mal_id,omitempty" binding:"omitempty,min=1"`
+	AniListID      *int   `json:"anilist_id,omitempty" binding:"omitempty,min=1"`
+	MangaUpdatesID string `json:"mangaupdates_id,omitempty" binding:"omitempty,alphanum,max=20"`
+}
+
+// applyTo sets every field of the request on the manga.
+func (r createMangaRequest) applyTo(manga *domain.Manga) {
+	manga.Title = r.Title
+	manga.Description = r.Description
+	manga.Author = r.Author
+	manga.Status = domain.MangaStatus(r.Status)
+	manga.ContentRating = domain.ContentRating(r.ContentRating)
+	manga.Genres = r.Genres
+	manga.AltTitles = r.altTitles()
+	manga.Creators = r.creators()
+	r.setDetails(manga)
+}
+
+// setDetails copies the optional publication details, links and search language onto the manga.
+func (r createMangaRequest) setDetails(manga *domain.Manga) {
+	if r.Demographic != "" {
+		demographic := domain.Demographic(r.Demographic)
+		manga.Demographic = &demographic
+	}
+	manga.Year = r.Year
+	manga.OriginalLanguage = optionalString(r.OriginalLanguage)
+	manga.LastVolume = optionalString(r.LastVolume)
+	manga.LastChapter = optionalString(r.LastChapter)
+	manga.Links = domain.MangaLinks{
+		OfficialURL:    optionalString(r.Links.OfficialURL),
+		MyAnimeListID:  r.Links.MyAnimeListID,
+		AniListID:      r.Links.AniListID,
+		MangaUpdatesID: optionalString(r.Links.MangaUpdatesID),
+	}
+	manga.SearchLanguage = r.SearchLanguage
+}
+
+// optionalString returns nil for an empty string, which is stored as NULL.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// creatorCreditRequest credits an existing creator by ID, or one found or created by name.
+type creatorCreditRequest struct {
+	CreatorID string `json:"creator_id" binding:"required_without=Name,omitempty,uuid"`
+	Name      string `json:"name" binding:"max=100"`
+	Role      string `json:"role" binding:"required,oneof=story art"`
+}
+
+type altTitleRequest struct {
+	Title    string `json:"title" binding:"required,max=255"`
+	Language string `json:"language" binding:"required,max=10"`
+}
+
+// altTitles converts the requested alternative titles, never returning nil.
+func (r createMangaRequest) altTitles() []domain.MangaTitle {
+	titles := make([]domain.MangaTitle, 0, len(r.AltTitles))
+	for _, t := range r.AltTitles {
+		titles = append(titles, domain.MangaTitle{Title: t.Title, Language: t.Language})
+	}
+	return titles
+}
+
+func (r createMangaRequest) creators() []domain.MangaCreator {
+	var credits []domain.MangaCreator
+	for _, credit := range r.Creators {
+		creatorID, _ := uuid.Parse(credit.CreatorID) // Validated by binding, uuid.Nil when only a name is given
+		credits = append(credits, domain.MangaCreator{
+			CreatorID: creatorID,
+			Name:      credit.Name,
+			Role:      domain.CreatorRole(credit.Role),
+		})
+	}
+	return credits
+}
+
+// preferredLanguages returns the display languages requested through the lang query parameter
+// (comma-separated) or, failing that, the Accept-Language header, most preferred first.
+func preferredLanguages(c *gin.Context) []string {
+	header := c.Query("lang")
+	if header == "" {
+		header = c.GetHeader("Accept-Language")
+	}
+
+	var languages []string
+	for _, part := range strings.Split(header, ",") {
+		tag, _, _ := strings.Cut(part, ";") // Drop quality values, clients list languages in order
+		if tag = strings.TrimSpace(tag); tag != "" && tag != "*" {
+			languages = append(languages, tag)
+		}
+	}
+	return languages
 }
 
 // @Summary      Create a new manga
-// @Description  Adds a new manga to the catalog. Requires 'manga:manage' permission.
+// @Description  Adds a new manga to the catalog. Creators can be credited explicitly, otherwise the author string is split into story and art credits.
+// @Description  Requires 'manga:manage' permission.
 // @Tags         Manga
 // @Accept       json
 // @Produce      json
@@ -39,6 +156,7 @@ type createMangaRequest struct {
 // @Failure      400  {object}  map[string]string
 // @Failure      401  {object}  map[string]string
 // @Failure      403  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /manga [post]
 func (h *MangaHandler) CreateManga(c *gin.Context) {
@@ -49,28 +167,52 @@ func (h *MangaHandler) CreateManga(c *gin.Context) {
 	}
 
 	manga := &domain.Manga{
-		Title:       req.Title,
-		Description: req.Description,
-		Author:      req.Author,
-		Status:      domain.MangaStatus(req.Status),
-		Genres:      req.Genres,
+		Title:         req.Title,
+		Description:   req.Description,
+		Author:        req.Author,
+		Status:        domain.MangaStatus(req.Status),
+		ContentRating: domain.ContentRating(req.ContentRating),
+		Genres:        req.Genres,
+		AltTitles:     req.altTitles(),
+		Creators:      req.creators(),
 	}
+	req.setDetails(manga)
 
 	if err := h.mangaService.Create(c.Request.Context(), manga); err != nil {
+		if errors.Is(err, repository.ErrExternalIDConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrCreatorNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "creator not found"})
+			return
+		}
+		if errors.Is(err, repository.ErrUnknownTags) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create manga"})
 		return
 	}
 
+	manga.Localize(preferredLanguages(c))
 	c.JSON(http.StatusCreated, manga)
 }
 
 // @Summary      Get a single manga by ID
-// @Description  Retrieves details for a single manga, including its genres.
+// @Description  Retrieves details for a single manga, including its genres, alternative titles and related manga.
+// @Description  DisplayTitle holds the title in the language requested via lang or Accept-Language, falling back to the main title.
+// @Description  Manga with a content rating the user hasn't opted into (only safe for logged-out visitors) are forbidden.
+// @Description  The ETag header holds the manga's version, with a digest of the display languages and content ratings the response was made for: send it as If-None-Match to revalidate a copy, or as If-Match to update or delete the manga.
 // @Tags         Manga
 // @Produce      json
-// @Param        id   path      string  true  "Manga ID"
+// @Param        id    path      string  true   "Manga ID"
+// @Param        lang  query     string  false  "Preferred display languages, comma-separated (e.g., en,ja-ro)"
+// @Param        If-None-Match  header  string  false  "ETag of a cached copy"
 // @Success      200  {object}  domain.Manga
+// @Success      304  "Not Modified"
 // @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /manga/{id} [get]
@@ -91,31 +233,83 @@ func (h *MangaHandler) GetManga(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve manga"})
 		return
 	}
+	if !manga.ContentRating.AllowedBy(middleware.ContentRatings(c)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": service.ErrContentRestricted.Error()})
+		return
+	}
+
+	// Don't reveal the titles of related manga the user may not see
+	ratings := middleware.ContentRatings(c)
+	manga.Relations = slices.DeleteFunc(manga.Relations, func(rel domain.MangaRelation) bool {
+		return !rel.ContentRating.AllowedBy(ratings)
+	})
 
+	// The response varies with the languages and, through the relations, with the content ratings
+	languages := preferredLanguages(c)
+	c.Header("Vary", "Accept-Language, Authorization")
+	if notModifiedTag(c, variantETag(manga.Version, fmt.Sprint(languages, ratings))) {
+		return
+	}
+	manga.Localize(languages)
 	c.JSON(http.StatusOK, manga)
 }
 
 // listMangaRequest defines the query parameters for listing manga.
 type listMangaRequest struct {
-	Page    int    `form:"page,default=1"`
-	PerPage int    `form:"per_page,default=20"`
-	Query   string `form:"q"`
-	Genres  string `form:"genres"` // Comma-separated
-	Status  string `form:"status"`
-	Sort    string `form:"sort"` // e.g., "title", "-created_at"
+	pageRequest
+	Query            string `form:"q"`
+	SearchLanguage   string `form:"search_language" binding:"max=10"`
+	Genres           string `form:"genres"` // Comma-separated
+	GenreMode        string `form:"genres_mode" binding:"omitempty,oneof=all any"`
+	ExcludeGenres    string `form:"exclude_genres"` // Comma-separated
+	ExcludeMode      string `form:"exclude_mode" binding:"omitempty,oneof=all any"`
+	Status           string `form:"status"`
+	ContentRating    string `form:"content_rating"` // Comma-separated
+	Demographic      string `form:"demographic"`    // Comma-separated
+	YearFrom         int    `form:"year_from" binding:"omitempty,min=1800,max=2200"`
+	YearTo           int    `form:"year_to" binding:"omitempty,min=1800,max=2200"`
+	OriginalLanguage string `form:"original_language"`
+	MyAnimeListID    int    `form:"mal_id" binding:"omitempty,min=1"`
+	AniListID        int    `form:"anilist_id" binding:"omitempty,min=1"`
+	MangaUpdatesID   string `form:"mangaupdates_id"`
+	AuthorID         string `form:"author_id" binding:"omitempty,uuid"`
+	Sort             string `form:"sort"` // e.g., "relevance", "title", "-created_at"
+	Facets           bool   `form:"facets"`
+}
+
+// listMangaResponse adds the facets of the search, if asked for, to the list envelope.
+type listMangaResponse struct {
+	listResponse[*domain.Manga]
+	Facets *domain.MangaFacets `json:"facets,omitempty"`
 }
 
 // @Summary      List manga
-// @Description  Retrieves a paginated and filtered list of manga.
+// @Description  Retrieves a paginated and filtered list of manga. Only manga with content ratings the user has opted into are listed (only safe for logged-out visitors).
 // @Tags         Manga
 // @Produce      json
 // @Param        page      query     int     false  "Page number" default(1)
-// @Param        per_page  query     int     false  "Items per page" default(20)
-// @Param        q         query     string  false  "Full-text search query for title and description"
-// @Param        genres    query     string  false  "Filter by comma-separated genre names (e.g., Action,Fantasy)"
+// @Param        per_page  query     int     false  "Items per page (at most 100)" default(20)
+// @Param        cursor    query     string  false  "next_cursor of the previous page, to continue right after it. Faster than page on deep pages; needs the same sort"
+// @Param        q         query     string  false  "Search the title, alternative titles and description. Matches word prefixes and tolerates typos in titles"
+// @Param        search_language  query  string  false  "Language of q, for stemming and stop words (e.g., en, fr)" default(en)
+// @Param        genres    query     string  false  "Filter by comma-separated tag names or slugs (e.g., Action,slice-of-life)"
+// @Param        genres_mode     query  string  false  "Whether manga must have all or any of the genres" Enums(all, any) default(all)
+// @Param        exclude_genres  query  string  false  "Hide manga with these comma-separated tag names or slugs"
+// @Param        exclude_mode    query  string  false  "Hide manga with any of the excluded genres, or only those with all of them" Enums(any, all) default(any)
 // @Param        status    query     string  false  "Filter by status" Enums(ongoing, completed, hiatus, cancelled)
-// @Param        sort      query     string  false  "Sort order (e.g., title, -created_at)"
-// @Success      200       {array}   domain.Manga
+// @Param        content_rating  query  string  false  "Filter by comma-separated content ratings (safe, suggestive, explicit), within the user's preferences"
+// @Param        author_id query     string  false  "Filter by creator ID (author or artist)"
+// @Param        demographic        query  string  false  "Filter by comma-separated demographics (shounen, shoujo, seinen, josei)"
+// @Param        year_from          query  int     false  "Only manga that started in or after this year"
+// @Param        year_to            query  int     false  "Only manga that started in or before this year"
+// @Param        original_language  query  string  false  "Filter by original language (e.g., ja, ko)"
+// @Param        mal_id             query  int     false  "Look up by MyAnimeList ID"
+// @Param        anilist_id         query  int     false  "Look up by AniList ID"
+// @Param        mangaupdates_id    query  string  false  "Look up by MangaUpdates ID"
+// @Param        sort      query     string  false  "Sort order (e.g., relevance, title, -year, -created_at). Searches default to relevance"
+// @Param        lang      query     string  false  "Preferred display languages, comma-separated (e.g., en,ja-ro)"
+// @Param        facets    query     bool    false  "Also count all matches by genre, status, content rating and year. The counts of a field ignore the search's filter on it"
+// @Success      200       {object}  handler.listMangaResponse
 // @Failure      400       {object}  map[string]string
 // @Failure      500       {object}  map[string]string
 // @Router       /manga [get]
@@ -127,14 +321,57 @@ func (h *MangaHandler) ListManga(c *gin.Context) {
 	}
 
 	params := repository.ListMangaParams{
-		Limit:       req.PerPage,
-		Offset:      (req.Page - 1) * req.PerPage,
-		SearchQuery: req.Query,
-		Status:      req.Status,
+		Limit:            req.PerPage,
+		Offset:           req.offset(),
+		Cursor:           req.Cursor,
+		SearchQuery:      req.Query,
+		SearchLanguage:   req.SearchLanguage,
+		Status:           req.Status,
+		ContentRatings:   middleware.ContentRatings(c),
+		YearFrom:         req.YearFrom,
+		YearTo:           req.YearTo,
+		OriginalLanguage: req.OriginalLanguage,
+		MyAnimeListID:    req.MyAnimeListID,
+		AniListID:        req.AniListID,
+		MangaUpdatesID:   req.MangaUpdatesID,
+		Facets:           req.Facets,
+	}
+
+	if req.ContentRating != "" {
+		// Narrow the ratings the user may see down to the requested ones
+		for _, r := range strings.Split(req.ContentRating, ",") {
+			rating := domain.ContentRating(r)
+			if !rating.AllowedBy(domain.ContentRatings) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": "unknown content rating: " + r})
+				return
+			}
+			params.ContentRatingFilter = append(params.ContentRatingFilter, rating)
+		}
+	}
+
+	if req.AuthorID != "" {
+		params.CreatorID = uuid.MustParse(req.AuthorID) // Validated by binding
+	}
+
+	if req.Demographic != "" {
+		for _, d := range strings.Split(req.Demographic, ",") {
+			switch demographic := domain.Demographic(d); demographic {
+			case domain.DemographicShounen, domain.DemographicShoujo, domain.DemographicSeinen, domain.DemographicJosei:
+				params.Demographics = append(params.Demographics, demographic)
+			default:
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": "unknown demographic: " + d})
+				return
+			}
+		}
 	}
 
 	if req.Genres != "" {
 		params.Genres = strings.Split(req.Genres, ",")
+		params.GenreMode = req.GenreMode
+	}
+	if req.ExcludeGenres != "" {
+		params.ExcludedGenres = strings.Split(req.ExcludeGenres, ",")
+		params.ExcludedGenreMode = req.ExcludeMode
 	}
 
 	if req.Sort != "" {
@@ -147,13 +384,54 @@ func (h *MangaHandler) ListManga(c *gin.Context) {
 		}
 	}
 
-	mangas, err := h.mangaService.List(c.Request.Context(), params)
+	page, err := h.mangaService.List(c.Request.Context(), params)
 	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			invalidCursor(c)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list manga"})
 		return
 	}
 
-	c.JSON(http.StatusOK, mangas)
+	languages := preferredLanguages(c)
+	for _, manga := range page.Items {
+		manga.Localize(languages)
+	}
+	c.JSON(http.StatusOK, listMangaResponse{
+		listResponse: newListResponse(&page.Page, req.pageRequest),
+		Facets:       page.Facets,
+	})
+}
+
+type autocompleteRequest struct {
+	Query string `form:"q" binding:"required,max=100"`
+	Limit int    `form:"limit,default=10" binding:"min=1,max=20"`
+}
+
+// @Summary      Autocomplete manga titles
+// @Description  Suggests manga for a search being typed, by main or alternative titles that start with or resemble it, tolerating typos. Only manga with content ratings the user has opted into are suggested.
+// @Tags         Manga
+// @Produce      json
+// @Param        q      query     string  true   "The search typed so far"
+// @Param        limit  query     int     false  "Maximum number of suggestions" default(10)
+// @Success      200    {array}   domain.MangaSuggestion
+// @Failure      400    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /manga/autocomplete [get]
+func (h *MangaHandler) Autocomplete(c *gin.Context) {
+	var req autocompleteRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	suggestions, err := h.mangaService.Autocomplete(c.Request.Context(), req.Query, middleware.ContentRatings(c), req.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to autocomplete manga"})
+		return
+	}
+	c.JSON(http.StatusOK, suggestions)
 }
 
 // updateMangaRequest uses the same fields as createMangaRequest.
@@ -167,31 +445,172 @@ func (h *MangaHandler) UpdateManga(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
 	var req updateMangaRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
 		return
 	}
 
-	manga := &domain.Manga{
-		ID:          id,
-		Title:       req.Title,
-		Description: req.Description,
-		Author:      req.Author,
-		Status:      domain.MangaStatus(req.Status),
-		Genres:      req.Genres,
+	h.saveManga(c, &domain.Manga{ID: id, Version: version}, createMangaRequest(req))
+}
+
+// @Summary      Partially update a manga
+// @Description  Applies a JSON Merge Patch (RFC 7386) to the manga: only the fields in the body change, objects such as links are merged and null clears an optional field.
+// @Description  Lists such as genres, alt_titles and creators are replaced as a whole. Patching author without creators credits the manga to the new author.
+// @Description  Requires 'manga:manage' permission.
+// @Tags         Manga
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string  true  "Manga ID"
+// @Param        If-Match header   string  false "ETag of the manga as last read"
+// @Param        request body      handler.createMangaRequest true "Fields to change"
+// @Success      200     {object}  domain.Manga
+// @Failure      400     {object}  map[string]string
+// @Failure      401     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
+// @Failure      412     {object}  map[string]string
+// @Failure      428     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /manga/{id} [patch]
+func (h *MangaHandler) PatchManga(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga ID format"})
+		return
+	}
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
 	}
 
-	err = h.mangaService.Update(c.Request.Context(), manga)
+	manga, err := h.mangaService.GetByID(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrMangaNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
 			return
 		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve manga"})
+		return
+	}
+	if version != 0 && version != manga.Version {
+		preconditionFailed(c)
+		return
+	}
+
+	req, err := patchMangaRequest(manga, patch)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	// Relations aren't part of the request and are kept as they are. The update is conditional
+	// on the version just read even without If-Match, so a concurrent change isn't overwritten.
+	h.saveManga(c, &domain.Manga{ID: id, Version: manga.Version, Relations: manga.Relations}, req)
+}
+
+// patchMangaRequest applies a merge patch to the request form of the manga.
+func patchMangaRequest(manga *domain.Manga, patch []byte) (createMangaRequest, error) {
+	current := newMangaRequest(manga)
+	fields := patchedFields(patch)
+	if _, ok := fields["author"]; ok {
+		if _, ok := fields["creators"]; !ok {
+			current.Creators = nil // The credits are derived from the new author
+		}
+	}
+	var req createMangaRequest
+	err := applyMergePatch(current, patch, &req)
+	return req, err
+}
+
+// newMangaRequest is the request that would recreate the manga as it is.
+func newMangaRequest(manga *domain.Manga) createMangaRequest {
+	req := createMangaRequest{
+		Title:            manga.Title,
+		Description:      manga.Description,
+		Author:           manga.Author,
+		Status:           string(manga.Status),
+		ContentRating:    string(manga.ContentRating),
+		Genres:           manga.Genres,
+		Year:             manga.Year,
+		OriginalLanguage: derefString(manga.OriginalLanguage),
+		LastVolume:       derefString(manga.LastVolume),
+		LastChapter:      derefString(manga.LastChapter),
+		Links: mangaLinksRequest{
+			OfficialURL:    derefString(manga.Links.OfficialURL),
+			MyAnimeListID:  manga.Links.MyAnimeListID,
+			AniListID:      manga.Links.AniListID,
+			MangaUpdatesID: derefString(manga.Links.MangaUpdatesID),
+		},
+		SearchLanguage: manga.SearchLanguage,
+	}
+	if manga.Demographic != nil {
+		req.Demographic = string(*manga.Demographic)
+	}
+	for _, t := range manga.AltTitles {
+		req.AltTitles = append(req.AltTitles, altTitleRequest{Title: t.Title, Language: t.Language})
+	}
+	for _, credit := range manga.Creators {
+		req.Creators = append(req.Creators, creatorCreditRequest{
+			CreatorID: credit.CreatorID.String(),
+			Name:      credit.Name,
+			Role:      string(credit.Role),
+		})
+	}
+	return req
+}
+
+// derefString returns the empty string for nil, the inverse of optionalString.
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// saveManga sets the fields of the request on the manga, saves it and responds with it. The
+// update is conditional on manga.Version unless that is 0.
+func (h *MangaHandler) saveManga(c *gin.Context, manga *domain.Manga, req createMangaRequest) {
+	req.applyTo(manga)
+	err := h.mangaService.Update(c.Request.Context(), manga)
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			versionConflict(c)
+			return
+		}
+		if errors.Is(err, repository.ErrExternalIDConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrMangaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+			return
+		}
+		if errors.Is(err, repository.ErrCreatorNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "creator not found"})
+			return
+		}
+		if errors.Is(err, repository.ErrUnknownTags) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update manga"})
 		return
 	}
 
+	setETag(c, manga.Version)
+	manga.Localize(preferredLanguages(c))
 	c.JSON(http.StatusOK, manga)
 }
 
@@ -203,8 +622,16 @@ func (h *MangaHandler) DeleteManga(c *gin.Context) {
 		return
 	}
 
-	err = h.mangaService.Delete(c.Request.Context(), id)
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+	err = h.mangaService.Delete(c.Request.Context(), id, version)
 	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			preconditionFailed(c)
+			return
+		}
 		if errors.Is(err, repository.ErrMangaNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
 			return
@@ -215,3 +642,97 @@ func (h *MangaHandler) DeleteManga(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+type setRelationRequest struct {
+	Type string `json:"type" binding:"required,oneof=sequel prequel side_story main_story spin_off original_story alternate_version adapted_from adaptation"`
+}
+
+// @Summary      Relate two manga
+// @Description  Records how the related manga relates to the manga, e.g. {"type": "sequel"} if it is the manga's sequel, replacing any earlier relation between them.
+// @Description  The inverse relation (here, prequel) is recorded on the related manga. Requires 'manga:manage' permission.
+// @Tags         Manga
+// @Accept       json
+// @Security     BearerAuth
+// @Param        id          path  string                     true  "Manga ID"
+// @Param        related_id  path  string                     true  "Related manga ID"
+// @Param        request     body  handler.setRelationRequest  true  "Relation"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /manga/{id}/relations/{related_id} [put]
+func (h *MangaHandler) SetRelation(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga ID format"})
+		return
+	}
+	relatedID, err := uuid.Parse(c.Param("related_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid related manga ID format"})
+		return
+	}
+
+	var req setRelationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	err = h.mangaService.SetRelation(c.Request.Context(), id, relatedID, domain.RelationType(req.Type))
+	if err != nil {
+		if errors.Is(err, service.ErrSelfRelation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrMangaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to relate manga"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      Remove a relation
+// @Description  Removes the relation between the two manga, from both sides. Requires 'manga:manage' permission.
+// @Tags         Manga
+// @Security     BearerAuth
+// @Param        id          path  string  true  "Manga ID"
+// @Param        related_id  path  string  true  "Related manga ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /manga/{id}/relations/{related_id} [delete]
+func (h *MangaHandler) DeleteRelation(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga ID format"})
+		return
+	}
+	relatedID, err := uuid.Parse(c.Param("related_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid related manga ID format"})
+		return
+	}
+
+	if err := h.mangaService.DeleteRelation(c.Request.Context(), id, relatedID); err != nil {
+		if errors.Is(err, repository.ErrRelationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "relation not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove relation"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
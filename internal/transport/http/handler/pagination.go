@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// pageRequest holds the pagination query parameters of list endpoints. A cursor, taken from
+// the next_cursor of the previous response, takes precedence over the page number.
+type pageRequest struct {
+	Page    int    `form:"page,default=1" binding:"min=1"`
+	PerPage int    `form:"per_page,default=20" binding:"min=1,max=100"` // Capped, it is the LIMIT of the query
+	Cursor  string `form:"cursor"`
+}
+
+func (p pageRequest) offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+// listResponse is the envelope of paginated lists. Page is left out when the list was
+// fetched with a cursor, and NextCursor on the last page.
+type listResponse[T any] struct {
+	Data       []T    `json:"data"`
+	Total      int    `json:"total"`
+	Page       int    `json:"page,omitempty"`
+	PerPage    int    `json:"per_page"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func newListResponse[T any](page *repository.Page[T], req pageRequest) listResponse[T] {
+	resp := listResponse[T]{
+		Data:       page.Items,
+		Total:      page.Total,
+		PerPage:    req.PerPage,
+		NextCursor: page.NextCursor,
+	}
+	if req.Cursor == "" {
+		resp.Page = req.Page
+	}
+	return resp
+}
+
+// invalidCursor reports a cursor that could not be read, or was made for another sort order.
+func invalidCursor(c *gin.Context) {
+	c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": repository.ErrInvalidCursor.Error()})
+}
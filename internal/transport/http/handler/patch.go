@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/0xpanadol/manga/pkg/mergepatch"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// applyMergePatch applies a JSON Merge Patch to current, the request form of the resource, and
+// decodes and validates the result into req. A patched resource is thus checked like a full
+// update, and fields the patch leaves out keep their values.
+func applyMergePatch(current interface{}, patch []byte, req interface{}) error {
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	patched, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(patched, req); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return fmt.Errorf("%s must be a %s", typeErr.Field, typeErr.Type)
+		}
+		return errors.New("the patch must be a JSON object")
+	}
+	return binding.Validator.ValidateStruct(req)
+}
+
+// patchedFields lists the top-level members of a merge patch.
+func patchedFields(patch []byte) map[string]json.RawMessage {
+	var fields map[string]json.RawMessage
+	_ = json.Unmarshal(patch, &fields) // Not an object: the patch replaces everything and fails validation
+	return fields
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/0xpanadol/manga/internal/domain"
@@ -12,11 +13,32 @@ import (
 )
 
 type SocialHandler struct {
-	socialService *service.SocialService
+	socialService  *service.SocialService
+	mangaService   *service.MangaService
+	chapterService *service.ChapterService
 }
 
-func NewSocialHandler(socialService *service.SocialService) *SocialHandler {
-	return &SocialHandler{socialService: socialService}
+func NewSocialHandler(socialService *service.SocialService, mangaService *service.MangaService, chapterService *service.ChapterService) *SocialHandler {
+	return &SocialHandler{socialService: socialService, mangaService: mangaService, chapterService: chapterService}
+}
+
+// checkChapter responds with 404 and returns false if the chapter doesn't exist or the user
+// can't see it, and with 403 if its manga's content rating isn't allowed, like GetChapter does.
+func (h *SocialHandler) checkChapter(c *gin.Context, chapterID uuid.UUID) bool {
+	chapter, err := h.chapterService.GetByID(c.Request.Context(), chapterID)
+	if err != nil {
+		if errors.Is(err, repository.ErrChapterNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve chapter"})
+		return false
+	}
+	if !canSeeChapter(c, chapter) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+		return false
+	}
+	return checkContentRating(c, h.mangaService, chapter.MangaID)
 }
 
 // @Summary      Toggle manga favorite status
@@ -28,6 +50,7 @@ func NewSocialHandler(socialService *service.SocialService) *SocialHandler {
 // @Success      200  {object}  repository.ToggleFavoriteResult
 // @Failure      400  {object}  map[string]string
 // @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /manga/{id}/favorite [post]
 func (h *SocialHandler) ToggleFavorite(c *gin.Context) {
@@ -42,6 +65,10 @@ func (h *SocialHandler) ToggleFavorite(c *gin.Context) {
 
 	result, err := h.socialService.ToggleFavorite(c.Request.Context(), userID, mangaID)
 	if err != nil {
+		if errors.Is(err, repository.ErrMangaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update favorite status"})
 		return
 	}
@@ -55,42 +82,57 @@ func (h *SocialHandler) ToggleFavorite(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        page      query     int     false "Page number" default(1)
-// @Param        per_page  query     int     false "Items per page" default(20)
-// @Success      200       {array}   domain.Manga
+// @Param        per_page  query     int     false "Items per page (at most 100)" default(20)
+// @Param        cursor    query     string  false "next_cursor of the previous page, to continue right after it"
+// @Param        lang      query     string  false "Preferred display languages, comma-separated (e.g., en,ja-ro)"
+// @Success      200       {object}  handler.listResponse[domain.Manga]
+// @Failure      400       {object}  map[string]string
 // @Failure      401       {object}  map[string]string
 // @Failure      500       {object}  map[string]string
 // @Router       /users/me/favorites [get]
 func (h *SocialHandler) ListFavorites(c *gin.Context) {
 	userID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
 
-	var req listMangaRequest // Re-using from manga_handler
+	var req pageRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
 		return
 	}
 
 	params := repository.ListMangaParams{
-		Limit:  req.PerPage,
-		Offset: (req.Page - 1) * req.PerPage,
+		Limit:          req.PerPage,
+		Offset:         req.offset(),
+		Cursor:         req.Cursor,
+		ContentRatings: middleware.ContentRatings(c),
 	}
 
-	mangas, err := h.socialService.ListFavorites(c.Request.Context(), userID, params)
+	page, err := h.socialService.ListFavorites(c.Request.Context(), userID, params)
 	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			invalidCursor(c)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list favorites"})
 		return
 	}
 
-	c.JSON(http.StatusOK, mangas)
+	languages := preferredLanguages(c)
+	for _, manga := range page.Items {
+		manga.Localize(languages)
+	}
+	c.JSON(http.StatusOK, newListResponse(page, req))
 }
 
 // @Summary      Mark chapter as read
-// @Description  Marks a chapter as read for the current user.
+// @Description  Marks a chapter as read for the current user. Unpublished chapters can only be marked by their uploader or users with 'chapters:manage', and chapters of manga with a content rating the user hasn't opted into not at all.
 // @Tags         Social
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Chapter ID"
 // @Success      204  "No Content"
 // @Failure      400  {object}  map[string]string
 // @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /chapters/{id}/progress [post]
 func (h *SocialHandler) MarkChapterAsRead(c *gin.Context) {
@@ -101,9 +143,17 @@ func (h *SocialHandler) MarkChapterAsRead(c *gin.Context) {
 		return
 	}
 
+	if !h.checkChapter(c, chapterID) {
+		return
+	}
+
 	userID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
 
 	if err := h.socialService.MarkChapterAsRead(c.Request.Context(), userID, chapterID); err != nil {
+		if errors.Is(err, repository.ErrChapterNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to mark chapter as read"})
 		return
 	}
@@ -112,7 +162,7 @@ func (h *SocialHandler) MarkChapterAsRead(c *gin.Context) {
 }
 
 // @Summary      List user's read chapters
-// @Description  Retrieves a list of all chapters marked as read by the current user.
+// @Description  Retrieves a list of all published chapters marked as read by the current user, leaving out those of manga with a content rating the user hasn't opted into.
 // @Tags         Social
 // @Produce      json
 // @Security     BearerAuth
@@ -123,7 +173,7 @@ func (h *SocialHandler) MarkChapterAsRead(c *gin.Context) {
 func (h *SocialHandler) ListReadChapters(c *gin.Context) {
 	userID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
 
-	chapters, err := h.socialService.ListReadChapters(c.Request.Context(), userID)
+	chapters, err := h.socialService.ListReadChapters(c.Request.Context(), userID, middleware.ContentRatings(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list read chapters"})
 		return
@@ -137,7 +187,7 @@ type createCommentRequest struct {
 }
 
 // @Summary      Post a comment on a manga
-// @Description  Adds a new comment to a specific manga.
+// @Description  Adds a new comment to a specific manga. Manga with a content rating the user hasn't opted into can't be commented on.
 // @Tags         Social
 // @Accept       json
 // @Produce      json
@@ -147,6 +197,8 @@ type createCommentRequest struct {
 // @Success      201     {object}  domain.Comment
 // @Failure      400     {object}  map[string]string
 // @Failure      401     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
 // @Failure      500     {object}  map[string]string
 // @Router       /manga/{id}/comments [post]
 func (h *SocialHandler) CreateMangaComment(c *gin.Context) {
@@ -162,6 +214,9 @@ func (h *SocialHandler) CreateMangaComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
 		return
 	}
+	if !checkContentRating(c, h.mangaService, mangaID) {
+		return
+	}
 
 	userID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
 
@@ -172,6 +227,10 @@ func (h *SocialHandler) CreateMangaComment(c *gin.Context) {
 	}
 
 	if err := h.socialService.CreateComment(c.Request.Context(), comment); err != nil {
+		if errors.Is(err, repository.ErrMangaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create comment"})
 		return
 	}
@@ -180,7 +239,7 @@ func (h *SocialHandler) CreateMangaComment(c *gin.Context) {
 }
 
 // @Summary      Post a comment on a chapter
-// @Description  Adds a new comment to a specific chapter.
+// @Description  Adds a new comment to a specific chapter. Unpublished chapters can only be commented on by their uploader or users with 'chapters:manage', and chapters of manga with a content rating the user hasn't opted into not at all.
 // @Tags         Social
 // @Accept       json
 // @Produce      json
@@ -190,6 +249,8 @@ func (h *SocialHandler) CreateMangaComment(c *gin.Context) {
 // @Success      201     {object}  domain.Comment
 // @Failure      400     {object}  map[string]string
 // @Failure      401     {object}  map[string]string
+// @Failure      403     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
 // @Failure      500     {object}  map[string]string
 // @Router       /chapters/{id}/comments [post]
 func (h *SocialHandler) CreateChapterComment(c *gin.Context) {
@@ -205,6 +266,9 @@ func (h *SocialHandler) CreateChapterComment(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
 		return
 	}
+	if !h.checkChapter(c, chapterID) {
+		return
+	}
 
 	userID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
 
@@ -215,6 +279,10 @@ func (h *SocialHandler) CreateChapterComment(c *gin.Context) {
 	}
 
 	if err := h.socialService.CreateComment(c.Request.Context(), comment); err != nil {
+		if errors.Is(err, repository.ErrChapterNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create comment"})
 		return
 	}
@@ -223,14 +291,17 @@ func (h *SocialHandler) CreateChapterComment(c *gin.Context) {
 }
 
 // @Summary      List manga comments
-// @Description  Retrieves a paginated list of comments for a specific manga.
+// @Description  Retrieves a paginated list of comments for a specific manga. Comments on manga with a content rating the user hasn't opted into are forbidden.
 // @Tags         Social
 // @Produce      json
 // @Param        id        path      string  true  "Manga ID"
 // @Param        page      query     int     false "Page number" default(1)
-// @Param        per_page  query     int     false "Items per page" default(20)
-// @Success      200       {array}   domain.CommentWithUser
+// @Param        per_page  query     int     false "Items per page (at most 100)" default(20)
+// @Param        cursor    query     string  false "next_cursor of the previous page, to continue right after it"
+// @Success      200       {object}  handler.listResponse[domain.CommentWithUser]
 // @Failure      400       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
 // @Failure      500       {object}  map[string]string
 // @Router       /manga/{id}/comments [get]
 func (h *SocialHandler) ListMangaComments(c *gin.Context) {
@@ -241,9 +312,13 @@ func (h *SocialHandler) ListMangaComments(c *gin.Context) {
 		return
 	}
 
-	var req listMangaRequest // Re-using for pagination
+	var req pageRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	if !checkContentRating(c, h.mangaService, mangaID) {
 		return
 	}
 
@@ -251,27 +326,35 @@ func (h *SocialHandler) ListMangaComments(c *gin.Context) {
 		ParentID:   mangaID,
 		ParentType: domain.ParentTypeManga,
 		Limit:      req.PerPage,
-		Offset:     (req.Page - 1) * req.PerPage,
+		Offset:     req.offset(),
+		Cursor:     req.Cursor,
 	}
 
-	comments, err := h.socialService.ListComments(c.Request.Context(), params)
+	page, err := h.socialService.ListComments(c.Request.Context(), params)
 	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			invalidCursor(c)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list comments"})
 		return
 	}
 
-	c.JSON(http.StatusOK, comments)
+	c.JSON(http.StatusOK, newListResponse(page, req))
 }
 
 // @Summary      List chapter comments
-// @Description  Retrieves a paginated list of comments for a specific chapter.
+// @Description  Retrieves a paginated list of comments for a specific chapter. Comments on unpublished chapters are only listed for their uploader and users with 'chapters:manage'; comments on chapters of manga with a content rating the user hasn't opted into are forbidden.
 // @Tags         Social
 // @Produce      json
 // @Param        id        path      string  true  "Chapter ID"
 // @Param        page      query     int     false "Page number" default(1)
-// @Param        per_page  query     int     false "Items per page" default(20)
-// @Success      200       {array}   domain.CommentWithUser
+// @Param        per_page  query     int     false "Items per page (at most 100)" default(20)
+// @Param        cursor    query     string  false "next_cursor of the previous page, to continue right after it"
+// @Success      200       {object}  handler.listResponse[domain.CommentWithUser]
 // @Failure      400       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
 // @Failure      500       {object}  map[string]string
 // @Router       /chapters/{id}/comments [get]
 func (h *SocialHandler) ListChapterComments(c *gin.Context) {
@@ -282,9 +365,13 @@ func (h *SocialHandler) ListChapterComments(c *gin.Context) {
 		return
 	}
 
-	var req listMangaRequest // Re-using for pagination
+	var req pageRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	if !h.checkChapter(c, chapterID) {
 		return
 	}
 
@@ -292,14 +379,19 @@ func (h *SocialHandler) ListChapterComments(c *gin.Context) {
 		ParentID:   chapterID,
 		ParentType: domain.ParentTypeChapter,
 		Limit:      req.PerPage,
-		Offset:     (req.Page - 1) * req.PerPage,
+		Offset:     req.offset(),
+		Cursor:     req.Cursor,
 	}
 
-	comments, err := h.socialService.ListComments(c.Request.Context(), params)
+	page, err := h.socialService.ListComments(c.Request.Context(), params)
 	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			invalidCursor(c)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list comments"})
 		return
 	}
 
-	c.JSON(http.StatusOK, comments)
+	c.JSON(http.StatusOK, newListResponse(page, req))
 }
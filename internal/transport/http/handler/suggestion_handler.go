@@ -0,0 +1,327 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/0xpanadol/manga/internal/transport/http/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SuggestionHandler lets users suggest edits to manga and moderators review them.
+type SuggestionHandler struct {
+	suggestionService *service.SuggestionService
+	mangaService      *service.MangaService
+}
+
+func NewSuggestionHandler(suggestionService *service.SuggestionService, mangaService *service.MangaService) *SuggestionHandler {
+	return &SuggestionHandler{suggestionService: suggestionService, mangaService: mangaService}
+}
+
+type submitSuggestionRequest struct {
+	// The edit, as a JSON Merge Patch of the manga's fields like for PATCH /manga/{id}
+	Changes map[string]interface{} `json:"changes" binding:"required"`
+	Note    string                 `json:"note" binding:"max=1000"` // e.g. a source for the change
+}
+
+type rejectSuggestionRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"` // Shown to the submitter
+}
+
+type listSuggestionsRequest struct {
+	pageRequest
+	Status  string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	MangaID string `form:"manga_id" binding:"omitempty,uuid"`
+}
+
+// @Summary      Suggest an edit to a manga
+// @Description  Proposes changes to a manga's details, given like a PATCH of the manga. Only the fields that differ from the manga as it is are kept.
+// @Description  The suggestion waits for a moderator to approve or reject it, see GET /users/me/suggestions.
+// @Tags         Suggestions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        manga_id  path      string  true  "Manga ID"
+// @Param        request   body      handler.submitSuggestionRequest true "Suggested changes"
+// @Success      201       {object}  domain.EditSuggestion
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /manga/{manga_id}/suggestions [post]
+func (h *SuggestionHandler) SubmitSuggestion(c *gin.Context) {
+	mangaID, err := uuid.Parse(c.Param("manga_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga ID format"})
+		return
+	}
+
+	var req submitSuggestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+	patch, err := json.Marshal(req.Changes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	manga, err := h.mangaService.GetByID(c.Request.Context(), mangaID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMangaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve manga"})
+		return
+	}
+	edit, err := patchMangaRequest(manga, patch)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+	proposed := &domain.Manga{ID: mangaID}
+	edit.applyTo(proposed)
+
+	suggestion := &domain.EditSuggestion{
+		MangaID: mangaID,
+		UserID:  c.MustGet(middleware.UserIDKey).(uuid.UUID),
+		Note:    optionalString(req.Note),
+	}
+	suggestion, err = h.suggestionService.Submit(c.Request.Context(), suggestion, proposed)
+	if err != nil {
+		if errors.Is(err, service.ErrNoSuggestedChanges) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrMangaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to submit suggestion"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, suggestion)
+}
+
+// @Summary      List my suggestions
+// @Description  Lists the edits the user has suggested, newest first, with their review status and the reason of rejections.
+// @Tags         Suggestions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status    query     string  false "Filter by status" Enums(pending, approved, rejected)
+// @Param        page      query     int     false "Page number" default(1)
+// @Param        per_page  query     int     false "Items per page (at most 100)" default(20)
+// @Param        cursor    query     string  false "next_cursor of the previous page, to continue right after it"
+// @Success      200       {object}  handler.listResponse[domain.EditSuggestion]
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /users/me/suggestions [get]
+func (h *SuggestionHandler) ListMySuggestions(c *gin.Context) {
+	var req listSuggestionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	params := repository.ListSuggestionsParams{
+		UserID: c.MustGet(middleware.UserIDKey).(uuid.UUID),
+		Status: domain.SuggestionStatus(req.Status),
+		Limit:  req.PerPage,
+		Offset: req.offset(),
+		Cursor: req.Cursor,
+	}
+	h.list(c, params, req.pageRequest)
+}
+
+// @Summary      List suggestions
+// @Description  The moderation queue: pending suggestions, oldest first. Reviewed suggestions are listed newest first.
+// @Description  Requires 'manga:manage' permission.
+// @Tags         Suggestions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status    query     string  false "Filter by status" Enums(pending, approved, rejected) default(pending)
+// @Param        manga_id  query     string  false "Only suggestions for this manga"
+// @Param        page      query     int     false "Page number" default(1)
+// @Param        per_page  query     int     false "Items per page (at most 100)" default(20)
+// @Param        cursor    query     string  false "next_cursor of the previous page, to continue right after it"
+// @Success      200       {object}  handler.listResponse[domain.EditSuggestion]
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /suggestions [get]
+func (h *SuggestionHandler) ListSuggestions(c *gin.Context) {
+	var req listSuggestionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+	if req.Status == "" {
+		req.Status = string(domain.SuggestionPending)
+	}
+
+	params := repository.ListSuggestionsParams{
+		Status:      domain.SuggestionStatus(req.Status),
+		OldestFirst: req.Status == string(domain.SuggestionPending),
+		Limit:       req.PerPage,
+		Offset:      req.offset(),
+		Cursor:      req.Cursor,
+	}
+	if req.MangaID != "" {
+		params.MangaID, _ = uuid.Parse(req.MangaID) // Validated by binding
+	}
+	h.list(c, params, req.pageRequest)
+}
+
+func (h *SuggestionHandler) list(c *gin.Context, params repository.ListSuggestionsParams, req pageRequest) {
+	page, err := h.suggestionService.List(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			invalidCursor(c)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list suggestions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newListResponse(page, req))
+}
+
+// @Summary      Get a suggestion
+// @Description  Only the submitter or a user with 'manga:manage' may view it.
+// @Tags         Suggestions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Suggestion ID"
+// @Success      200  {object}  domain.EditSuggestion
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /suggestions/{id} [get]
+func (h *SuggestionHandler) GetSuggestion(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid suggestion ID format"})
+		return
+	}
+
+	suggestion, err := h.suggestionService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrSuggestionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "suggestion not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve suggestion"})
+		return
+	}
+
+	// Respond with 404 rather than 403, like for jobs
+	userID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
+	if suggestion.UserID != userID && !middleware.HasPermission(c, "manga:manage") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "suggestion not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestion)
+}
+
+// @Summary      Approve a suggestion
+// @Description  Applies the suggested changes to the manga. The update appears in the manga's history as made by the submitter.
+// @Description  Fails with 409 if a field it changes has been changed differently since it was submitted. Requires 'manga:manage' permission.
+// @Tags         Suggestions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Suggestion ID"
+// @Success      200  {object}  domain.EditSuggestion
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /suggestions/{id}/approve [post]
+func (h *SuggestionHandler) ApproveSuggestion(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid suggestion ID format"})
+		return
+	}
+
+	reviewerID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
+	suggestion, err := h.suggestionService.Approve(c.Request.Context(), id, reviewerID)
+	if err != nil {
+		reviewFailed(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestion)
+}
+
+// @Summary      Reject a suggestion
+// @Description  Closes the suggestion without applying it. The reason is shown to the submitter. Requires 'manga:manage' permission.
+// @Tags         Suggestions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string  true  "Suggestion ID"
+// @Param        request  body      handler.rejectSuggestionRequest true "Reason"
+// @Success      200      {object}  domain.EditSuggestion
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /suggestions/{id}/reject [post]
+func (h *SuggestionHandler) RejectSuggestion(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid suggestion ID format"})
+		return
+	}
+
+	var req rejectSuggestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	reviewerID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
+	suggestion, err := h.suggestionService.Reject(c.Request.Context(), id, reviewerID, req.Reason)
+	if err != nil {
+		reviewFailed(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestion)
+}
+
+// reviewFailed responds with the error of a failed approval or rejection.
+func reviewFailed(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrSuggestionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "suggestion not found"})
+	case errors.Is(err, repository.ErrMangaNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "manga not found"})
+	case errors.Is(err, repository.ErrVersionConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": "the manga was changed while approving, try again"})
+	case errors.Is(err, repository.ErrSuggestionReviewed),
+		errors.Is(err, service.ErrSuggestionOutdated),
+		errors.Is(err, repository.ErrExternalIDConflict),
+		errors.Is(err, repository.ErrCreatorNotFound),
+		errors.Is(err, repository.ErrUnknownTags): // Deleted since the suggestion
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to review suggestion"})
+	}
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TagHandler struct {
+	tagService *service.TagService
+}
+
+func NewTagHandler(tagService *service.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+type createTagRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=50"`
+	Slug        string `json:"slug,omitempty" binding:"omitempty,max=60"` // Derived from the name if omitted
+	Description string `json:"description,omitempty"`
+	Group       string `json:"group" binding:"required,oneof=genre theme format content_warning"`
+}
+
+// @Summary      List tags
+// @Description  Lists the tags manga can be classified with (genres, themes, formats and content warnings). Use a tag's name or slug in the genres of a manga.
+// @Tags         Tags
+// @Produce      json
+// @Param        group  query     string  false  "Only tags of this group" Enums(genre, theme, format, content_warning)
+// @Success      200    {array}   domain.Tag
+// @Failure      400    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /tags [get]
+func (h *TagHandler) ListTags(c *gin.Context) {
+	group := c.Query("group")
+	switch domain.TagGroup(group) {
+	case "", domain.TagGroupGenre, domain.TagGroupTheme, domain.TagGroupFormat, domain.TagGroupContentWarning:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag group"})
+		return
+	}
+
+	tags, err := h.tagService.List(c.Request.Context(), domain.TagGroup(group))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list tags"})
+		return
+	}
+	if tags == nil {
+		tags = []*domain.Tag{}
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// @Summary      Create a tag
+// @Description  Adds a tag to a group. Requires 'tags:manage' permission.
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.createTagRequest true "Tag Info"
+// @Success      201  {object}  domain.Tag
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /tags [post]
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req createTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	tag := &domain.Tag{
+		Name:        req.Name,
+		Slug:        req.Slug,
+		Description: req.Description,
+		Group:       domain.TagGroup(req.Group),
+	}
+	if err := h.tagService.Create(c.Request.Context(), tag); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tag)
+}
+
+// @Summary      Update a tag
+// @Description  Renames, regroups or describes a tag. Manga keep the tag. Requires 'tags:manage' permission.
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                   true  "Tag ID"
+// @Param        request  body  handler.createTagRequest  true  "Tag Info"
+// @Success      200  {object}  domain.Tag
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /tags/{id} [put]
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag ID format"})
+		return
+	}
+
+	var req createTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	tag := &domain.Tag{
+		ID:          id,
+		Name:        req.Name,
+		Slug:        req.Slug,
+		Description: req.Description,
+		Group:       domain.TagGroup(req.Group),
+	}
+	if err := h.tagService.Update(c.Request.Context(), tag); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// @Summary      Delete a tag
+// @Description  Deletes the tag and removes it from every manga. Requires 'tags:manage' permission.
+// @Tags         Tags
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Tag ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /tags/{id} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag ID format"})
+		return
+	}
+
+	if err := h.tagService.Delete(c.Request.Context(), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TagHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTagNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "tag not found"})
+	case errors.Is(err, service.ErrInvalidSlug):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrTagAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save tag"})
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/0xpanadol/manga/internal/repository"
+	"github.com/0xpanadol/manga/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TrashHandler struct {
+	trashService *service.TrashService
+}
+
+func NewTrashHandler(trashService *service.TrashService) *TrashHandler {
+	return &TrashHandler{trashService: trashService}
+}
+
+// @Summary      List deleted manga
+// @Description  Lists the manga in the trash, most recently deleted first. They are purged for good once the retention period has passed.
+// @Description  Requires 'manga:manage' permission.
+// @Tags         Trash
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page      query     int     false "Page number" default(1)
+// @Param        per_page  query     int     false "Items per page (at most 100)" default(20)
+// @Param        cursor    query     string  false "next_cursor of the previous page, to continue right after it"
+// @Success      200       {object}  handler.listResponse[domain.Manga]
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /trash/manga [get]
+func (h *TrashHandler) ListManga(c *gin.Context) {
+	var req pageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	params := repository.ListTrashParams{Limit: req.PerPage, Offset: req.offset(), Cursor: req.Cursor}
+	page, err := h.trashService.ListManga(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			invalidCursor(c)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list deleted manga"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newListResponse(page, req))
+}
+
+// @Summary      Restore a deleted manga
+// @Description  Takes a manga out of the trash, along with the chapters that were deleted with it. Requires 'manga:manage' permission.
+// @Tags         Trash
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Manga ID"
+// @Success      200  {object}  domain.Manga
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string "An external ID has been taken by another manga since"
+// @Failure      500  {object}  map[string]string
+// @Router       /trash/manga/{id}/restore [post]
+func (h *TrashHandler) RestoreManga(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid manga ID format"})
+		return
+	}
+
+	manga, err := h.trashService.RestoreManga(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrMangaNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "manga not found in the trash"})
+			return
+		}
+		if errors.Is(err, repository.ErrExternalIDConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to restore manga"})
+		return
+	}
+
+	setETag(c, manga.Version)
+	c.JSON(http.StatusOK, manga)
+}
+
+// @Summary      List deleted chapters
+// @Description  Lists the chapters deleted on their own, most recently deleted first. Chapters deleted along with their manga are restored with it.
+// @Description  Requires 'chapters:manage' permission.
+// @Tags         Trash
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page      query     int     false "Page number" default(1)
+// @Param        per_page  query     int     false "Items per page (at most 100)" default(20)
+// @Param        cursor    query     string  false "next_cursor of the previous page, to continue right after it"
+// @Success      200       {object}  handler.listResponse[domain.Chapter]
+// @Failure      400       {object}  map[string]string
+// @Failure      401       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /trash/chapters [get]
+func (h *TrashHandler) ListChapters(c *gin.Context) {
+	var req pageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	params := repository.ListTrashParams{Limit: req.PerPage, Offset: req.offset(), Cursor: req.Cursor}
+	page, err := h.trashService.ListChapters(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			invalidCursor(c)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list deleted chapters"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newListResponse(page, req))
+}
+
+// @Summary      Restore a deleted chapter
+// @Description  Takes a chapter out of the trash. Requires 'chapters:manage' permission.
+// @Tags         Trash
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Chapter ID"
+// @Success      200  {object}  domain.Chapter
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string "The chapter number has been taken since, or the manga is in the trash"
+// @Failure      500  {object}  map[string]string
+// @Router       /trash/chapters/{id}/restore [post]
+func (h *TrashHandler) RestoreChapter(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chapter ID format"})
+		return
+	}
+
+	chapter, err := h.trashService.RestoreChapter(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrChapterNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "chapter not found in the trash"})
+			return
+		}
+		if errors.Is(err, repository.ErrMangaNotFound) {
+			c.JSON(http.StatusConflict, gin.H{"error": "the chapter's manga is in the trash; restore it instead"})
+			return
+		}
+		if errors.Is(err, repository.ErrChapterAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to restore chapter"})
+		return
+	}
+
+	setETag(c, chapter.Version)
+	c.JSON(http.StatusOK, chapter)
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 
+	"github.com/0xpanadol/manga/internal/domain"
 	"github.com/0xpanadol/manga/internal/service"
 	"github.com/0xpanadol/manga/internal/transport/http/middleware"
 	"github.com/gin-gonic/gin"
@@ -46,3 +47,62 @@ func (h *UserHandler) GetMe(c *gin.Context) {
 		Email:    user.Email,
 	})
 }
+
+type preferencesRequest struct {
+	ContentRatings []domain.ContentRating `json:"content_ratings" binding:"required,min=1,dive,oneof=safe suggestive explicit"`
+}
+
+type preferencesResponse struct {
+	ContentRatings []domain.ContentRating `json:"content_ratings"`
+}
+
+// @Summary      Get current user's preferences
+// @Description  Retrieves the content ratings the user sees. New users only see safe manga.
+// @Tags         Users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  handler.preferencesResponse
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /users/me/preferences [get]
+func (h *UserHandler) GetPreferences(c *gin.Context) {
+	userID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
+
+	ratings, err := h.userService.ContentRatings(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not retrieve preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, preferencesResponse{ContentRatings: ratings})
+}
+
+// @Summary      Update current user's preferences
+// @Description  Sets the content ratings of the manga the user sees, e.g. ["safe", "suggestive"]. Manga with other ratings are hidden from lists and cannot be opened.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body handler.preferencesRequest true "Preferences"
+// @Success      200  {object}  handler.preferencesResponse
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /users/me/preferences [put]
+func (h *UserHandler) UpdatePreferences(c *gin.Context) {
+	userID := c.MustGet(middleware.UserIDKey).(uuid.UUID)
+
+	var req preferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	ratings, err := h.userService.UpdateContentRatings(c.Request.Context(), userID, req.ContentRatings)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not update preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, preferencesResponse{ContentRatings: ratings})
+}
@@ -5,6 +5,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/0xpanadol/manga/internal/actor"
 	"github.com/0xpanadol/manga/pkg/jwtauth"
 	"github.com/gin-gonic/gin"
 )
@@ -38,16 +39,50 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
-		// Set user info in context for downstream handlers
+		// Set user info in context for downstream handlers, and the user as the actor of the
+		// request's changes for services
 		c.Set(UserIDKey, claims.UserID)
 		c.Set(UserRoleKey, claims.Role)
 		c.Set(UserPermissionsKey, claims.Permissions)
+		c.Request = c.Request.WithContext(actor.WithUserID(c.Request.Context(), claims.UserID))
 
 		c.Next()
 	}
 }
 
+// OptionalAuthMiddleware sets the user info like AuthMiddleware when a valid bearer token is sent,
+// and otherwise lets the request through anonymously. Public routes use it to show extra
+// content to privileged users.
+func OptionalAuthMiddleware(jwtSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fields := strings.Fields(c.GetHeader(AuthorizationHeaderKey))
+		if len(fields) >= 2 && strings.EqualFold(fields[0], AuthorizationTypeBearer) {
+			if claims, err := jwtauth.ValidateToken(fields[1], jwtSecret); err == nil {
+				c.Set(UserIDKey, claims.UserID)
+				c.Set(UserRoleKey, claims.Role)
+				c.Set(UserPermissionsKey, claims.Permissions)
+				c.Request = c.Request.WithContext(actor.WithUserID(c.Request.Context(), claims.UserID))
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// HasPermission reports whether the authenticated user, if any, holds the permission.
+func HasPermission(c *gin.Context, permission string) bool {
+	permissions, _ := c.Get(UserPermissionsKey)
+	permissionSlice, _ := permissions.([]string)
+	return slices.Contains(permissionSlice, permission)
+}
+
 func PermissionRequired(requiredPermission string) gin.HandlerFunc {
+	return AnyPermissionRequired(requiredPermission)
+}
+
+// AnyPermissionRequired lets through users holding at least one of the permissions. Handlers
+// tell them apart with HasPermission.
+func AnyPermissionRequired(requiredPermissions ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		permissions, exists := c.Get(UserPermissionsKey)
 		if !exists {
@@ -62,7 +97,7 @@ func PermissionRequired(requiredPermission string) gin.HandlerFunc {
 			return
 		}
 
-		if !slices.Contains(permissionSlice, requiredPermission) {
+		if !slices.ContainsFunc(requiredPermissions, func(p string) bool { return slices.Contains(permissionSlice, p) }) {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
 			return
 		}
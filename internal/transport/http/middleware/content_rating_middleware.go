@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"log"
+
+	"github.com/0xpanadol/manga/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const ContentRatingsKey = "ContentRatings"
+
+// ContentRatingPreferences looks up the manga content ratings a user has opted into.
+type ContentRatingPreferences interface {
+	ContentRatings(ctx context.Context, userID uuid.UUID) ([]domain.ContentRating, error)
+}
+
+// ContentRatingMiddleware loads the content ratings of the authenticated user, if any, for
+// ContentRatings. It must run after AuthMiddleware or OptionalAuthMiddleware.
+func ContentRatingMiddleware(preferences ContentRatingPreferences) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userID, exists := c.Get(UserIDKey); exists {
+			ratings, err := preferences.ContentRatings(c.Request.Context(), userID.(uuid.UUID))
+			if err != nil {
+				// Fall back to the defaults rather than showing mature content.
+				log.Printf("Failed to load content ratings of user %s: %v", userID, err)
+			} else {
+				c.Set(ContentRatingsKey, ratings)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// ContentRatings returns the content ratings the request may see. Logged-out visitors get
+// domain.DefaultContentRatings.
+func ContentRatings(c *gin.Context) []domain.ContentRating {
+	if ratings, exists := c.Get(ContentRatingsKey); exists {
+		return ratings.([]domain.ContentRating)
+	}
+	return domain.DefaultContentRatings
+}
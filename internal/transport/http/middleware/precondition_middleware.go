@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IfMatchRequired rejects requests without an If-Match header with 428 Precondition Required,
+// so clients can't update or delete a resource without saying which version they have seen.
+// When required is false, If-Match stays optional and the middleware does nothing.
+func IfMatchRequired(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if required && c.GetHeader("If-Match") == "" {
+			c.AbortWithStatusJSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header required, send the ETag of the resource as last read"})
+			return
+		}
+		c.Next()
+	}
+}
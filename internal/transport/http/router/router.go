@@ -13,8 +13,26 @@ func Setup(
 	mangaHandler *handler.MangaHandler,
 	chapterHandler *handler.ChapterHandler,
 	socialHandler *handler.SocialHandler,
+	jobHandler *handler.JobHandler,
+	downloadHandler *handler.DownloadHandler,
+	coverHandler *handler.CoverHandler,
+	creatorHandler *handler.CreatorHandler,
+	tagHandler *handler.TagHandler,
+	trashHandler *handler.TrashHandler,
+	historyHandler *handler.HistoryHandler,
+	suggestionHandler *handler.SuggestionHandler,
+	chapterReviewHandler *handler.ChapterReviewHandler,
+	preferences middleware.ContentRatingPreferences,
 	jwtSecret string,
+	requireIfMatch bool,
 ) {
+	// Public routes that reveal unpublished chapters to users with 'chapters:manage'
+	optionalAuth := middleware.OptionalAuthMiddleware(jwtSecret)
+	// Routes serving manga hide content ratings the user hasn't opted into; it follows the auth middleware
+	contentRatings := middleware.ContentRatingMiddleware(preferences)
+	// Updates and deletes of manga and chapters may have to say which version they change
+	ifMatch := middleware.IfMatchRequired(requireIfMatch)
+
 	api := router.Group("/api/v1")
 	{
 		auth := api.Group("/auth")
@@ -30,16 +48,20 @@ func Setup(
 		users.Use(middleware.AuthMiddleware(jwtSecret))
 		{
 			users.GET("/me", userHandler.GetMe)
+			users.GET("/me/preferences", userHandler.GetPreferences)
+			users.PUT("/me/preferences", userHandler.UpdatePreferences)
 		}
 
 		// Manga ROUTES
 		manga := api.Group("/manga")
 		{
 			// Public routes
-			manga.GET("/", mangaHandler.ListManga)
-			manga.GET("/:id", mangaHandler.GetManga)
+			manga.GET("/", optionalAuth, contentRatings, mangaHandler.ListManga)
+			manga.GET("/autocomplete", optionalAuth, contentRatings, mangaHandler.Autocomplete)
+			manga.GET("/:id", optionalAuth, contentRatings, mangaHandler.GetManga)
 			// Chapter routes nested under manga
-			manga.GET("/:id/chapters", chapterHandler.ListChapters)
+			manga.GET("/:id/chapters", optionalAuth, contentRatings, chapterHandler.ListChapters)
+			manga.GET("/:id/covers", optionalAuth, contentRatings, coverHandler.ListCovers)
 
 			// Admin-only routes
 			adminManga := manga.Group("/")
@@ -49,31 +71,101 @@ func Setup(
 			)
 			{
 				adminManga.POST("/", mangaHandler.CreateManga)
-				adminManga.PUT("/:id", mangaHandler.UpdateManga)
-				adminManga.DELETE("/:id", mangaHandler.DeleteManga)
+				adminManga.PUT("/:id", ifMatch, mangaHandler.UpdateManga)
+				adminManga.PATCH("/:id", ifMatch, mangaHandler.PatchManga)
+				adminManga.DELETE("/:id", ifMatch, mangaHandler.DeleteManga)
+				adminManga.PUT("/:id/relations/:related_id", mangaHandler.SetRelation)
+				adminManga.DELETE("/:id/relations/:related_id", mangaHandler.DeleteRelation)
+
+				// Every change to a manga or its chapters is recorded in its history
+				adminManga.GET("/:id/history", historyHandler.ListHistory)
+				adminManga.POST("/:manga_id/history/:revision_id/revert", ifMatch, historyHandler.RevertRevision)
+
+				// Covers. POST routes under /manga name the wildcard :manga_id, see the chapter routes below.
+				adminManga.POST("/:manga_id/covers", coverHandler.UploadCover)
+				adminManga.PUT("/:id/covers/:cover_id/primary", coverHandler.SetPrimaryCover)
+				adminManga.DELETE("/:id/covers/:cover_id", coverHandler.DeleteCover)
+			}
+		}
+
+		// Authors & artists ROUTES
+		authors := api.Group("/authors")
+		{
+			authors.GET("/", creatorHandler.ListCreators)
+			authors.GET("/:id", optionalAuth, contentRatings, creatorHandler.GetCreator)
+			authors.POST("/", middleware.AuthMiddleware(jwtSecret), middleware.PermissionRequired("manga:manage"), creatorHandler.CreateCreator)
+		}
+
+		// Tags ROUTES
+		tags := api.Group("/tags")
+		{
+			tags.GET("/", tagHandler.ListTags)
+
+			adminTags := tags.Group("/")
+			adminTags.Use(
+				middleware.AuthMiddleware(jwtSecret),
+				middleware.PermissionRequired("tags:manage"),
+			)
+			{
+				adminTags.POST("/", tagHandler.CreateTag)
+				adminTags.PUT("/:id", tagHandler.UpdateTag)
+				adminTags.DELETE("/:id", tagHandler.DeleteTag)
 			}
 		}
 
+		// Trash ROUTES. Deleted manga and chapters can be restored until the worker purges them.
+		trash := api.Group("/trash")
+		trash.Use(middleware.AuthMiddleware(jwtSecret))
+		{
+			trash.GET("/manga", middleware.PermissionRequired("manga:manage"), trashHandler.ListManga)
+			trash.POST("/manga/:id/restore", middleware.PermissionRequired("manga:manage"), trashHandler.RestoreManga)
+			trash.GET("/chapters", middleware.PermissionRequired("chapters:manage"), trashHandler.ListChapters)
+			trash.POST("/chapters/:id/restore", middleware.PermissionRequired("chapters:manage"), trashHandler.RestoreChapter)
+		}
+
+		// Suggestions ROUTES. Edits suggested by users wait here until a moderator reviews them.
+		suggestions := api.Group("/suggestions")
+		suggestions.Use(middleware.AuthMiddleware(jwtSecret))
+		{
+			suggestions.GET("/", middleware.PermissionRequired("manga:manage"), suggestionHandler.ListSuggestions)
+			suggestions.GET("/:id", suggestionHandler.GetSuggestion) // The submitter's own, or any for moderators
+			suggestions.POST("/:id/approve", middleware.PermissionRequired("manga:manage"), suggestionHandler.ApproveSuggestion)
+			suggestions.POST("/:id/reject", middleware.PermissionRequired("manga:manage"), suggestionHandler.RejectSuggestion)
+		}
+
+		// Review ROUTES. Chapters uploaded with 'chapters:submit' only go live once approved here.
+		review := api.Group("/review")
+		review.Use(middleware.AuthMiddleware(jwtSecret), middleware.PermissionRequired("chapters:manage"))
+		{
+			review.GET("/chapters", chapterReviewHandler.ListPending)
+			review.POST("/chapters/:id/approve", chapterReviewHandler.ApproveChapter)
+			review.POST("/chapters/:id/reject", chapterReviewHandler.RejectChapter)
+		}
+
 		// Chapters ROUTES
 		chapters := api.Group("/chapters")
 		{
-			chapters.GET("/:id", chapterHandler.GetChapter)
+			chapters.GET("/:id", optionalAuth, contentRatings, chapterHandler.GetChapter)
+			chapters.GET("/:id/download", optionalAuth, contentRatings, downloadHandler.DownloadChapter)
 		}
 		// Admin-only routes
 		adminPermission := middleware.PermissionRequired("chapters:manage")
 		authMiddleware := middleware.AuthMiddleware(jwtSecret)
+		// Uploaders may also create chapters, which wait for review, and add pages to them
+		submitPermission := middleware.AnyPermissionRequired("chapters:manage", "chapters:submit")
 
 		// Create chapter is nested under manga for context
-		api.POST("/manga/:manga_id/chapters", authMiddleware, adminPermission, chapterHandler.CreateChapter)
+		api.POST("/manga/:manga_id/chapters", authMiddleware, submitPermission, chapterHandler.CreateChapter)
 
 		// Update/Delete chapter can be at the top level
-		api.PUT("/chapters/:id", authMiddleware, adminPermission, chapterHandler.UpdateChapter)
-		api.DELETE("/chapters/:id", authMiddleware, adminPermission, chapterHandler.DeleteChapter)
-		api.POST("/chapters/:id/pages", authMiddleware, adminPermission, chapterHandler.UploadPages) // New
+		api.PUT("/chapters/:id", authMiddleware, adminPermission, ifMatch, chapterHandler.UpdateChapter)
+		api.PATCH("/chapters/:id", authMiddleware, adminPermission, ifMatch, chapterHandler.PatchChapter)
+		api.DELETE("/chapters/:id", authMiddleware, adminPermission, ifMatch, chapterHandler.DeleteChapter)
+		api.POST("/chapters/:id/pages", authMiddleware, submitPermission, chapterHandler.UploadPages)
 
 		// Public Comment Routes
-		api.GET("/manga/:id/comments", socialHandler.ListMangaComments)
-		api.GET("/chapters/:id/comments", socialHandler.ListChapterComments)
+		api.GET("/manga/:id/comments", optionalAuth, contentRatings, socialHandler.ListMangaComments)
+		api.GET("/chapters/:id/comments", optionalAuth, contentRatings, socialHandler.ListChapterComments)
 
 		// Authenticated Routes
 		authenticated := api.Group("/")
@@ -81,13 +173,26 @@ func Setup(
 		{
 			// Favorites & Progress
 			authenticated.POST("/manga/:manga_id/favorite", socialHandler.ToggleFavorite)
-			authenticated.GET("/users/me/favorites", socialHandler.ListFavorites)
-			authenticated.POST("/chapters/:id/progress", socialHandler.MarkChapterAsRead)
-			authenticated.GET("/users/me/progress", socialHandler.ListReadChapters)
+			authenticated.GET("/users/me/favorites", contentRatings, socialHandler.ListFavorites)
+			authenticated.POST("/chapters/:id/progress", contentRatings, socialHandler.MarkChapterAsRead)
+			authenticated.GET("/users/me/progress", contentRatings, socialHandler.ListReadChapters)
 
 			// Comment Creation
-			authenticated.POST("/manga/:manga_id/comments", socialHandler.CreateMangaComment)
-			authenticated.POST("/chapters/:id/comments", socialHandler.CreateChapterComment)
+			authenticated.POST("/manga/:manga_id/comments", contentRatings, socialHandler.CreateMangaComment)
+			authenticated.POST("/chapters/:id/comments", contentRatings, socialHandler.CreateChapterComment)
+
+			// Edit suggestions
+			authenticated.POST("/manga/:manga_id/suggestions", suggestionHandler.SubmitSuggestion)
+			authenticated.GET("/users/me/suggestions", suggestionHandler.ListMySuggestions)
+
+			// Uploaded chapters and their review
+			authenticated.GET("/users/me/chapters", chapterReviewHandler.ListMyChapters)
+
+			// Background Jobs
+			authenticated.GET("/jobs/:id", jobHandler.GetJob)
+
+			// Volume downloads are built by the worker, so they're tied to a user who can poll the job
+			authenticated.GET("/manga/:id/volumes/:volume/download", contentRatings, downloadHandler.DownloadVolume)
 		}
 	}
 }
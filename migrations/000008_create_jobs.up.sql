@@ -0,0 +1,16 @@
+-- Jobs Table: Tracks long-running work handed off to the worker (e.g., archive extraction)
+CREATE TYPE job_status AS ENUM ('pending', 'running', 'completed', 'failed');
+
+CREATE TABLE "jobs" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "type" varchar(50) NOT NULL,
+  "status" job_status NOT NULL DEFAULT 'pending',
+  "payload" jsonb NOT NULL DEFAULT '{}',
+  "result" jsonb,
+  "error" text,
+  "created_by" uuid REFERENCES "users" ("id") ON DELETE SET NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "jobs" ("created_by");
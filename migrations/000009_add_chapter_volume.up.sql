@@ -0,0 +1,4 @@
+-- Chapters can optionally belong to a volume (e.g., "1", "2", "Extra"), used for volume downloads
+ALTER TABLE "chapters" ADD COLUMN "volume" varchar(20);
+
+CREATE INDEX ON "chapters" ("manga_id", "volume");
@@ -0,0 +1,5 @@
+ALTER TABLE "chapters"
+  DROP COLUMN IF EXISTS "publish_at",
+  DROP COLUMN IF EXISTS "publication_state";
+
+DROP TYPE IF EXISTS chapter_publication_state;
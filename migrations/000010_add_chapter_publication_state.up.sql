@@ -0,0 +1,12 @@
+-- Chapters start as drafts and only become visible to readers once published
+CREATE TYPE chapter_publication_state AS ENUM ('draft', 'scheduled', 'published', 'unpublished');
+
+ALTER TABLE "chapters"
+  ADD COLUMN "publication_state" chapter_publication_state NOT NULL DEFAULT 'draft',
+  ADD COLUMN "publish_at" timestamptz; -- When a scheduled chapter goes live, or when a published one did
+
+-- Chapters that existed before this migration were already public
+UPDATE "chapters" SET "publication_state" = 'published', "publish_at" = "created_at";
+
+-- The scheduler only ever looks at scheduled chapters
+CREATE INDEX ON "chapters" ("publish_at") WHERE "publication_state" = 'scheduled';
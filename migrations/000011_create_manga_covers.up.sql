@@ -0,0 +1,17 @@
+-- Manga Covers: A manga can have several covers (e.g., one per volume or language), one of which is primary.
+-- The primary cover's URL is mirrored into manga.cover_image_url.
+CREATE TABLE "manga_covers" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "manga_id" uuid NOT NULL REFERENCES "manga" ("id") ON DELETE CASCADE,
+  "url" varchar(255) NOT NULL,
+  "thumbnail_url" varchar(255) NOT NULL,
+  "volume" varchar(20),
+  "language" varchar(10),
+  "is_primary" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "manga_covers" ("manga_id");
+
+-- At most one primary cover per manga
+CREATE UNIQUE INDEX ON "manga_covers" ("manga_id") WHERE "is_primary";
@@ -0,0 +1,15 @@
+DROP TRIGGER IF EXISTS manga_titles_tsvectorupdate ON "manga_titles";
+DROP FUNCTION IF EXISTS manga_titles_search_trigger();
+DROP TABLE IF EXISTS "manga_titles";
+
+-- Restore the search trigger from 000006_add_manga_fts
+CREATE OR REPLACE FUNCTION manga_search_trigger() RETURNS trigger AS $$
+begin
+  new.search_tsv :=
+    setweight(to_tsvector('pg_catalog.english', coalesce(new.title,'')), 'A') ||
+    setweight(to_tsvector('pg_catalog.english', coalesce(new.description,'')), 'B');
+  return new;
+end
+$$ LANGUAGE plpgsql;
+
+UPDATE "manga" SET search_tsv = NULL;
@@ -0,0 +1,31 @@
+-- Manga Titles: Alternative and localized titles (e.g., Japanese, romaji, English), tagged with a language
+CREATE TABLE "manga_titles" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "manga_id" uuid NOT NULL REFERENCES "manga" ("id") ON DELETE CASCADE,
+  "title" varchar(255) NOT NULL,
+  "language" varchar(10) NOT NULL,
+  UNIQUE ("manga_id", "language", "title")
+);
+
+-- Alternative titles are searched with the same weight as the main title.
+CREATE OR REPLACE FUNCTION manga_search_trigger() RETURNS trigger AS $$
+begin
+  new.search_tsv :=
+    setweight(to_tsvector('pg_catalog.english', coalesce(new.title,'')), 'A') ||
+    setweight(to_tsvector('pg_catalog.english', coalesce(
+      (SELECT string_agg(t.title, ' ') FROM manga_titles t WHERE t.manga_id = new.id), '')), 'A') ||
+    setweight(to_tsvector('pg_catalog.english', coalesce(new.description,'')), 'B');
+  return new;
+end
+$$ LANGUAGE plpgsql;
+
+-- Changing a manga's titles re-runs the manga trigger above to refresh its search_tsv.
+CREATE OR REPLACE FUNCTION manga_titles_search_trigger() RETURNS trigger AS $$
+begin
+  UPDATE "manga" SET search_tsv = NULL WHERE id = coalesce(new.manga_id, old.manga_id);
+  return null;
+end
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER manga_titles_tsvectorupdate AFTER INSERT OR UPDATE OR DELETE
+ON "manga_titles" FOR EACH ROW EXECUTE PROCEDURE manga_titles_search_trigger();
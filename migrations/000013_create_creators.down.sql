@@ -0,0 +1,6 @@
+-- manga.author still holds the credit line, so nothing needs to be restored.
+DROP TABLE IF EXISTS "manga_creators";
+DROP TYPE IF EXISTS creator_role;
+DROP TABLE IF EXISTS "creators";
+
+ALTER TABLE "manga" ALTER COLUMN "author" TYPE varchar(100) USING left("author", 100);
@@ -0,0 +1,48 @@
+-- Creators Table: Authors and artists, linked to the manga they worked on
+CREATE TABLE "creators" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "name" varchar(100) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- Creators are matched by name when manga are created from a plain author string.
+CREATE UNIQUE INDEX creators_name_idx ON "creators" (lower("name"));
+
+-- Manga_Creators Junction Table: A creator can be credited for the story, the art, or both
+CREATE TYPE creator_role AS ENUM ('story', 'art');
+
+CREATE TABLE "manga_creators" (
+  "manga_id" uuid NOT NULL REFERENCES "manga" ("id") ON DELETE CASCADE,
+  "creator_id" uuid NOT NULL REFERENCES "creators" ("id") ON DELETE CASCADE,
+  "role" creator_role NOT NULL,
+  PRIMARY KEY ("manga_id", "creator_id", "role")
+);
+
+-- For listing a creator's works
+CREATE INDEX ON "manga_creators" ("creator_id", "manga_id");
+
+-- manga.author is kept as the credit line derived from the creators, which may list several names.
+ALTER TABLE "manga" ALTER COLUMN "author" TYPE varchar(255);
+
+-- Split existing author strings like "Tsugumi Ohba, Takeshi Obata" into creators.
+-- A single name is credited for story and art; otherwise the first name is the story and the rest the art.
+-- The separators must match creatorsFromAuthor in internal/service/creator_service.go.
+CREATE TEMP TABLE author_parts AS
+SELECT m.id AS manga_id, trim(p.name) AS name, p.ord, count(*) OVER (PARTITION BY m.id) AS total
+FROM manga m,
+     LATERAL regexp_split_to_table(m.author, '\s*(,|&|/|;|\s+and\s+)\s*', 'i') WITH ORDINALITY AS p(name, ord)
+WHERE trim(p.name) <> '';
+
+INSERT INTO creators (name)
+SELECT DISTINCT ON (lower(name)) name FROM author_parts ORDER BY lower(name), name
+ON CONFLICT DO NOTHING;
+
+INSERT INTO manga_creators (manga_id, creator_id, role)
+SELECT p.manga_id, c.id, r.role
+FROM author_parts p
+JOIN creators c ON lower(c.name) = lower(p.name)
+CROSS JOIN (VALUES ('story'::creator_role), ('art'::creator_role)) AS r(role)
+WHERE p.total = 1 OR (p.ord = 1 AND r.role = 'story') OR (p.ord > 1 AND r.role = 'art')
+ON CONFLICT DO NOTHING;
+
+DROP TABLE author_parts;
@@ -0,0 +1,11 @@
+DELETE FROM roles_permissions WHERE permission_id = (SELECT id FROM permissions WHERE code = 'tags:manage');
+DELETE FROM permissions WHERE code = 'tags:manage';
+
+ALTER TABLE "genres"
+  DROP CONSTRAINT IF EXISTS genres_slug_key,
+  DROP COLUMN IF EXISTS "created_at",
+  DROP COLUMN IF EXISTS "group",
+  DROP COLUMN IF EXISTS "description",
+  DROP COLUMN IF EXISTS "slug";
+
+DROP TYPE IF EXISTS tag_group;
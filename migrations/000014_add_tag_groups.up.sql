@@ -0,0 +1,24 @@
+-- Genres become tags: besides genres they can be themes, formats or content warnings.
+-- The table keeps its name so manga_genres and existing queries stay valid.
+CREATE TYPE tag_group AS ENUM ('genre', 'theme', 'format', 'content_warning');
+
+ALTER TABLE "genres"
+  ADD COLUMN "slug" varchar(60),
+  ADD COLUMN "description" text NOT NULL DEFAULT '',
+  ADD COLUMN "group" tag_group NOT NULL DEFAULT 'genre',
+  ADD COLUMN "created_at" timestamptz NOT NULL DEFAULT (now());
+
+-- e.g., "Slice of Life" -> "slice-of-life"
+UPDATE "genres" SET slug = trim(BOTH '-' FROM regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g'));
+
+ALTER TABLE "genres" ALTER COLUMN "slug" SET NOT NULL;
+ALTER TABLE "genres" ADD CONSTRAINT genres_slug_key UNIQUE ("slug");
+
+-- Add a new permission for managing tags
+INSERT INTO permissions (code) VALUES ('tags:manage');
+
+-- Assign the new permission to the Admin role
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT
+  'a4198182-a398-4244-9635-5b58f3286d79', -- Admin Role ID
+  id FROM permissions WHERE code = 'tags:manage';
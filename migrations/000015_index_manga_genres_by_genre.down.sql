@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "manga_genres_genre_id_idx";
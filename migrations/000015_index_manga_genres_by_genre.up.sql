@@ -0,0 +1,3 @@
+-- The primary key (manga_id, genre_id) answers "which tags does this manga have".
+-- Tag filters ask the reverse, "which manga have this tag", which this index serves.
+CREATE INDEX "manga_genres_genre_id_idx" ON "manga_genres" ("genre_id", "manga_id");
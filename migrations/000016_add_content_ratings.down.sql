@@ -0,0 +1,4 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "content_ratings";
+ALTER TABLE "manga" DROP COLUMN IF EXISTS "content_rating";
+
+DROP TYPE IF EXISTS content_rating;
@@ -0,0 +1,8 @@
+-- Content ratings let mature manga be hidden from minors and logged-out visitors.
+CREATE TYPE content_rating AS ENUM ('safe', 'suggestive', 'explicit');
+
+ALTER TABLE "manga" ADD COLUMN "content_rating" content_rating NOT NULL DEFAULT 'safe';
+CREATE INDEX ON "manga" ("content_rating");
+
+-- The ratings each user has opted into. Everyone starts with safe content only.
+ALTER TABLE "users" ADD COLUMN "content_ratings" content_rating[] NOT NULL DEFAULT '{safe}';
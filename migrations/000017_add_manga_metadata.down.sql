@@ -0,0 +1,12 @@
+ALTER TABLE "manga"
+  DROP COLUMN IF EXISTS "mangaupdates_id",
+  DROP COLUMN IF EXISTS "anilist_id",
+  DROP COLUMN IF EXISTS "mal_id",
+  DROP COLUMN IF EXISTS "official_url",
+  DROP COLUMN IF EXISTS "last_chapter",
+  DROP COLUMN IF EXISTS "last_volume",
+  DROP COLUMN IF EXISTS "original_language",
+  DROP COLUMN IF EXISTS "year",
+  DROP COLUMN IF EXISTS "demographic";
+
+DROP TYPE IF EXISTS manga_demographic;
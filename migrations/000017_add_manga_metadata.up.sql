@@ -0,0 +1,22 @@
+-- Publication details shown on series pages, and links to stores and other catalog sites.
+CREATE TYPE manga_demographic AS ENUM ('shounen', 'shoujo', 'seinen', 'josei');
+
+ALTER TABLE "manga"
+  ADD COLUMN "demographic" manga_demographic,
+  ADD COLUMN "year" smallint CHECK ("year" BETWEEN 1800 AND 2200),
+  ADD COLUMN "original_language" varchar(10),
+  ADD COLUMN "last_volume" varchar(20),
+  ADD COLUMN "last_chapter" varchar(20),
+  ADD COLUMN "official_url" varchar(255),
+  ADD COLUMN "mal_id" integer,
+  ADD COLUMN "anilist_id" integer,
+  ADD COLUMN "mangaupdates_id" varchar(20);
+
+CREATE INDEX ON "manga" ("demographic");
+CREATE INDEX ON "manga" ("year");
+CREATE INDEX ON "manga" ("original_language");
+
+-- A series has one entry on each site, so two manga can't share an ID.
+CREATE UNIQUE INDEX manga_mal_id_key ON "manga" ("mal_id");
+CREATE UNIQUE INDEX manga_anilist_id_key ON "manga" ("anilist_id");
+CREATE UNIQUE INDEX manga_mangaupdates_id_key ON "manga" ("mangaupdates_id");
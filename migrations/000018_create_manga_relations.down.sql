@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "manga_relations";
+DROP TYPE IF EXISTS manga_relation_type;
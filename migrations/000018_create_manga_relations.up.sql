@@ -0,0 +1,20 @@
+-- Manga Relations: "related_manga_id is a <type> of manga_id". Every relation is stored in both
+-- directions with the inverse type (a sequel's inverse is a prequel), see domain.RelationType.
+CREATE TYPE manga_relation_type AS ENUM (
+  'sequel', 'prequel',
+  'side_story', 'main_story',
+  'spin_off', 'original_story',
+  'alternate_version',
+  'adapted_from', 'adaptation'
+);
+
+CREATE TABLE "manga_relations" (
+  "manga_id" uuid NOT NULL REFERENCES "manga" ("id") ON DELETE CASCADE,
+  "related_manga_id" uuid NOT NULL REFERENCES "manga" ("id") ON DELETE CASCADE,
+  "type" manga_relation_type NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("manga_id", "related_manga_id"),
+  CHECK ("manga_id" <> "related_manga_id")
+);
+
+CREATE INDEX ON "manga_relations" ("related_manga_id");
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "manga_titles_title_trgm_idx";
+DROP INDEX IF EXISTS "manga_title_trgm_idx";
+-- The pg_trgm extension is left installed, other database objects may use it.
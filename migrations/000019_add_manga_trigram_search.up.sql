@@ -0,0 +1,6 @@
+-- Trigram matching makes title search tolerate typos ("berserkk") and partial words ("one pic").
+-- The GIN indexes serve both similarity (<%) and prefix (ILIKE 'abc%') matches.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX "manga_title_trgm_idx" ON "manga" USING GIN ("title" gin_trgm_ops);
+CREATE INDEX "manga_titles_title_trgm_idx" ON "manga_titles" USING GIN ("title" gin_trgm_ops);
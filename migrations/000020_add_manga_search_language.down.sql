@@ -0,0 +1,16 @@
+-- Restore the search trigger from 000012_create_manga_titles
+CREATE OR REPLACE FUNCTION manga_search_trigger() RETURNS trigger AS $$
+begin
+  new.search_tsv :=
+    setweight(to_tsvector('pg_catalog.english', coalesce(new.title,'')), 'A') ||
+    setweight(to_tsvector('pg_catalog.english', coalesce(
+      (SELECT string_agg(t.title, ' ') FROM manga_titles t WHERE t.manga_id = new.id), '')), 'A') ||
+    setweight(to_tsvector('pg_catalog.english', coalesce(new.description,'')), 'B');
+  return new;
+end
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE "manga" DROP COLUMN IF EXISTS "search_language";
+DROP FUNCTION IF EXISTS manga_search_config(text);
+
+UPDATE "manga" SET search_tsv = NULL;
@@ -0,0 +1,53 @@
+-- The language of a manga's title and description picks the text-search configuration its
+-- search_tsv is built with. English stemming mangles romaji and other languages' words.
+ALTER TABLE "manga" ADD COLUMN "search_language" varchar(10) NOT NULL DEFAULT 'en';
+
+-- manga_search_config maps a BCP 47 language tag to the built-in configuration for it, ignoring
+-- the region ("pt-BR" -> portuguese). Languages without one, such as Japanese or romaji ("ja-ro"),
+-- fall back to simple, which indexes words as they are.
+CREATE OR REPLACE FUNCTION manga_search_config(language text) RETURNS regconfig AS $$
+  SELECT CASE lower(split_part(language, '-', 1))
+    WHEN 'ar' THEN 'pg_catalog.arabic'
+    WHEN 'da' THEN 'pg_catalog.danish'
+    WHEN 'de' THEN 'pg_catalog.german'
+    WHEN 'el' THEN 'pg_catalog.greek'
+    WHEN 'en' THEN 'pg_catalog.english'
+    WHEN 'es' THEN 'pg_catalog.spanish'
+    WHEN 'fi' THEN 'pg_catalog.finnish'
+    WHEN 'fr' THEN 'pg_catalog.french'
+    WHEN 'hu' THEN 'pg_catalog.hungarian'
+    WHEN 'id' THEN 'pg_catalog.indonesian'
+    WHEN 'it' THEN 'pg_catalog.italian'
+    WHEN 'nl' THEN 'pg_catalog.dutch'
+    WHEN 'no' THEN 'pg_catalog.norwegian'
+    WHEN 'nb' THEN 'pg_catalog.norwegian'
+    WHEN 'pt' THEN 'pg_catalog.portuguese'
+    WHEN 'ro' THEN 'pg_catalog.romanian'
+    WHEN 'ru' THEN 'pg_catalog.russian'
+    WHEN 'sv' THEN 'pg_catalog.swedish'
+    WHEN 'tr' THEN 'pg_catalog.turkish'
+    ELSE 'pg_catalog.simple'
+  END::regconfig
+$$ LANGUAGE sql STABLE;
+
+-- Titles and descriptions use the manga's configuration, alternative titles that of their own language.
+CREATE OR REPLACE FUNCTION manga_search_trigger() RETURNS trigger AS $$
+declare
+  config regconfig := manga_search_config(new.search_language);
+  alt_titles tsvector := '';
+  t record;
+begin
+  FOR t IN SELECT title, language FROM manga_titles WHERE manga_id = new.id LOOP
+    alt_titles := alt_titles || to_tsvector(manga_search_config(t.language), t.title);
+  END LOOP;
+
+  new.search_tsv :=
+    setweight(to_tsvector(config, coalesce(new.title,'')), 'A') ||
+    setweight(alt_titles, 'A') ||
+    setweight(to_tsvector(config, coalesce(new.description,'')), 'B');
+  return new;
+end
+$$ LANGUAGE plpgsql;
+
+-- Rebuild every manga's search_tsv through the trigger.
+UPDATE "manga" SET search_tsv = NULL;
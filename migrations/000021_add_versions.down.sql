@@ -0,0 +1,2 @@
+ALTER TABLE "chapters" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "manga" DROP COLUMN IF EXISTS "version";
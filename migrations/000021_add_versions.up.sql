@@ -0,0 +1,4 @@
+-- Versions make updates conditional: they are served as ETags and checked against If-Match,
+-- so concurrent edits can't silently overwrite each other. Every change increments them.
+ALTER TABLE "manga" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
+ALTER TABLE "chapters" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
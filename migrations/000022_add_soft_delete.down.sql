@@ -0,0 +1,19 @@
+-- The trash is emptied, as the old constraints can't hold trashed duplicates
+DELETE FROM "chapters" WHERE "deleted_at" IS NOT NULL;
+DELETE FROM "manga" WHERE "deleted_at" IS NOT NULL;
+
+DROP INDEX IF EXISTS manga_mal_id_key;
+DROP INDEX IF EXISTS manga_anilist_id_key;
+DROP INDEX IF EXISTS manga_mangaupdates_id_key;
+CREATE UNIQUE INDEX manga_mal_id_key ON "manga" ("mal_id");
+CREATE UNIQUE INDEX manga_anilist_id_key ON "manga" ("anilist_id");
+CREATE UNIQUE INDEX manga_mangaupdates_id_key ON "manga" ("mangaupdates_id");
+
+DROP INDEX IF EXISTS chapters_manga_id_chapter_number_key;
+ALTER TABLE "chapters" ADD CONSTRAINT "chapters_manga_id_chapter_number_key" UNIQUE ("manga_id", "chapter_number");
+
+DROP INDEX IF EXISTS chapters_deleted_at_idx;
+DROP INDEX IF EXISTS manga_deleted_at_idx;
+
+ALTER TABLE "chapters" DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE "manga" DROP COLUMN IF EXISTS "deleted_at";
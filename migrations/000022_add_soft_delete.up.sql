@@ -0,0 +1,20 @@
+-- Deleted manga and chapters go to the trash: deleted_at is set and every query leaves them out
+-- until they are restored, or purged by the worker once the retention period has passed.
+-- Deleting a manga trashes its chapters with the same deleted_at, so restoring it brings back
+-- exactly those.
+ALTER TABLE "manga" ADD COLUMN "deleted_at" timestamptz;
+ALTER TABLE "chapters" ADD COLUMN "deleted_at" timestamptz;
+
+CREATE INDEX manga_deleted_at_idx ON "manga" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
+CREATE INDEX chapters_deleted_at_idx ON "chapters" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
+
+-- Trashed rows don't hold on to their chapter number or external IDs
+ALTER TABLE "chapters" DROP CONSTRAINT "chapters_manga_id_chapter_number_key";
+CREATE UNIQUE INDEX chapters_manga_id_chapter_number_key ON "chapters" ("manga_id", "chapter_number") WHERE "deleted_at" IS NULL;
+
+DROP INDEX manga_mal_id_key;
+DROP INDEX manga_anilist_id_key;
+DROP INDEX manga_mangaupdates_id_key;
+CREATE UNIQUE INDEX manga_mal_id_key ON "manga" ("mal_id") WHERE "deleted_at" IS NULL;
+CREATE UNIQUE INDEX manga_anilist_id_key ON "manga" ("anilist_id") WHERE "deleted_at" IS NULL;
+CREATE UNIQUE INDEX manga_mangaupdates_id_key ON "manga" ("mangaupdates_id") WHERE "deleted_at" IS NULL;
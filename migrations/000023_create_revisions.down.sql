@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "revisions";
+DROP TYPE IF EXISTS revision_action;
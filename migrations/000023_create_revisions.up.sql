@@ -0,0 +1,18 @@
+-- Every change to a manga or chapter is recorded with who made it and a field-level diff.
+-- Revisions go with their manga or chapter when it is purged from the trash.
+CREATE TYPE revision_action AS ENUM ('create', 'update', 'delete', 'restore', 'revert');
+
+CREATE TABLE "revisions" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "manga_id" uuid NOT NULL REFERENCES "manga" ("id") ON DELETE CASCADE,
+  "chapter_id" uuid REFERENCES "chapters" ("id") ON DELETE CASCADE, -- Set for revisions of chapters
+  "action" revision_action NOT NULL,
+  "actor_id" uuid REFERENCES "users" ("id") ON DELETE SET NULL, -- NULL for changes made by the system
+  "changes" jsonb NOT NULL DEFAULT '{}', -- {"Field": {"Old": ..., "New": ...}}
+  "snapshot" jsonb, -- The tracked fields after the change, NULL for deletes
+  "reverted_from" uuid REFERENCES "revisions" ("id") ON DELETE SET NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- The history of a manga lists its revisions and its chapters' newest first
+CREATE INDEX ON "revisions" ("manga_id", "created_at" DESC, "id" DESC);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "edit_suggestions";
+DROP TYPE IF EXISTS suggestion_status;
@@ -0,0 +1,21 @@
+-- Users propose edits to a manga, which wait in a queue until a moderator approves or rejects them.
+CREATE TYPE suggestion_status AS ENUM ('pending', 'approved', 'rejected');
+
+CREATE TABLE "edit_suggestions" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "manga_id" uuid NOT NULL REFERENCES "manga" ("id") ON DELETE CASCADE,
+  "user_id" uuid NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+  "changes" jsonb NOT NULL, -- {"Field": {"Old": ..., "New": ...}} against the manga as it was when submitted
+  "note" text, -- The submitter's explanation, e.g. a source
+  "status" suggestion_status NOT NULL DEFAULT 'pending',
+  "reviewed_by" uuid REFERENCES "users" ("id") ON DELETE SET NULL,
+  "reviewed_at" timestamptz,
+  "rejection_reason" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- The moderation queue lists pending suggestions oldest first
+CREATE INDEX ON "edit_suggestions" ("created_at", "id") WHERE "status" = 'pending';
+-- Users list their own suggestions newest first
+CREATE INDEX ON "edit_suggestions" ("user_id", "created_at" DESC, "id" DESC);
+CREATE INDEX ON "edit_suggestions" ("manga_id");
@@ -0,0 +1,14 @@
+-- Uploaders become regular users again
+UPDATE users SET role_id = 'd29a08e3-40a2-43e5-8f6a-1e6ca3a2f7a9' WHERE role_id = '6f1c2b1e-8a57-4d0c-9a8e-3b7f5d2c4e91';
+DELETE FROM roles WHERE id = '6f1c2b1e-8a57-4d0c-9a8e-3b7f5d2c4e91';
+DELETE FROM roles_permissions WHERE permission_id = (SELECT id FROM permissions WHERE code = 'chapters:submit');
+DELETE FROM permissions WHERE code = 'chapters:submit';
+
+ALTER TABLE "chapters"
+  DROP COLUMN IF EXISTS "uploaded_by",
+  DROP COLUMN IF EXISTS "review_status",
+  DROP COLUMN IF EXISTS "reviewed_by",
+  DROP COLUMN IF EXISTS "reviewed_at",
+  DROP COLUMN IF EXISTS "rejection_reason";
+
+DROP TYPE IF EXISTS chapter_review_status;
@@ -0,0 +1,30 @@
+-- Chapters uploaded by holders of 'chapters:submit' wait for a moderator's review before they can go live.
+-- Chapters created by 'chapters:manage' holders, and those from before, are approved.
+CREATE TYPE chapter_review_status AS ENUM ('pending', 'approved', 'rejected');
+
+ALTER TABLE "chapters"
+  ADD COLUMN "uploaded_by" uuid REFERENCES "users" ("id") ON DELETE SET NULL,
+  ADD COLUMN "review_status" chapter_review_status NOT NULL DEFAULT 'approved',
+  ADD COLUMN "reviewed_by" uuid REFERENCES "users" ("id") ON DELETE SET NULL,
+  ADD COLUMN "reviewed_at" timestamptz,
+  ADD COLUMN "rejection_reason" text;
+
+-- The review queue lists pending chapters oldest first
+CREATE INDEX ON "chapters" ("created_at", "id") WHERE "review_status" = 'pending' AND "deleted_at" IS NULL;
+-- Uploaders list their own chapters newest first
+CREATE INDEX ON "chapters" ("uploaded_by", "created_at" DESC, "id" DESC) WHERE "uploaded_by" IS NOT NULL;
+
+-- Add a new permission for submitting chapters for review
+INSERT INTO permissions (code) VALUES ('chapters:submit');
+
+-- Add an Uploader role for trusted community members, assigned in the database
+INSERT INTO roles (id, name) VALUES ('6f1c2b1e-8a57-4d0c-9a8e-3b7f5d2c4e91', 'Uploader');
+
+-- Uploaders read and submit; Admins get every permission
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT '6f1c2b1e-8a57-4d0c-9a8e-3b7f5d2c4e91', id FROM permissions WHERE code IN ('manga:read', 'chapters:submit');
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT
+  'a4198182-a398-4244-9635-5b58f3286d79', -- Admin Role ID
+  id FROM permissions WHERE code = 'chapters:submit';
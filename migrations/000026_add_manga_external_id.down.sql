@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS manga_external_id_key;
+
+ALTER TABLE "manga" DROP COLUMN IF EXISTS "external_id";
@@ -0,0 +1,5 @@
+-- Key of a manga in the catalog it was imported from, so re-importing the catalog updates the
+-- manga instead of adding it again. Like the other external IDs, trashed manga don't hold on to it.
+ALTER TABLE "manga" ADD COLUMN "external_id" varchar(100);
+
+CREATE UNIQUE INDEX manga_external_id_key ON "manga" ("external_id") WHERE "deleted_at" IS NULL;
@@ -0,0 +1,214 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // Register GIF decoder for validation
+	_ "image/jpeg" // Register JPEG decoder for validation
+	_ "image/png"  // Register PNG decoder for validation
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrInvalidArchive = errors.New("invalid archive")
+	ErrLimitExceeded  = errors.New("archive exceeds size limits")
+	ErrInvalidImage   = errors.New("archive contains an invalid image")
+	ErrNoPages        = errors.New("archive contains no images")
+)
+
+// Limits bounds the work done while extracting an untrusted archive.
+type Limits struct {
+	MaxPages            int     // Maximum number of image entries
+	MaxPageSize         int64   // Maximum uncompressed size of a single entry
+	MaxTotalSize        int64   // Maximum uncompressed size of all entries combined
+	MaxCompressionRatio float64 // Maximum uncompressed/compressed ratio of a single entry
+}
+
+// DefaultLimits are used for any zero field in the Limits passed to ExtractPages.
+var DefaultLimits = Limits{
+	MaxPages:            1000,
+	MaxPageSize:         50 << 20,  // 50 MiB
+	MaxTotalSize:        512 << 20, // 512 MiB
+	MaxCompressionRatio: 100,
+}
+
+// Page is a validated image extracted from an archive.
+type Page struct {
+	Name        string // Path of the entry inside the archive
+	ContentType string
+	Data        []byte
+}
+
+// Extension returns the canonical file extension for the page's content type.
+func (p Page) Extension() string {
+	return extensions[p.ContentType]
+}
+
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ExtractPages reads a ZIP/CBZ archive and returns its images in natural filename order.
+// Non-image entries (ComicInfo.xml, text files, OS metadata) are skipped.
+func ExtractPages(r io.ReaderAt, size int64, limits Limits) ([]Page, error) {
+	limits = limits.withDefaults()
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	var files []*zip.File
+	for _, f := range zr.File {
+		if err := checkEntryName(f.Name); err != nil {
+			return nil, err
+		}
+		if f.FileInfo().IsDir() || isIgnored(f.Name) || !isImageName(f.Name) {
+			continue
+		}
+		files = append(files, f)
+	}
+
+	if len(files) == 0 {
+		return nil, ErrNoPages
+	}
+	if len(files) > limits.MaxPages {
+		return nil, fmt.Errorf("%w: more than %d pages", ErrLimitExceeded, limits.MaxPages)
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return NaturalLess(files[i].Name, files[j].Name)
+	})
+
+	pages := make([]Page, 0, len(files))
+	var total int64
+	for _, f := range files {
+		// The sizes in the header are attacker-controlled, so they are only a cheap first check;
+		// readEntry enforces the real limits while decompressing.
+		if f.UncompressedSize64 > uint64(limits.MaxPageSize) {
+			return nil, fmt.Errorf("%w: %s is larger than %d bytes", ErrLimitExceeded, f.Name, limits.MaxPageSize)
+		}
+		if f.CompressedSize64 > 0 && float64(f.UncompressedSize64)/float64(f.CompressedSize64) > limits.MaxCompressionRatio {
+			return nil, fmt.Errorf("%w: %s has a suspicious compression ratio", ErrLimitExceeded, f.Name)
+		}
+
+		data, err := readEntry(f, limits.MaxPageSize)
+		if err != nil {
+			return nil, err
+		}
+		total += int64(len(data))
+		if total > limits.MaxTotalSize {
+			return nil, fmt.Errorf("%w: total size is larger than %d bytes", ErrLimitExceeded, limits.MaxTotalSize)
+		}
+
+		contentType, err := validateImage(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidImage, f.Name, err)
+		}
+		pages = append(pages, Page{Name: f.Name, ContentType: contentType, Data: data})
+	}
+
+	return pages, nil
+}
+
+// CheckSize rejects an archive of the size before it is stored or read: one larger than
+// MaxTotalSize can't hold pages within it, but for its ZIP headers.
+func (l Limits) CheckSize(size int64) error {
+	if maxSize := l.withDefaults().MaxTotalSize; size > maxSize {
+		return fmt.Errorf("%w: archive is larger than %d bytes", ErrLimitExceeded, maxSize)
+	}
+	return nil
+}
+
+func (l Limits) withDefaults() Limits {
+	if l.MaxPages <= 0 {
+		l.MaxPages = DefaultLimits.MaxPages
+	}
+	if l.MaxPageSize <= 0 {
+		l.MaxPageSize = DefaultLimits.MaxPageSize
+	}
+	if l.MaxTotalSize <= 0 {
+		l.MaxTotalSize = DefaultLimits.MaxTotalSize
+	}
+	if l.MaxCompressionRatio <= 0 {
+		l.MaxCompressionRatio = DefaultLimits.MaxCompressionRatio
+	}
+	return l
+}
+
+// readEntry decompresses a single entry, refusing to read more than maxSize bytes.
+func readEntry(f *zip.File, maxSize int64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, f.Name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, f.Name, err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%w: %s is larger than %d bytes", ErrLimitExceeded, f.Name, maxSize)
+	}
+	return data, nil
+}
+
+// checkEntryName rejects entries that would escape the extraction root (zip-slip).
+func checkEntryName(name string) error {
+	if strings.Contains(name, "\\") || path.IsAbs(name) {
+		return fmt.Errorf("%w: unsafe entry name %q", ErrInvalidArchive, name)
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return fmt.Errorf("%w: unsafe entry name %q", ErrInvalidArchive, name)
+		}
+	}
+	return nil
+}
+
+// isIgnored reports whether the entry is OS or tool metadata rather than content.
+func isIgnored(name string) bool {
+	if strings.HasPrefix(name, "__MACOSX/") {
+		return true
+	}
+	return strings.HasPrefix(path.Base(name), ".")
+}
+
+func isImageName(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp":
+		return true
+	}
+	return false
+}
+
+// validateImage sniffs the real content type and checks that the image header decodes.
+func validateImage(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if _, ok := extensions[contentType]; !ok {
+		return "", fmt.Errorf("unsupported content type %s", contentType)
+	}
+	// The standard library has no WebP decoder; content sniffing already checked its signature.
+	if contentType == "image/webp" {
+		return contentType, nil
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	if cfg.Width == 0 || cfg.Height == 0 {
+		return "", errors.New("image has no dimensions")
+	}
+	return contentType, nil
+}
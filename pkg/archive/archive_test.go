@@ -0,0 +1,101 @@
+package archive_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/0xpanadol/manga/pkg/archive"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pngBytes(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 4, 4))))
+	return buf.Bytes()
+}
+
+func buildZip(t *testing.T, entries map[string][]byte) *bytes.Reader {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range entries {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestExtractPages_NaturalOrderAndSkipsMetadata(t *testing.T) {
+	img := pngBytes(t)
+	r := buildZip(t, map[string][]byte{
+		"ch01/page10.png":       img,
+		"ch01/page2.png":        img,
+		"ch01/page1.png":        img,
+		"ComicInfo.xml":         []byte("<ComicInfo/>"),
+		"__MACOSX/ch01/._page1": []byte("junk"),
+		"ch01/.DS_Store":        []byte("junk"),
+	})
+
+	pages, err := archive.ExtractPages(r, r.Size(), archive.Limits{})
+	require.NoError(t, err)
+	require.Len(t, pages, 3)
+	assert.Equal(t, "ch01/page1.png", pages[0].Name)
+	assert.Equal(t, "ch01/page2.png", pages[1].Name)
+	assert.Equal(t, "ch01/page10.png", pages[2].Name)
+	assert.Equal(t, "image/png", pages[0].ContentType)
+	assert.Equal(t, ".png", pages[0].Extension())
+}
+
+func TestExtractPages_RejectsZipSlip(t *testing.T) {
+	r := buildZip(t, map[string][]byte{"../../evil.png": pngBytes(t)})
+
+	_, err := archive.ExtractPages(r, r.Size(), archive.Limits{})
+	assert.ErrorIs(t, err, archive.ErrInvalidArchive)
+}
+
+func TestExtractPages_EnforcesLimits(t *testing.T) {
+	img := pngBytes(t)
+
+	r := buildZip(t, map[string][]byte{"1.png": img, "2.png": img})
+	_, err := archive.ExtractPages(r, r.Size(), archive.Limits{MaxPages: 1})
+	assert.ErrorIs(t, err, archive.ErrLimitExceeded)
+
+	r = buildZip(t, map[string][]byte{"1.png": img})
+	_, err = archive.ExtractPages(r, r.Size(), archive.Limits{MaxPageSize: 8})
+	assert.ErrorIs(t, err, archive.ErrLimitExceeded)
+
+	// Highly compressible data trips the compression ratio check.
+	r = buildZip(t, map[string][]byte{"bomb.png": make([]byte, 1<<20)})
+	_, err = archive.ExtractPages(r, r.Size(), archive.Limits{})
+	assert.ErrorIs(t, err, archive.ErrLimitExceeded)
+}
+
+func TestExtractPages_RejectsInvalidImages(t *testing.T) {
+	r := buildZip(t, map[string][]byte{"1.jpg": []byte("definitely not a jpeg")})
+
+	_, err := archive.ExtractPages(r, r.Size(), archive.Limits{})
+	assert.ErrorIs(t, err, archive.ErrInvalidImage)
+}
+
+func TestLimits_CheckSize(t *testing.T) {
+	limits := archive.Limits{MaxTotalSize: 1 << 20}
+	assert.NoError(t, limits.CheckSize(1<<20))
+	assert.ErrorIs(t, limits.CheckSize(1<<20+1), archive.ErrLimitExceeded)
+
+	var defaults archive.Limits
+	assert.NoError(t, defaults.CheckSize(archive.DefaultLimits.MaxTotalSize))
+	assert.ErrorIs(t, defaults.CheckSize(archive.DefaultLimits.MaxTotalSize+1), archive.ErrLimitExceeded)
+}
+
+func TestNaturalLess(t *testing.T) {
+	assert.True(t, archive.NaturalLess("page2.jpg", "page10.jpg"))
+	assert.True(t, archive.NaturalLess("Page1.jpg", "page01.jpg"))
+	assert.True(t, archive.NaturalLess("a/9.png", "b/1.png"))
+	assert.False(t, archive.NaturalLess("10", "9"))
+}
@@ -0,0 +1,85 @@
+package archive
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+)
+
+// ExtractDir reads the images of an unpacked chapter folder in natural filename order,
+// applying the same filtering, validation and limits as ExtractPages.
+func ExtractDir(fsys fs.FS, limits Limits) ([]Page, error) {
+	limits = limits.withDefaults()
+
+	var names []string
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// The trailing slash lets a top-level __MACOSX directory match as well.
+		if name != "." && isIgnored(name+"/") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() && isImageName(name) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	if len(names) == 0 {
+		return nil, ErrNoPages
+	}
+	if len(names) > limits.MaxPages {
+		return nil, fmt.Errorf("%w: more than %d pages", ErrLimitExceeded, limits.MaxPages)
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		return NaturalLess(names[i], names[j])
+	})
+
+	pages := make([]Page, 0, len(names))
+	var total int64
+	for _, name := range names {
+		data, err := readFile(fsys, name, limits.MaxPageSize)
+		if err != nil {
+			return nil, err
+		}
+		total += int64(len(data))
+		if total > limits.MaxTotalSize {
+			return nil, fmt.Errorf("%w: total size is larger than %d bytes", ErrLimitExceeded, limits.MaxTotalSize)
+		}
+
+		contentType, err := validateImage(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidImage, name, err)
+		}
+		pages = append(pages, Page{Name: name, ContentType: contentType, Data: data})
+	}
+
+	return pages, nil
+}
+
+// readFile reads a single file, refusing to read more than maxSize bytes.
+func readFile(fsys fs.FS, name string, maxSize int64) ([]byte, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%w: %s is larger than %d bytes", ErrLimitExceeded, name, maxSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,58 @@
+package comicbook
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+)
+
+// WriteCBZ writes a comic book archive: the pages as numbered images plus ComicInfo.xml.
+// Pages are streamed one at a time, so memory use does not grow with the page count.
+func WriteCBZ(ctx context.Context, w io.Writer, meta Metadata, pages []Page) error {
+	zw := zip.NewWriter(w)
+
+	info, err := marshalComicInfo(meta, len(pages))
+	if err != nil {
+		return err
+	}
+	f, err := zw.Create("ComicInfo.xml")
+	if err != nil {
+		return fmt.Errorf("failed to write ComicInfo.xml: %w", err)
+	}
+	if _, err := f.Write(info); err != nil {
+		return fmt.Errorf("failed to write ComicInfo.xml: %w", err)
+	}
+
+	for i, page := range pages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := writeCBZPage(ctx, zw, i, page); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func writeCBZPage(ctx context.Context, zw *zip.Writer, index int, page Page) error {
+	rc, err := page.Open(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open page %s: %w", page.Name, err)
+	}
+	defer rc.Close()
+
+	// Images are already compressed, so store them as-is.
+	f, err := zw.CreateHeader(&zip.FileHeader{
+		Name:   fmt.Sprintf("%04d%s", index+1, pageExtension(page)),
+		Method: zip.Store,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add page %s: %w", page.Name, err)
+	}
+	if _, err := io.Copy(f, rc); err != nil {
+		return fmt.Errorf("failed to write page %s: %w", page.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package comicbook
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Supported output formats.
+const (
+	FormatCBZ  = "cbz"
+	FormatEPUB = "epub"
+	FormatPDF  = "pdf"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported download format")
+
+// Metadata describes the book being assembled. It is written as ComicInfo.xml
+// for CBZ and mapped onto the native metadata of EPUB and PDF.
+type Metadata struct {
+	Identifier string // Stable unique ID, e.g. "urn:uuid:<chapter id>"
+	Series     string
+	Number     string // Chapter number; empty for volume bundles
+	Volume     string
+	Title      string
+	Writer     string
+	Summary    string
+	Language   string // ISO 639-1 code, optional
+}
+
+// DisplayTitle builds a human readable title such as "Berserk Vol. 1 Ch. 3 - The Guardians".
+func (m Metadata) DisplayTitle() string {
+	parts := []string{m.Series}
+	if m.Volume != "" {
+		parts = append(parts, "Vol. "+m.Volume)
+	}
+	if m.Number != "" {
+		parts = append(parts, "Ch. "+m.Number)
+	}
+	title := strings.Join(parts, " ")
+	if m.Title != "" {
+		title += " - " + m.Title
+	}
+	return title
+}
+
+// Page is a single image of the book. Open is called once, when the page is written.
+type Page struct {
+	Name        string // Original file name, used for its extension
+	ContentType string
+	Open        func(ctx context.Context) (io.ReadCloser, error)
+}
+
+// ContentType returns the MIME type of a format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCBZ:
+		return "application/vnd.comicbook+zip"
+	case FormatEPUB:
+		return "application/epub+zip"
+	case FormatPDF:
+		return "application/pdf"
+	}
+	return "application/octet-stream"
+}
+
+// Write assembles the pages into the requested format and writes the result to w.
+func Write(ctx context.Context, w io.Writer, format string, meta Metadata, pages []Page) error {
+	switch format {
+	case FormatCBZ:
+		return WriteCBZ(ctx, w, meta, pages)
+	case FormatEPUB:
+		return WriteEPUB(ctx, w, meta, pages)
+	case FormatPDF:
+		return WritePDF(ctx, w, meta, pages)
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+}
+
+// readPage loads a page fully into memory; PDF and EPUB need the image size up front.
+func readPage(ctx context.Context, page Page) ([]byte, error) {
+	rc, err := page.Open(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open page %s: %w", page.Name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page %s: %w", page.Name, err)
+	}
+	return data, nil
+}
+
+func pageExtension(page Page) string {
+	switch page.ContentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	}
+	return strings.ToLower(path.Ext(page.Name))
+}
+
+// comicInfo is the ComicRack metadata schema understood by most comic readers.
+type comicInfo struct {
+	XMLName     xml.Name        `xml:"ComicInfo"`
+	XSI         string          `xml:"xmlns:xsi,attr"`
+	XSD         string          `xml:"xmlns:xsd,attr"`
+	Title       string          `xml:"Title,omitempty"`
+	Series      string          `xml:"Series,omitempty"`
+	Number      string          `xml:"Number,omitempty"`
+	Volume      string          `xml:"Volume,omitempty"`
+	Summary     string          `xml:"Summary,omitempty"`
+	Writer      string          `xml:"Writer,omitempty"`
+	PageCount   int             `xml:"PageCount"`
+	LanguageISO string          `xml:"LanguageISO,omitempty"`
+	Manga       string          `xml:"Manga"`
+	Pages       []comicInfoPage `xml:"Pages>Page"`
+}
+
+type comicInfoPage struct {
+	Image int    `xml:"Image,attr"`
+	Type  string `xml:"Type,attr,omitempty"`
+}
+
+func marshalComicInfo(meta Metadata, pageCount int) ([]byte, error) {
+	info := comicInfo{
+		XSI:         "http://www.w3.org/2001/XMLSchema-instance",
+		XSD:         "http://www.w3.org/2001/XMLSchema",
+		Title:       meta.Title,
+		Series:      meta.Series,
+		Number:      meta.Number,
+		Volume:      meta.Volume,
+		Summary:     meta.Summary,
+		Writer:      meta.Writer,
+		PageCount:   pageCount,
+		LanguageISO: meta.Language,
+		Manga:       "YesAndRightToLeft",
+	}
+	for i := 0; i < pageCount; i++ {
+		page := comicInfoPage{Image: i}
+		if i == 0 {
+			page.Type = "FrontCover"
+		}
+		info.Pages = append(info.Pages, page)
+	}
+
+	out, err := xml.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ComicInfo.xml: %w", err)
+	}
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,152 @@
+package comicbook_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/0xpanadol/manga/pkg/comicbook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var meta = comicbook.Metadata{
+	Identifier: "urn:uuid:00000000-0000-0000-0000-000000000001",
+	Series:     "Berserk",
+	Number:     "3",
+	Volume:     "1",
+	Title:      "The Guardians",
+	Language:   "en",
+}
+
+// testPages returns a JPEG page and a PNG page, with their encoded images.
+func testPages(t *testing.T) ([]comicbook.Page, [][]byte) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 12))
+	img.Set(1, 1, color.RGBA{R: 255, A: 255})
+
+	var jpg, pngData bytes.Buffer
+	require.NoError(t, jpeg.Encode(&jpg, img, nil))
+	require.NoError(t, png.Encode(&pngData, img))
+
+	page := func(name, contentType string, data []byte) comicbook.Page {
+		return comicbook.Page{Name: name, ContentType: contentType, Open: func(ctx context.Context) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}}
+	}
+	return []comicbook.Page{
+		page("001.jpg", "image/jpeg", jpg.Bytes()),
+		page("002.png", "image/png", pngData.Bytes()),
+	}, [][]byte{jpg.Bytes(), pngData.Bytes()}
+}
+
+func readZip(t *testing.T, data []byte) *zip.Reader {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	return zr
+}
+
+func readEntry(t *testing.T, f *zip.File) []byte {
+	rc, err := f.Open()
+	require.NoError(t, err)
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	return data
+}
+
+func TestWriteCBZ(t *testing.T) {
+	pages, images := testPages(t)
+	var buf bytes.Buffer
+	require.NoError(t, comicbook.Write(context.Background(), &buf, comicbook.FormatCBZ, meta, pages))
+
+	zr := readZip(t, buf.Bytes())
+	require.Len(t, zr.File, 3)
+	assert.Equal(t, "ComicInfo.xml", zr.File[0].Name)
+	assert.Contains(t, string(readEntry(t, zr.File[0])), "<Series>Berserk</Series>")
+
+	for i, name := range []string{"0001.jpg", "0002.png"} {
+		f := zr.File[i+1]
+		assert.Equal(t, name, f.Name)
+		assert.Equal(t, zip.Store, f.Method, "images are stored as they are")
+		assert.Equal(t, images[i], readEntry(t, f))
+	}
+}
+
+func TestWriteEPUB(t *testing.T) {
+	pages, images := testPages(t)
+	var buf bytes.Buffer
+	require.NoError(t, comicbook.Write(context.Background(), &buf, comicbook.FormatEPUB, meta, pages))
+	data := buf.Bytes()
+
+	// The mimetype must be the first entry, stored without an extra field, so it can be read
+	// at a fixed offset of the file
+	assert.Equal(t, "PK\x03\x04", string(data[:4]))
+	assert.Equal(t, "mimetypeapplication/epub+zip", string(data[30:58]))
+
+	zr := readZip(t, data)
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{
+		"mimetype",
+		"META-INF/container.xml",
+		"OEBPS/images/0001.jpg",
+		"OEBPS/pages/0001.xhtml",
+		"OEBPS/images/0002.png",
+		"OEBPS/pages/0002.xhtml",
+		"OEBPS/nav.xhtml",
+		"OEBPS/content.opf",
+	}, names)
+	assert.Equal(t, zip.Store, zr.File[0].Method)
+	for _, f := range zr.File[1:] {
+		assert.Equal(t, zip.Deflate, f.Method, f.Name)
+	}
+
+	assert.Equal(t, images[0], readEntry(t, zr.File[2]))
+	assert.Contains(t, string(readEntry(t, zr.File[3])), `content="width=8, height=12"`)
+	opf := string(readEntry(t, zr.File[7]))
+	assert.Contains(t, opf, "urn:uuid:00000000-0000-0000-0000-000000000001")
+	assert.Contains(t, opf, `href="images/0002.png"`)
+}
+
+func TestWritePDF(t *testing.T) {
+	pages, _ := testPages(t)
+	var buf bytes.Buffer
+	require.NoError(t, comicbook.Write(context.Background(), &buf, comicbook.FormatPDF, meta, pages))
+	data := buf.Bytes()
+
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+	assert.Contains(t, string(data), "/Count 2")
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(data[xref:], []byte("xref\n0 ")))
+
+	// Every entry of the cross-reference table points at the start of its object
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(data[xref:], -1)
+	require.Len(t, entries, 3+3*len(pages)) // Catalog, pages and info, then an image, content and page per page
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		require.Less(t, offset, xref)
+		assert.True(t, bytes.HasPrefix(data[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	err := comicbook.Write(context.Background(), io.Discard, "mobi", meta, nil)
+	assert.ErrorIs(t, err, comicbook.ErrUnsupportedFormat)
+}
@@ -0,0 +1,178 @@
+package comicbook
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"image"
+	_ "image/gif"  // Register GIF decoder for page dimensions
+	_ "image/jpeg" // Register JPEG decoder for page dimensions
+	_ "image/png"  // Register PNG decoder for page dimensions
+	"io"
+	"strings"
+	"time"
+)
+
+// Fallback viewport for pages whose dimensions cannot be read (e.g. WebP).
+const (
+	defaultPageWidth  = 1000
+	defaultPageHeight = 1500
+)
+
+const epubContainer = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+type epubPage struct {
+	image     string
+	document  string
+	mediaType string
+}
+
+// WriteEPUB writes a fixed-layout EPUB 3 book with one XHTML document per page.
+func WriteEPUB(ctx context.Context, w io.Writer, meta Metadata, pages []Page) error {
+	zw := zip.NewWriter(w)
+
+	// The mimetype entry must come first and be stored uncompressed.
+	mimetype, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return fmt.Errorf("failed to write mimetype: %w", err)
+	}
+	if _, err := io.WriteString(mimetype, ContentType(FormatEPUB)); err != nil {
+		return fmt.Errorf("failed to write mimetype: %w", err)
+	}
+	if err := writeZipFile(zw, "META-INF/container.xml", []byte(epubContainer)); err != nil {
+		return err
+	}
+
+	entries := make([]epubPage, 0, len(pages))
+	for i, page := range pages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		data, err := readPage(ctx, page)
+		if err != nil {
+			return err
+		}
+
+		entry := epubPage{
+			image:     fmt.Sprintf("images/%04d%s", i+1, pageExtension(page)),
+			document:  fmt.Sprintf("pages/%04d.xhtml", i+1),
+			mediaType: page.ContentType,
+		}
+		if err := writeZipFile(zw, "OEBPS/"+entry.image, data); err != nil {
+			return err
+		}
+
+		width, height := defaultPageWidth, defaultPageHeight
+		if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+			width, height = cfg.Width, cfg.Height
+		}
+		doc := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml">
+<head>
+  <title>Page %d</title>
+  <meta name="viewport" content="width=%d, height=%d"/>
+  <style>html, body { margin: 0; padding: 0; } img { display: block; width: 100%%; height: 100%%; }</style>
+</head>
+<body><img src="../%s" alt="Page %d"/></body>
+</html>
+`, i+1, width, height, entry.image, i+1)
+		if err := writeZipFile(zw, "OEBPS/"+entry.document, []byte(doc)); err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := writeZipFile(zw, "OEBPS/nav.xhtml", epubNav(meta, entries)); err != nil {
+		return err
+	}
+	if err := writeZipFile(zw, "OEBPS/content.opf", epubPackage(meta, entries)); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func epubPackage(meta Metadata, entries []epubPage) []byte {
+	language := meta.Language
+	if language == "" {
+		language = "und"
+	}
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id" prefix="rendition: http://www.idpf.org/vocab/rendition/#">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+`)
+	fmt.Fprintf(&b, "    <dc:identifier id=\"book-id\">%s</dc:identifier>\n", xmlEscape(meta.Identifier))
+	fmt.Fprintf(&b, "    <dc:title>%s</dc:title>\n", xmlEscape(meta.DisplayTitle()))
+	fmt.Fprintf(&b, "    <dc:language>%s</dc:language>\n", xmlEscape(language))
+	if meta.Writer != "" {
+		fmt.Fprintf(&b, "    <dc:creator>%s</dc:creator>\n", xmlEscape(meta.Writer))
+	}
+	if meta.Summary != "" {
+		fmt.Fprintf(&b, "    <dc:description>%s</dc:description>\n", xmlEscape(meta.Summary))
+	}
+	fmt.Fprintf(&b, "    <meta property=\"dcterms:modified\">%s</meta>\n", time.Now().UTC().Format("2006-01-02T15:04:05Z"))
+	b.WriteString(`    <meta property="rendition:layout">pre-paginated</meta>
+    <meta property="rendition:spread">none</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+`)
+	for i, entry := range entries {
+		properties := ""
+		if i == 0 {
+			properties = ` properties="cover-image"`
+		}
+		fmt.Fprintf(&b, "    <item id=\"img%d\" href=\"%s\" media-type=\"%s\"%s/>\n", i+1, entry.image, entry.mediaType, properties)
+		fmt.Fprintf(&b, "    <item id=\"page%d\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", i+1, entry.document)
+	}
+	b.WriteString("  </manifest>\n  <spine page-progression-direction=\"rtl\">\n")
+	for i := range entries {
+		fmt.Fprintf(&b, "    <itemref idref=\"page%d\"/>\n", i+1)
+	}
+	b.WriteString("  </spine>\n</package>\n")
+	return []byte(b.String())
+}
+
+func epubNav(meta Metadata, entries []epubPage) []byte {
+	first := ""
+	if len(entries) > 0 {
+		first = entries[0].document
+	}
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head><title>%[1]s</title></head>
+<body>
+  <nav epub:type="toc"><ol><li><a href="%[2]s">%[1]s</a></li></ol></nav>
+</body>
+</html>
+`, xmlEscape(meta.DisplayTitle()), first))
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,173 @@
+package comicbook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+var ErrUnsupportedImage = errors.New("image format cannot be embedded in a PDF")
+
+// pdfWriter tracks byte offsets of indirect objects for the cross-reference table.
+type pdfWriter struct {
+	w       io.Writer
+	offset  int64
+	offsets []int64 // offsets[i] is the position of object i+1
+	err     error
+}
+
+func (p *pdfWriter) write(b []byte) {
+	if p.err != nil {
+		return
+	}
+	n, err := p.w.Write(b)
+	p.offset += int64(n)
+	p.err = err
+}
+
+func (p *pdfWriter) printf(format string, args ...any) {
+	p.write([]byte(fmt.Sprintf(format, args...)))
+}
+
+// reserve allocates an object number so it can be referenced before it is written.
+func (p *pdfWriter) reserve() int {
+	p.offsets = append(p.offsets, 0)
+	return len(p.offsets)
+}
+
+func (p *pdfWriter) object(id int, body string) {
+	p.offsets[id-1] = p.offset
+	p.printf("%d 0 obj\n%s\nendobj\n", id, body)
+}
+
+func (p *pdfWriter) stream(id int, dict string, data []byte) {
+	p.offsets[id-1] = p.offset
+	if dict != "" {
+		dict += " "
+	}
+	p.printf("%d 0 obj\n<< %s/Length %d >>\nstream\n", id, dict, len(data))
+	p.write(data)
+	p.printf("\nendstream\nendobj\n")
+}
+
+// WritePDF writes a PDF with one image per page, each page sized to its image.
+// JPEG pages are embedded as-is; other formats are re-encoded as JPEG.
+func WritePDF(ctx context.Context, w io.Writer, meta Metadata, pages []Page) error {
+	p := &pdfWriter{w: w}
+	p.write([]byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"))
+
+	catalogID := p.reserve()
+	pagesID := p.reserve()
+	infoID := p.reserve()
+	p.object(catalogID, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R /ViewerPreferences << /Direction /R2L >> >>", pagesID))
+	p.object(infoID, pdfInfo(meta))
+
+	kids := make([]string, 0, len(pages))
+	for _, page := range pages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		data, err := readPage(ctx, page)
+		if err != nil {
+			return err
+		}
+		img, err := pdfImage(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", page.Name, err)
+		}
+
+		imageID := p.reserve()
+		p.stream(imageID, fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /DCTDecode",
+			img.width, img.height, img.colorSpace), img.data)
+
+		contentID := p.reserve()
+		p.stream(contentID, "", []byte(fmt.Sprintf("q %d 0 0 %d 0 0 cm /Im0 Do Q", img.width, img.height)))
+
+		pageID := p.reserve()
+		p.object(pageID, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Resources << /XObject << /Im0 %d 0 R >> >> /Contents %d 0 R >>",
+			pagesID, img.width, img.height, imageID, contentID))
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageID))
+	}
+
+	p.object(pagesID, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+
+	xrefOffset := p.offset
+	p.printf("xref\n0 %d\n0000000000 65535 f \n", len(p.offsets)+1)
+	for _, offset := range p.offsets {
+		p.printf("%010d 00000 n \n", offset)
+	}
+	p.printf("trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(p.offsets)+1, catalogID, infoID, xrefOffset)
+
+	if p.err != nil {
+		return fmt.Errorf("failed to write PDF: %w", p.err)
+	}
+	return nil
+}
+
+type pdfImageData struct {
+	data          []byte
+	width, height int
+	colorSpace    string
+}
+
+// pdfImage returns JPEG data the PDF can embed with DCTDecode.
+func pdfImage(data []byte) (*pdfImageData, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+
+	// Baseline RGB and grayscale JPEGs can be copied straight into the file.
+	if format == "jpeg" {
+		switch cfg.ColorModel {
+		case color.YCbCrModel:
+			return &pdfImageData{data: data, width: cfg.Width, height: cfg.Height, colorSpace: "DeviceRGB"}, nil
+		case color.GrayModel:
+			return &pdfImageData{data: data, width: cfg.Width, height: cfg.Height, colorSpace: "DeviceGray"}, nil
+		}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, fmt.Errorf("failed to re-encode image: %w", err)
+	}
+	bounds := img.Bounds()
+	return &pdfImageData{data: buf.Bytes(), width: bounds.Dx(), height: bounds.Dy(), colorSpace: "DeviceRGB"}, nil
+}
+
+func pdfInfo(meta Metadata) string {
+	var b strings.Builder
+	b.WriteString("<< /Producer (manga)")
+	fmt.Fprintf(&b, " /Title %s", pdfString(meta.DisplayTitle()))
+	if meta.Writer != "" {
+		fmt.Fprintf(&b, " /Author %s", pdfString(meta.Writer))
+	}
+	if meta.Summary != "" {
+		fmt.Fprintf(&b, " /Subject %s", pdfString(meta.Summary))
+	}
+	b.WriteString(" >>")
+	return b.String()
+}
+
+// pdfString encodes text as a UTF-16BE hex string so non-Latin titles survive.
+func pdfString(s string) string {
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	b.WriteString(">")
+	return b.String()
+}
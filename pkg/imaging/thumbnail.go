@@ -0,0 +1,115 @@
+// Package imaging decodes uploaded images and produces JPEG thumbnails using only the standard library.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // Register GIF decoder
+	"image/jpeg"
+	_ "image/png" // Register PNG decoder
+	"io"
+	"math"
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image, expected JPEG, PNG or GIF")
+	ErrImageTooLarge    = errors.New("image dimensions are too large")
+)
+
+// Limits on the dimensions of images Decode accepts. A small compressed file can declare huge
+// dimensions, and decoding allocates for every pixel.
+const (
+	MaxDimension = 10000      // Width or height
+	MaxPixels    = 40_000_000 // Width times height
+)
+
+// thumbnailQuality is the JPEG quality used for thumbnails.
+const thumbnailQuality = 85
+
+// Decode decodes a JPEG, PNG or GIF image and returns it with its MIME content type. The
+// dimensions in the image header are checked against MaxDimension and MaxPixels before the
+// pixels are decoded.
+func Decode(r io.Reader) (image.Image, string, error) {
+	var header bytes.Buffer
+	cfg, _, err := image.DecodeConfig(io.TeeReader(r, &header))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	if cfg.Width > MaxDimension || cfg.Height > MaxDimension || cfg.Width*cfg.Height > MaxPixels {
+		return nil, "", fmt.Errorf("%w: %dx%d, at most %dx%d and %d pixels", ErrImageTooLarge,
+			cfg.Width, cfg.Height, MaxDimension, MaxDimension, MaxPixels)
+	}
+
+	img, format, err := image.Decode(io.MultiReader(&header, r))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	return img, "image/" + format, nil
+}
+
+// Thumbnail scales img down to fit within maxWidth x maxHeight and encodes it as a JPEG.
+// Transparent areas are flattened onto white.
+func Thumbnail(img image.Image, maxWidth, maxHeight int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, Fit(img, maxWidth, maxHeight), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Fit returns an opaque copy of img scaled down, preserving the aspect ratio, to fit within
+// maxWidth x maxHeight. Images that already fit are copied at their original size.
+func Fit(img image.Image, maxWidth, maxHeight int) *image.RGBA {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// Flatten onto white first, so the box filter below only deals with opaque pixels.
+	src := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Over)
+
+	if width <= maxWidth && height <= maxHeight {
+		return src
+	}
+	scale := math.Min(float64(maxWidth)/float64(width), float64(maxHeight)/float64(height))
+	dstWidth := max(1, int(math.Round(float64(width)*scale)))
+	dstHeight := max(1, int(math.Round(float64(height)*scale)))
+	return downscale(src, dstWidth, dstHeight)
+}
+
+// downscale shrinks src with a box filter: each destination pixel is the average of the
+// source pixels it covers, which avoids the aliasing of nearest-neighbour sampling.
+func downscale(src *image.RGBA, dstWidth, dstHeight int) *image.RGBA {
+	srcWidth, srcHeight := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < dstHeight; y++ {
+		y0 := y * srcHeight / dstHeight
+		y1 := max(y0+1, (y+1)*srcHeight/dstHeight)
+		for x := 0; x < dstWidth; x++ {
+			x0 := x * srcWidth / dstWidth
+			x1 := max(x0+1, (x+1)*srcWidth/dstWidth)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					for c := 0; c < 4; c++ {
+						sum[c] += int(row[sx*4+c])
+					}
+				}
+			}
+
+			n := (y1 - y0) * (x1 - x0)
+			i := dst.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				dst.Pix[i+c] = uint8(sum[c] / n)
+			}
+		}
+	}
+	return dst
+}
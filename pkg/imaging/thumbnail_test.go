@@ -0,0 +1,80 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		wantW, wantH  int
+	}{
+		{"portrait", 1000, 1500, 200, 300},
+		{"landscape", 1200, 400, 300, 100},
+		{"already small", 100, 150, 100, 150},
+		{"extreme ratio", 10000, 10, 300, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewGray(image.Rect(0, 0, tt.width, tt.height))
+			got := Fit(img, 300, 300)
+			assert.Equal(t, tt.wantW, got.Bounds().Dx())
+			assert.Equal(t, tt.wantH, got.Bounds().Dy())
+		})
+	}
+}
+
+func TestFitAveragesAndFlattensTransparency(t *testing.T) {
+	// Alternating black and transparent columns average to mid-grey once flattened onto white.
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x += 2 {
+			img.Set(x, y, color.Black)
+		}
+	}
+
+	got := Fit(img, 2, 1)
+	c := got.RGBAAt(0, 0)
+	assert.InDelta(t, 127, int(c.R), 1)
+	assert.Equal(t, uint8(255), c.A)
+}
+
+func TestDecodeAndThumbnail(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 800, 1200))))
+
+	img, contentType, err := Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+
+	thumb, err := Thumbnail(img, 320, 480)
+	require.NoError(t, err)
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(thumb))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, 320, cfg.Width)
+	assert.Equal(t, 480, cfg.Height)
+
+	_, _, err = Decode(bytes.NewReader([]byte("not an image")))
+	assert.ErrorIs(t, err, ErrUnsupportedImage)
+}
+
+func TestDecodeRejectsOversizedImages(t *testing.T) {
+	// A tiny GIF whose header claims a 50000x50000 logical screen, which would take gigabytes to decode
+	var buf bytes.Buffer
+	require.NoError(t, gif.Encode(&buf, image.NewPaletted(image.Rect(0, 0, 1, 1), color.Palette{color.Black}), nil))
+	data := buf.Bytes()
+	data[6], data[7], data[8], data[9] = 0x50, 0xC3, 0x50, 0xC3 // Little-endian 50000
+
+	_, _, err := Decode(bytes.NewReader(data))
+	assert.ErrorIs(t, err, ErrImageTooLarge)
+}
@@ -0,0 +1,51 @@
+package mergepatch_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xpanadol/manga/pkg/mergepatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test cases from RFC 7386, appendix A.
+func TestApply(t *testing.T) {
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tt := range tests {
+		got, err := mergepatch.Apply([]byte(tt.doc), []byte(tt.patch))
+		require.NoError(t, err)
+		assert.JSONEq(t, tt.want, string(got), "doc %s, patch %s", tt.doc, tt.patch)
+	}
+}
+
+func TestApplyKeepsNumbers(t *testing.T) {
+	got, err := mergepatch.Apply([]byte(`{"id":12345678901234567890}`), []byte(`{"year":2001}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":12345678901234567890,"year":2001}`, string(got))
+}
+
+func TestApplyInvalidPatch(t *testing.T) {
+	for _, patch := range []string{``, `{"a":`, `{} {}`} {
+		_, err := mergepatch.Apply([]byte(`{}`), []byte(patch))
+		assert.True(t, errors.Is(err, mergepatch.ErrInvalidPatch), "patch %q", patch)
+	}
+}
@@ -0,0 +1,209 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalStorage stores objects on the local filesystem. It is intended for
+// single-box deployments where the API serves the files itself through a static route.
+type LocalStorage struct {
+	root    string
+	baseURL string
+	urlPath string
+}
+
+// NewLocalStorage creates the root directory if needed. baseURL is the public URL
+// under which root is served, e.g. "http://localhost:8080/media". Its path can't be the root,
+// which would leave no room for the API's own routes.
+func NewLocalStorage(root, baseURL string) (*LocalStorage, error) {
+	if root == "" {
+		return nil, errors.New("local storage path is required")
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid local storage base URL: %w", err)
+	}
+	urlPath := strings.TrimSuffix(u.Path, "/")
+	if urlPath == "" {
+		return nil, fmt.Errorf("local storage base URL %q needs a path to serve files under, e.g. /media", baseURL)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory: %w", err)
+	}
+	return &LocalStorage{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/") + "/",
+		urlPath: urlPath,
+	}, nil
+}
+
+// Root returns the directory objects are stored in, so it can be mounted as a static route.
+func (s *LocalStorage) Root() string {
+	return s.root
+}
+
+// URLPath returns the path of the base URL, where Root is to be mounted, e.g. "/media".
+func (s *LocalStorage) URLPath() string {
+	return s.urlPath
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	fullPath, key, err := s.resolve(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	// Write to a temporary file first so readers never observe a partial object.
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), fullPath); err != nil {
+		return "", fmt.Errorf("failed to store object: %w", err)
+	}
+
+	return s.URL(key), nil
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	fullPath, _, err := s.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(fullPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to open object: %w", err)
+	}
+	return f, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	fullPath, _, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	fullPath, key, err := s.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := os.Stat(fullPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to stat object: %w", err)
+	}
+	return &ObjectInfo{
+		Key:          key,
+		Size:         fi.Size(),
+		ContentType:  mime.TypeByExtension(path.Ext(key)),
+		LastModified: fi.ModTime(),
+	}, nil
+}
+
+func (s *LocalStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	// Only the directory the prefix ends in can hold matching objects
+	dir := s.root
+	if i := strings.LastIndex(prefix, "/"); i > 0 {
+		var err error
+		if dir, _, err = s.resolve(prefix[:i]); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	var objects []ObjectInfo
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, ObjectInfo{
+			Key:          key,
+			Size:         fi.Size(),
+			ContentType:  mime.TypeByExtension(path.Ext(key)),
+			LastModified: fi.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+	return objects, nil
+}
+
+// SignedURL returns the plain public URL: locally stored objects are served
+// without access control, so there is nothing to sign.
+func (s *LocalStorage) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if _, err := s.Stat(ctx, key); err != nil {
+		return "", err
+	}
+	return s.URL(key), nil
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return s.baseURL + key
+}
+
+func (s *LocalStorage) KeyFromURL(rawURL string) (string, bool) {
+	key, ok := strings.CutPrefix(rawURL, s.baseURL)
+	return key, ok && key != ""
+}
+
+// resolve maps a key to a path inside the storage root.
+func (s *LocalStorage) resolve(key string) (string, string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), key, nil
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// MinioStorage stores objects in a MinIO (or any S3-compatible) bucket.
+type MinioStorage struct {
+	client     *minio.Client
+	bucketName string
+	endpoint   string
+	useSSL     bool
+}
+
+// NewMinioStorage creates and initializes a new MinioStorage.
+func NewMinioStorage(endpoint, accessKey, secretKey, bucketName string, useSSL bool) (*MinioStorage, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create minio client: %w", err)
+	}
+
+	s := &MinioStorage{
+		client:     client,
+		bucketName: bucketName,
+		endpoint:   endpoint,
+		useSSL:     useSSL,
+	}
+
+	// Ensure the bucket exists.
+	if err := s.ensureBucket(context.Background()); err != nil {
+		return nil, fmt.Errorf("bucket initialization failed: %w", err)
+	}
+
+	return s, nil
+}
+
+// ensureBucket creates the bucket if it doesn't already exist.
+func (s *MinioStorage) ensureBucket(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucketName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return s.client.MakeBucket(ctx, s.bucketName, minio.MakeBucketOptions{})
+	}
+	return nil
+}
+
+func (s *MinioStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.client.PutObject(ctx, s.bucketName, key, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file to minio: %w", err)
+	}
+
+	return s.URL(key), nil
+}
+
+func (s *MinioStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	// GetObject is lazy and only fails on first read, so stat first to surface missing keys.
+	info, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := s.client.GetObject(ctx, s.bucketName, info.Key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object from minio: %w", err)
+	}
+	return obj, nil
+}
+
+func (s *MinioStorage) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	if err := s.client.RemoveObject(ctx, s.bucketName, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete object from minio: %w", err)
+	}
+	return nil
+}
+
+func (s *MinioStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := s.client.StatObject(ctx, s.bucketName, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to stat object in minio: %w", err)
+	}
+	return &ObjectInfo{
+		Key:          info.Key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+func (s *MinioStorage) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	for obj := range s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("failed to list objects in minio: %w", obj.Err)
+		}
+		objects = append(objects, ObjectInfo{
+			Key:          obj.Key,
+			Size:         obj.Size,
+			ContentType:  obj.ContentType,
+			LastModified: obj.LastModified,
+		})
+	}
+	return objects, nil
+}
+
+func (s *MinioStorage) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	u, err := s.client.PresignedGetObject(ctx, s.bucketName, key, expiry, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign object url: %w", err)
+	}
+	return u.String(), nil
+}
+
+// URL returns the public URL of the object.
+// Note: For production S3, the URL format would be different.
+// This format works for MinIO's local setup.
+func (s *MinioStorage) URL(key string) string {
+	return s.baseURL() + key
+}
+
+func (s *MinioStorage) KeyFromURL(rawURL string) (string, bool) {
+	key, ok := strings.CutPrefix(rawURL, s.baseURL())
+	return key, ok && key != ""
+}
+
+func (s *MinioStorage) baseURL() string {
+	scheme := "http"
+	if s.useSSL {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/%s/", scheme, s.endpoint, s.bucketName)
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// ErrObjectNotFound is returned when the requested key does not exist in the backend.
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// Storage is implemented by every object storage backend (MinIO/S3, local disk, memory).
+// Keys are slash-separated paths relative to the backend root, e.g. "chapters/<id>/<file>.jpg".
+type Storage interface {
+	// Put stores the content of r under key and returns the object's public URL.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error)
+	// Get opens the object for reading. The caller must close the returned reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// Stat returns metadata about the object.
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	// List returns all objects whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// SignedURL returns a URL granting temporary read access to the object.
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+	// URL returns the public URL of the object.
+	URL(key string) string
+	// KeyFromURL resolves a public URL produced by this backend back to its key.
+	KeyFromURL(rawURL string) (string, bool)
+}
+
+// Supported values for Config.Driver.
+const (
+	DriverMinio = "minio"
+	DriverLocal = "local"
+)
+
+// Config selects and configures a storage backend.
+type Config struct {
+	Driver string
+
+	MinioEndpoint   string
+	MinioAccessKey  string
+	MinioSecretKey  string
+	MinioBucketName string
+	MinioUseSSL     bool
+
+	LocalPath    string
+	LocalBaseURL string
+}
+
+// New creates the backend selected by cfg.Driver. An empty driver defaults to MinIO.
+func New(cfg Config) (Storage, error) {
+	switch cfg.Driver {
+	case "", DriverMinio:
+		return NewMinioStorage(cfg.MinioEndpoint, cfg.MinioAccessKey, cfg.MinioSecretKey, cfg.MinioBucketName, cfg.MinioUseSSL)
+	case DriverLocal:
+		return NewLocalStorage(cfg.LocalPath, cfg.LocalBaseURL)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+// cleanKey normalizes a key and rejects anything that could escape the storage root.
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	cleaned := path.Clean(key)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,147 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xpanadol/manga/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func backends(t *testing.T) map[string]storage.Storage {
+	local, err := storage.NewLocalStorage(t.TempDir(), "http://localhost:8080/media")
+	require.NoError(t, err)
+
+	return map[string]storage.Storage{
+		"local":  local,
+		"memory": storage.NewMemoryStorage(),
+	}
+}
+
+func TestLocalStorage_URLPath(t *testing.T) {
+	for baseURL, want := range map[string]string{
+		"http://localhost:8080/media":       "/media",
+		"https://example.com/static/files/": "/static/files",
+		"/uploads":                          "/uploads",
+	} {
+		local, err := storage.NewLocalStorage(t.TempDir(), baseURL)
+		require.NoError(t, err, baseURL)
+		assert.Equal(t, want, local.URLPath(), baseURL)
+	}
+	for _, baseURL := range []string{"http://localhost:8080", "http://localhost:8080/", ""} {
+		_, err := storage.NewLocalStorage(t.TempDir(), baseURL)
+		assert.Error(t, err, baseURL)
+	}
+}
+
+func TestStorage_RoundTrip(t *testing.T) {
+	for name, s := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			content := "page-content"
+
+			url, err := s.Put(ctx, "chapters/abc/001.jpg", strings.NewReader(content), int64(len(content)), "image/jpeg")
+			require.NoError(t, err)
+
+			key, ok := s.KeyFromURL(url)
+			require.True(t, ok)
+			assert.Equal(t, "chapters/abc/001.jpg", key)
+
+			info, err := s.Stat(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(content)), info.Size)
+
+			r, err := s.Get(ctx, key)
+			require.NoError(t, err)
+			data, err := io.ReadAll(r)
+			r.Close()
+			require.NoError(t, err)
+			assert.Equal(t, content, string(data))
+
+			_, err = s.Put(ctx, "covers/xyz.png", strings.NewReader("x"), 1, "image/png")
+			require.NoError(t, err)
+			objects, err := s.List(ctx, "chapters/")
+			require.NoError(t, err)
+			require.Len(t, objects, 1)
+			assert.Equal(t, key, objects[0].Key)
+
+			signed, err := s.SignedURL(ctx, key, time.Minute)
+			require.NoError(t, err)
+			assert.NotEmpty(t, signed)
+
+			require.NoError(t, s.Delete(ctx, key))
+			_, err = s.Stat(ctx, key)
+			assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+			_, err = s.Get(ctx, key)
+			assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+		})
+	}
+}
+
+func TestStorage_RejectsUnsafeKeys(t *testing.T) {
+	for name, s := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{"../escape.jpg", "/etc/passwd", "a/../../b", ""} {
+				_, err := s.Put(context.Background(), key, strings.NewReader("x"), 1, "")
+				assert.Error(t, err, key)
+			}
+		})
+	}
+}
+
+func TestStorage_NormalizesKeys(t *testing.T) {
+	for name, s := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			_, err := s.Put(ctx, "chapters/abc/./001.jpg", strings.NewReader("x"), 1, "image/jpeg")
+			require.NoError(t, err)
+
+			for _, key := range []string{"chapters/abc/001.jpg", "chapters//abc/001.jpg", "chapters/abd/../abc/001.jpg"} {
+				info, err := s.Stat(ctx, key)
+				require.NoError(t, err, key)
+				assert.Equal(t, "chapters/abc/001.jpg", info.Key, key)
+				r, err := s.Get(ctx, key)
+				require.NoError(t, err, key)
+				r.Close()
+			}
+			_, err = s.Stat(ctx, "../chapters/abc/001.jpg")
+			assert.Error(t, err)
+			assert.Error(t, s.Delete(ctx, "../chapters/abc/001.jpg"))
+
+			require.NoError(t, s.Delete(ctx, "chapters/./abc/001.jpg"))
+			_, err = s.Stat(ctx, "chapters/abc/001.jpg")
+			assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+		})
+	}
+}
+
+func TestStorage_ListPrefixes(t *testing.T) {
+	for name, s := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			for _, key := range []string{"chapters/abc/001.jpg", "chapters/abd/001.jpg", "covers/abc.png"} {
+				_, err := s.Put(ctx, key, strings.NewReader("x"), 1, "image/jpeg")
+				require.NoError(t, err)
+			}
+
+			keys := func(prefix string) []string {
+				objects, err := s.List(ctx, prefix)
+				require.NoError(t, err, prefix)
+				var keys []string
+				for _, object := range objects {
+					keys = append(keys, object.Key)
+				}
+				return keys
+			}
+			assert.ElementsMatch(t, []string{"chapters/abc/001.jpg", "chapters/abd/001.jpg"}, keys("chapters/ab"))
+			assert.Equal(t, []string{"chapters/abc/001.jpg"}, keys("chapters/abc/"))
+			assert.Len(t, keys(""), 3)
+			assert.Empty(t, keys("pages/"))
+			assert.Empty(t, keys("chapters/missing/"))
+		})
+	}
+}